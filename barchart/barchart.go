@@ -5,11 +5,14 @@
 package barchart
 
 import (
+	"image"
 	"math"
 
 	"github.com/NimbleMarkets/ntcharts/canvas"
 	"github.com/NimbleMarkets/ntcharts/canvas/buffer"
 	"github.com/NimbleMarkets/ntcharts/canvas/graph"
+	"github.com/NimbleMarkets/ntcharts/canvas/runes"
+	"github.com/NimbleMarkets/ntcharts/legend"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -250,6 +253,36 @@ func (m *Model) Height() int {
 	return m.Canvas.Height()
 }
 
+// GraphArea returns the canvas area of the bars,
+// which excludes the axis and labels.
+func (m *Model) GraphArea() image.Rectangle {
+	w, h := m.Canvas.Width(), m.Canvas.Height()
+	switch {
+	case !m.showAxis:
+		return image.Rect(0, 0, w, h)
+	case m.horizontal:
+		return image.Rect(m.origin.X+1, 0, w, h)
+	default:
+		return image.Rect(0, 0, w, m.origin.Y)
+	}
+}
+
+// LegendEntries returns a legend entry with a full block marker
+// for each distinct bar segment name in order of appearance.
+func (m *Model) LegendEntries() (r []legend.Entry) {
+	seen := make(map[string]bool)
+	for _, ds := range m.data {
+		for _, v := range ds.bd.Values {
+			if (v.Name == "") || seen[v.Name] {
+				continue
+			}
+			seen[v.Name] = true
+			r = append(r, legend.Entry{Name: v.Name, Marker: string(runes.FullBlock), Style: v.Style})
+		}
+	}
+	return
+}
+
 // MaxValue returns expected maximum data value.
 func (m *Model) MaxValue() float64 {
 	return m.max
@@ -7,127 +7,171 @@ import (
 	"github.com/NimbleMarkets/ntcharts/canvas"
 )
 
-// Float64ScaleBuffer is a variable size buffer
-// that stores float64 data values and a scaled version of the data.
-// Scaling is done by multiplying incoming values
-// by a constant scale factor.
-type Float64ScaleBuffer struct {
-	buf    []float64 // original data
-	sbuf   []float64 // scaled data
-	offset float64   // offset to subtract
-	scale  float64   // scaling factor
-}
-
-// NewFloat64ScaleBuffer returns *Float64ScaleBuffer initialized to default settings.
-func NewFloat64ScaleBuffer(o, sc float64) *Float64ScaleBuffer {
-	return &Float64ScaleBuffer{
-		buf:    []float64{},
-		sbuf:   []float64{},
-		offset: o,
-		scale:  sc,
+// ScaleFunc returns a scaled value of type T using
+// a given value, offset and scaling factor.
+// Buffers with a nil ScaleFunc store data values unscaled.
+type ScaleFunc[T any] func(v, offset, scale T) T
+
+// ScaleFloat64 is a ScaleFunc for float64 values that
+// subtracts the offset and then multiplies by the scaling factor.
+func ScaleFloat64(v, offset, scale float64) float64 {
+	return (v - offset) * scale
+}
+
+// ScaleFloat64Point is a ScaleFunc for Float64Point values that subtracts
+// the offset and then multiplies by the scaling factor for both X and Y values.
+func ScaleFloat64Point(v, offset, scale canvas.Float64Point) canvas.Float64Point {
+	return v.Sub(offset).Mul(scale)
+}
+
+// ScaleBuffer is a variable size buffer
+// that stores data values of type T and a scaled version of the data.
+// Scaling is done by the ScaleFunc using a constant offset and scale factor.
+type ScaleBuffer[T any] struct {
+	buf     []T          // original data
+	sbuf    []T          // scaled data
+	offset  T            // offset to subtract
+	scale   T            // scaling factor
+	scaleFn ScaleFunc[T] // function scaling data
+}
+
+// NewScaleBuffer returns *ScaleBuffer initialized with
+// given offset, scale factor and ScaleFunc, which may be nil
+// to store data values unscaled.
+func NewScaleBuffer[T any](o, sc T, fn ScaleFunc[T]) *ScaleBuffer[T] {
+	return &ScaleBuffer[T]{
+		buf:     []T{},
+		sbuf:    []T{},
+		offset:  o,
+		scale:   sc,
+		scaleFn: fn,
 	}
 }
 
 // Clear resets buffer contents.
-func (b *Float64ScaleBuffer) Clear() {
-	b.buf = []float64{}
-	b.sbuf = []float64{}
+func (b *ScaleBuffer[T]) Clear() {
+	b.buf = []T{}
+	b.sbuf = []T{}
 }
 
 // Length returns number of data in buffer.
-func (b *Float64ScaleBuffer) Length() int {
+func (b *ScaleBuffer[T]) Length() int {
 	return len(b.buf)
 }
 
 // Scale returns scaling factor.
-func (b *Float64ScaleBuffer) Scale() float64 {
+func (b *ScaleBuffer[T]) Scale() T {
 	return b.scale
 }
 
-// ScaleDatum returns a scaled float64 using
-// internal buffer scaling from a given float64.
-func (b *Float64ScaleBuffer) ScaleDatum(f float64) float64 {
-	return (f - b.offset) * b.scale
+// ScaleDatum returns a scaled value using
+// internal buffer scaling from a given value,
+// or the given value if the ScaleFunc is nil.
+func (b *ScaleBuffer[T]) ScaleDatum(v T) T {
+	if b.scaleFn == nil {
+		return v
+	}
+	return b.scaleFn(v, b.offset, b.scale)
 }
 
 // SetScale updates scaling factor and recomputes all scaled data.
-func (b *Float64ScaleBuffer) SetScale(sc float64) {
+func (b *ScaleBuffer[T]) SetScale(sc T) {
 	b.scale = sc
-	b.sbuf = make([]float64, 0, len(b.buf))
-	for _, v := range b.buf {
-		b.sbuf = append(b.sbuf, b.ScaleDatum(v))
-	}
+	b.rescale()
 }
 
 // Offset returns data value offset.
-func (b *Float64ScaleBuffer) Offset() float64 {
+func (b *ScaleBuffer[T]) Offset() T {
 	return b.offset
 }
 
 // SetOffset updates offset and recomputes all scaled data.
-func (b *Float64ScaleBuffer) SetOffset(o float64) {
+func (b *ScaleBuffer[T]) SetOffset(o T) {
+	b.offset = o
+	b.rescale()
+}
+
+// SetScaleFunc updates the ScaleFunc and recomputes all scaled data.
+func (b *ScaleBuffer[T]) SetScaleFunc(fn ScaleFunc[T]) {
+	b.scaleFn = fn
+	b.rescale()
+}
+
+// SetOffsetAndScale updates both offset and scaling factor
+// and recomputes all scaled data once.
+func (b *ScaleBuffer[T]) SetOffsetAndScale(o, sc T) {
 	b.offset = o
-	b.sbuf = make([]float64, 0, len(b.buf))
+	b.scale = sc
+	b.rescale()
+}
+
+// rescale recomputes all scaled data.
+func (b *ScaleBuffer[T]) rescale() {
+	b.sbuf = make([]T, 0, len(b.buf))
 	for _, v := range b.buf {
 		b.sbuf = append(b.sbuf, b.ScaleDatum(v))
 	}
 }
 
-// Push adds Float64Point data to the back of the buffer.
-func (b *Float64ScaleBuffer) Push(p float64) {
-	b.buf = append(b.buf, p)
-	b.sbuf = append(b.sbuf, b.ScaleDatum(p))
+// Push adds data to the back of the buffer.
+func (b *ScaleBuffer[T]) Push(v T) {
+	b.buf = append(b.buf, v)
+	b.sbuf = append(b.sbuf, b.ScaleDatum(v))
 }
 
-// Pop erases the oldest Float64Point from the buffer.
-func (b *Float64ScaleBuffer) Pop() {
+// Pop erases the oldest data from the buffer.
+// Does nothing if the buffer is empty.
+func (b *ScaleBuffer[T]) Pop() {
+	if len(b.buf) == 0 {
+		return
+	}
 	b.buf = b.buf[1:]
 	b.sbuf = b.sbuf[1:]
 }
 
 // SetData sets contents of internal buffer
-// to given []float64 and scales the data.
-func (b *Float64ScaleBuffer) SetData(d []float64) {
-	b.buf = make([]float64, 0, len(d))
-	b.sbuf = make([]float64, 0, len(d))
-	for _, p := range d {
-		b.buf = append(b.buf, p)
-		b.sbuf = append(b.sbuf, b.ScaleDatum(p))
+// to given []T and scales the data.
+func (b *ScaleBuffer[T]) SetData(d []T) {
+	b.buf = make([]T, 0, len(d))
+	b.sbuf = make([]T, 0, len(d))
+	for _, v := range d {
+		b.buf = append(b.buf, v)
+		b.sbuf = append(b.sbuf, b.ScaleDatum(v))
 	}
 }
 
 // ReadAll returns entire scaled data buffer.
-func (b *Float64ScaleBuffer) ReadAll() []float64 {
+func (b *ScaleBuffer[T]) ReadAll() []T {
 	return b.sbuf
 }
 
 // ReadAllRaw returns entire original data buffer.
-func (b *Float64ScaleBuffer) ReadAllRaw() []float64 {
+func (b *ScaleBuffer[T]) ReadAllRaw() []T {
 	return b.buf
 }
 
-// At returns Float64Point of scaled data at index i of buffer.
-func (b *Float64ScaleBuffer) At(i int) float64 {
+// At returns scaled data at index i of buffer.
+func (b *ScaleBuffer[T]) At(i int) T {
 	return b.sbuf[i]
 }
 
-// AtRaw returns Float64Point of original data at index i of buffer.
-func (b *Float64ScaleBuffer) AtRaw(i int) float64 {
+// AtRaw returns original data at index i of buffer.
+func (b *ScaleBuffer[T]) AtRaw(i int) T {
 	return b.buf[i]
 }
 
-// Float64ScaleRingBuffer is a fix-sized ring buffer
-// that stores float64 data values and a scaled version of the data.
-// Scaling is done by first subtracting by the offset and then multiplying
-// incoming values by a constant scale factor.
+// ScaleRingBuffer is a fix-sized ring buffer
+// that stores data values of type T and a scaled version of the data.
+// Scaling is done by the ScaleFunc using a constant offset and scale factor.
 // Unlike traditional ring buffers, pushing data to the buffer
 // while at full capacity will erase the oldest datum
 // from the buffer to create room for writing.
-type Float64ScaleRingBuffer struct {
-	buf    []float64 // original data
-	sbuf   []float64 // scaled data
-	offset float64   // offset to subtract
-	scale  float64   // scaling factor
+type ScaleRingBuffer[T any] struct {
+	buf     []T          // original data
+	sbuf    []T          // scaled data
+	offset  T            // offset to subtract
+	scale   T            // scaling factor
+	scaleFn ScaleFunc[T] // function scaling data
 
 	length int // number of elements
 	sz     int // capacitiy
@@ -136,110 +180,193 @@ type Float64ScaleRingBuffer struct {
 	rIdx int // read index
 }
 
-// NewFloat64ScaleRingBuffer returns *Float64ScaleRingBuffer initialized to default settings.
-func NewFloat64ScaleRingBuffer(s int, o, sc float64) *Float64ScaleRingBuffer {
-	return &Float64ScaleRingBuffer{
-		buf:    make([]float64, s),
-		sbuf:   make([]float64, s),
-		offset: o,
-		scale:  sc,
-		sz:     s,
-		wIdx:   0,
-		rIdx:   0}
+// NewScaleRingBuffer returns *ScaleRingBuffer initialized with
+// given capacity, offset, scale factor and ScaleFunc, which may be nil
+// to store data values unscaled.
+func NewScaleRingBuffer[T any](s int, o, sc T, fn ScaleFunc[T]) *ScaleRingBuffer[T] {
+	if s < 0 {
+		s = 0
+	}
+	return &ScaleRingBuffer[T]{
+		buf:     make([]T, s),
+		sbuf:    make([]T, s),
+		offset:  o,
+		scale:   sc,
+		scaleFn: fn,
+		sz:      s,
+		wIdx:    0,
+		rIdx:    0}
 }
 
 // Clear resets buffer contents.
-func (b *Float64ScaleRingBuffer) Clear() {
+func (b *ScaleRingBuffer[T]) Clear() {
 	b.length = 0
 	b.wIdx = 0
 	b.rIdx = 0
 }
 
 // Length returns number of data in buffer.
-func (b *Float64ScaleRingBuffer) Length() int {
+func (b *ScaleRingBuffer[T]) Length() int {
 	return b.length
 }
 
 // Size returns buffer capacity.
-func (b *Float64ScaleRingBuffer) Size() int {
+func (b *ScaleRingBuffer[T]) Size() int {
 	return b.sz
 }
 
+// Resize changes buffer capacity to given size.
+// If the new capacity is smaller than the number of data in buffer,
+// then the oldest data will be erased to fit the newest data.
+func (b *ScaleRingBuffer[T]) Resize(s int) {
+	if s < 0 {
+		s = 0
+	}
+	raw := b.getBuffer(b.buf)
+	scaled := b.getBuffer(b.sbuf)
+	if len(raw) > s {
+		raw = raw[len(raw)-s:]
+		scaled = scaled[len(scaled)-s:]
+	}
+	b.buf = make([]T, s)
+	b.sbuf = make([]T, s)
+	copy(b.buf, raw)
+	copy(b.sbuf, scaled)
+	b.sz = s
+	b.length = len(raw)
+	b.rIdx = 0
+	b.wIdx = b.length
+	if b.wIdx >= b.sz {
+		b.wIdx = 0
+	}
+}
+
 // Scale returns scaling factor.
-func (b *Float64ScaleRingBuffer) Scale() float64 {
+func (b *ScaleRingBuffer[T]) Scale() T {
 	return b.scale
 }
 
-// ScaleDatum returns a scaled float64 using
-// internal buffer scaling from a given float64.
-func (b *Float64ScaleRingBuffer) ScaleDatum(f float64) float64 {
-	return (f - b.offset) * b.scale
+// ScaleDatum returns a scaled value using
+// internal buffer scaling from a given value,
+// or the given value if the ScaleFunc is nil.
+func (b *ScaleRingBuffer[T]) ScaleDatum(v T) T {
+	if b.scaleFn == nil {
+		return v
+	}
+	return b.scaleFn(v, b.offset, b.scale)
 }
 
 // SetScale updates scaling factor and recomputes all scaled data.
-func (b *Float64ScaleRingBuffer) SetScale(sc float64) {
+func (b *ScaleRingBuffer[T]) SetScale(sc T) {
 	b.scale = sc
-	for i, v := range b.buf {
-		b.sbuf[i] = b.ScaleDatum(v)
-	}
+	b.rescale()
 }
 
 // Offset returns data value offset.
-func (b *Float64ScaleRingBuffer) Offset() float64 {
+func (b *ScaleRingBuffer[T]) Offset() T {
 	return b.offset
 }
 
 // SetOffset updates offset and recomputes all scaled data.
-func (b *Float64ScaleRingBuffer) SetOffset(o float64) {
+func (b *ScaleRingBuffer[T]) SetOffset(o T) {
+	b.offset = o
+	b.rescale()
+}
+
+// SetScaleFunc updates the ScaleFunc and recomputes all scaled data.
+func (b *ScaleRingBuffer[T]) SetScaleFunc(fn ScaleFunc[T]) {
+	b.scaleFn = fn
+	b.rescale()
+}
+
+// SetOffsetAndScale updates both offset and scaling factor
+// and recomputes all scaled data once.
+func (b *ScaleRingBuffer[T]) SetOffsetAndScale(o, sc T) {
 	b.offset = o
-	for i, v := range b.buf {
-		b.sbuf[i] = b.ScaleDatum(v)
+	b.scale = sc
+	b.rescale()
+}
+
+// rescale recomputes scaled data for all stored data.
+func (b *ScaleRingBuffer[T]) rescale() {
+	idx := b.rIdx
+	for i := 0; i < b.length; i++ {
+		b.sbuf[idx] = b.ScaleDatum(b.buf[idx])
+		idx = b.next(idx)
 	}
 }
 
-// Push adds float64 data to the back of the buffer.
-func (b *Float64ScaleRingBuffer) Push(f float64) {
-	b.buf[b.wIdx] = f
-	b.sbuf[b.wIdx] = b.ScaleDatum(f)
-	b.wIdx++
-	if b.wIdx >= b.sz {
-		b.wIdx = 0
+// next returns the buffer index following given index.
+func (b *ScaleRingBuffer[T]) next(i int) int {
+	i++
+	if i >= b.sz {
+		i = 0
+	}
+	return i
+}
+
+// Push adds data to the back of the buffer.
+// Does nothing if the buffer capacity is zero.
+func (b *ScaleRingBuffer[T]) Push(v T) {
+	if b.sz == 0 {
+		return
 	}
+	b.buf[b.wIdx] = v
+	b.sbuf[b.wIdx] = b.ScaleDatum(v)
+	b.wIdx = b.next(b.wIdx)
 	if b.length == b.sz { // on full buffer, just increment read index
-		b.rIdx++
-		if b.rIdx >= b.sz {
-			b.rIdx = 0
-		}
+		b.rIdx = b.next(b.rIdx)
 	} else {
 		b.length++
 	}
 }
 
-// Pop erases the oldest float64 from the buffer.
-func (b *Float64ScaleRingBuffer) Pop() {
-	b.rIdx++
-	if b.rIdx >= b.sz {
-		b.rIdx = 0
+// Pop erases the oldest data from the buffer.
+// Does nothing if the buffer is empty.
+func (b *ScaleRingBuffer[T]) Pop() {
+	if b.length == 0 {
+		return
 	}
+	b.rIdx = b.next(b.rIdx)
 	b.length--
 }
 
 // ReadAll returns entire scaled data buffer.
-func (b *Float64ScaleRingBuffer) ReadAll() []float64 {
+func (b *ScaleRingBuffer[T]) ReadAll() []T {
 	return b.getBuffer(b.sbuf)
 }
 
 // ReadAllRaw returns entire original data buffer.
-func (b *Float64ScaleRingBuffer) ReadAllRaw() []float64 {
+func (b *ScaleRingBuffer[T]) ReadAllRaw() []T {
 	return b.getBuffer(b.buf)
 }
 
-func (b *Float64ScaleRingBuffer) getBuffer(buf []float64) (f []float64) {
+// At returns scaled data at index i of buffer
+// where index 0 is the oldest data.
+func (b *ScaleRingBuffer[T]) At(i int) T {
+	return b.sbuf[b.index(i)]
+}
+
+// AtRaw returns original data at index i of buffer
+// where index 0 is the oldest data.
+func (b *ScaleRingBuffer[T]) AtRaw(i int) T {
+	return b.buf[b.index(i)]
+}
+
+// index returns internal buffer index from given data index.
+func (b *ScaleRingBuffer[T]) index(i int) int {
+	if (i < 0) || (i >= b.length) {
+		panic("buffer: ScaleRingBuffer index out of range")
+	}
+	return (b.rIdx + i) % b.sz
+}
+
+func (b *ScaleRingBuffer[T]) getBuffer(buf []T) (f []T) {
 	sz := b.sz
 	ln := b.length
 	idx := b.rIdx
 
-	f = make([]float64, 0, sz)
+	f = make([]T, 0, sz)
 	for i := 0; i < ln; i++ {
 		f = append(f, buf[idx])
 		idx++
@@ -250,111 +377,46 @@ func (b *Float64ScaleRingBuffer) getBuffer(buf []float64) (f []float64) {
 	return
 }
 
-// Float64PointScaleBuffer is a variable size buffer
-// that stores Float64Points and a scaled version of the Float64Points.
-// Scaling is done by multiplying incoming values (X,Y) coordinates
-// by a constant scale factor.
-type Float64PointScaleBuffer struct {
-	buf     []canvas.Float64Point // original data
-	sbuf    []canvas.Float64Point // scaled data
-	offsetP canvas.Float64Point   // offset to subtract X,Y values from
-	scale   canvas.Float64Point   // scaling factor for X,Y values
-}
-
-// NewFloat64PointScaleBuffer returns *Float64PointScaleBuffer initialized to default settings.
-func NewFloat64PointScaleBuffer(o, sc canvas.Float64Point) *Float64PointScaleBuffer {
-	return &Float64PointScaleBuffer{
-		buf:     []canvas.Float64Point{},
-		sbuf:    []canvas.Float64Point{},
-		offsetP: o,
-		scale:   sc,
-	}
-}
-
-// Clear resets buffer contents.
-func (b *Float64PointScaleBuffer) Clear() {
-	b.buf = []canvas.Float64Point{}
-	b.sbuf = []canvas.Float64Point{}
-}
-
-// Length returns number of data in buffer.
-func (b *Float64PointScaleBuffer) Length() int {
-	return len(b.buf)
-}
-
-// Scale returns Float64Point used to multiple data points by.
-func (b *Float64PointScaleBuffer) Scale() canvas.Float64Point {
-	return b.scale
-}
-
-// ScaleDatum returns a scaled Float64Point using
-// internal buffer scaling from a given Float64Point.
-func (b *Float64PointScaleBuffer) ScaleDatum(f canvas.Float64Point) canvas.Float64Point {
-	return f.Sub(b.offsetP).Mul(b.scale)
-}
-
-// SetScale updates scaling factor and recomputes all scaled data.
-func (b *Float64PointScaleBuffer) SetScale(sc canvas.Float64Point) {
-	b.scale = sc
-	b.sbuf = make([]canvas.Float64Point, 0, len(b.buf))
-	for _, v := range b.buf {
-		b.sbuf = append(b.sbuf, b.ScaleDatum(v))
-	}
-}
-
-// Offset returns Float64Point used to subtract data points from.
-func (b *Float64PointScaleBuffer) Offset() canvas.Float64Point {
-	return b.scale
-}
-
-// SetOffset updates offsets and recomputes all scaled data.
-func (b *Float64PointScaleBuffer) SetOffset(o canvas.Float64Point) {
-	b.offsetP = o
-	b.sbuf = make([]canvas.Float64Point, 0, len(b.buf))
-	for _, v := range b.buf {
-		b.sbuf = append(b.sbuf, b.ScaleDatum(v))
-	}
-}
+// Float64ScaleBuffer is a variable size buffer
+// that stores float64 data values and a scaled version of the data.
+// Scaling is done by first subtracting by the offset and then multiplying
+// incoming values by a constant scale factor.
+type Float64ScaleBuffer = ScaleBuffer[float64]
 
-// Push adds Float64Point data to the back of the buffer.
-func (b *Float64PointScaleBuffer) Push(p canvas.Float64Point) {
-	b.buf = append(b.buf, p)
-	b.sbuf = append(b.sbuf, b.ScaleDatum(p))
+// NewFloat64ScaleBuffer returns *Float64ScaleBuffer initialized to default settings.
+func NewFloat64ScaleBuffer(o, sc float64) *Float64ScaleBuffer {
+	return NewScaleBuffer(o, sc, ScaleFloat64)
 }
 
-// Pop erases the oldest Float64Point from the buffer.
-func (b *Float64PointScaleBuffer) Pop() {
-	b.buf = b.buf[1:]
-	b.sbuf = b.sbuf[1:]
-}
+// Float64ScaleRingBuffer is a fix-sized ring buffer
+// that stores float64 data values and a scaled version of the data.
+// Scaling is done by first subtracting by the offset and then multiplying
+// incoming values by a constant scale factor.
+type Float64ScaleRingBuffer = ScaleRingBuffer[float64]
 
-// SetData sets contents of internal buffer
-// to given []float64 and scales the data.
-func (b *Float64PointScaleBuffer) SetData(d []canvas.Float64Point) {
-	b.buf = make([]canvas.Float64Point, 0, len(d))
-	b.sbuf = make([]canvas.Float64Point, 0, len(d))
-	for _, p := range d {
-		b.buf = append(b.buf, p)
-		b.sbuf = append(b.sbuf, b.ScaleDatum(p))
-	}
+// NewFloat64ScaleRingBuffer returns *Float64ScaleRingBuffer initialized to default settings.
+func NewFloat64ScaleRingBuffer(s int, o, sc float64) *Float64ScaleRingBuffer {
+	return NewScaleRingBuffer(s, o, sc, ScaleFloat64)
 }
 
-// ReadAll returns entire scaled data buffer.
-func (b *Float64PointScaleBuffer) ReadAll() []canvas.Float64Point {
-	return b.sbuf
-}
+// Float64PointScaleBuffer is a variable size buffer
+// that stores Float64Points and a scaled version of the Float64Points.
+// Scaling is done by first subtracting by the offset and then multiplying
+// incoming (X,Y) coordinates by a constant scale factor.
+type Float64PointScaleBuffer = ScaleBuffer[canvas.Float64Point]
 
-// ReadAllRaw returns entire original data buffer.
-func (b *Float64PointScaleBuffer) ReadAllRaw() []canvas.Float64Point {
-	return b.buf
+// NewFloat64PointScaleBuffer returns *Float64PointScaleBuffer initialized to default settings.
+func NewFloat64PointScaleBuffer(o, sc canvas.Float64Point) *Float64PointScaleBuffer {
+	return NewScaleBuffer(o, sc, ScaleFloat64Point)
 }
 
-// At returns Float64Point of scaled data at index i of buffer.
-func (b *Float64PointScaleBuffer) At(i int) canvas.Float64Point {
-	return b.sbuf[i]
-}
+// Float64PointScaleRingBuffer is a fix-sized ring buffer
+// that stores Float64Points and a scaled version of the Float64Points.
+// Scaling is done by first subtracting by the offset and then multiplying
+// incoming (X,Y) coordinates by a constant scale factor.
+type Float64PointScaleRingBuffer = ScaleRingBuffer[canvas.Float64Point]
 
-// AtRaw returns Float64Point of original data at index i of buffer.
-func (b *Float64PointScaleBuffer) AtRaw(i int) canvas.Float64Point {
-	return b.buf[i]
+// NewFloat64PointScaleRingBuffer returns *Float64PointScaleRingBuffer initialized to default settings.
+func NewFloat64PointScaleRingBuffer(s int, o, sc canvas.Float64Point) *Float64PointScaleRingBuffer {
+	return NewScaleRingBuffer(s, o, sc, ScaleFloat64Point)
 }
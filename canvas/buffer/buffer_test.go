@@ -171,6 +171,41 @@ func TestFloat64ScaleRingBufferRescale(t *testing.T) {
 	}
 }
 
+func TestFloat64ScaleRingBufferResize(t *testing.T) {
+	buf := NewFloat64ScaleRingBuffer(3, 0, 2)
+	for i := 1; i <= 5; i++ {
+		buf.Push(float64(i))
+	}
+	// grow keeps all data in order and allows more data
+	buf.Resize(5)
+	buf.Push(6)
+	buf.Push(7)
+	exp := []float64{3, 4, 5, 6, 7}
+	if (buf.Size() != 5) || (buf.Length() != len(exp)) {
+		t.Errorf("Float64ScaleRingBuffer wrong size or length after grow:%d,%d", buf.Size(), buf.Length())
+	}
+	for i, v := range buf.ReadAllRaw() {
+		if v != exp[i] {
+			t.Errorf("Float64ScaleRingBuffer returned wrong value after grow:%f, expected %f", v, exp[i])
+		}
+		if buf.At(i) != exp[i]*2 {
+			t.Errorf("Float64ScaleRingBuffer returned wrong scaled value after grow:%f, expected %f", buf.At(i), exp[i]*2)
+		}
+	}
+	// shrink keeps newest data
+	buf.Resize(2)
+	buf.Push(8)
+	exp = []float64{7, 8}
+	if (buf.Size() != 2) || (buf.Length() != len(exp)) {
+		t.Errorf("Float64ScaleRingBuffer wrong size or length after shrink:%d,%d", buf.Size(), buf.Length())
+	}
+	for i, v := range buf.ReadAllRaw() {
+		if v != exp[i] {
+			t.Errorf("Float64ScaleRingBuffer returned wrong value after shrink:%f, expected %f", v, exp[i])
+		}
+	}
+}
+
 func TestFloat64ScaleBuffer(t *testing.T) {
 	offset := -10.0
 	scale := .5
@@ -426,6 +461,73 @@ func TestFloat64PointScaleBufferRescale(t *testing.T) {
 	}
 }
 
+func TestFloat64PointScaleRingBuffer(t *testing.T) {
+	sz := 3
+	sf := canvas.Float64Point{X: 2, Y: 0.5}
+	offset := canvas.Float64Point{X: 1, Y: 1}
+	buf := NewFloat64PointScaleRingBuffer(sz, offset, sf)
+	if buf.Offset() != offset {
+		t.Errorf("Float64PointScaleRingBuffer returned wrong offset:%f, expected %f", buf.Offset(), offset)
+	}
+	buf.Pop() // popping empty buffer does nothing
+	if buf.Length() != 0 {
+		t.Errorf("Float64PointScaleRingBuffer wrong length after empty pop:%d", buf.Length())
+	}
+
+	seq := []canvas.Float64Point{{X: 1, Y: 2}, {X: 2, Y: 4}, {X: 3, Y: 6}, {X: 4, Y: 8}, {X: 5, Y: 10}}
+	for _, v := range seq {
+		buf.Push(v)
+	}
+	if buf.Length() != sz {
+		t.Errorf("Float64PointScaleRingBuffer wrong length:%d, expected %d", buf.Length(), sz)
+	}
+	// oldest values were overwritten
+	exp := seq[len(seq)-sz:]
+	raw := buf.ReadAllRaw()
+	for i, v := range exp {
+		if raw[i] != v || buf.AtRaw(i) != v {
+			t.Errorf("Float64PointScaleRingBuffer returned wrong original value:%f, expected %f", buf.AtRaw(i), v)
+		}
+		if s := v.Sub(offset).Mul(sf); buf.At(i) != s {
+			t.Errorf("Float64PointScaleRingBuffer returned wrong scaled value:%f, expected %f", buf.At(i), s)
+		}
+	}
+
+	// rescale
+	newOffset := canvas.Float64Point{X: 0, Y: 0}
+	buf.SetOffset(newOffset)
+	for i, v := range buf.ReadAll() {
+		if s := exp[i].Mul(sf); v != s {
+			t.Errorf("Float64PointScaleRingBuffer returned wrong rescaled value:%f, expected %f", v, s)
+		}
+	}
+
+	buf.Pop()
+	if (buf.Length() != sz-1) || (buf.AtRaw(0) != exp[1]) {
+		t.Errorf("Float64PointScaleRingBuffer wrong data after pop:%f", buf.AtRaw(0))
+	}
+}
+
+func TestNilScaleFunc(t *testing.T) {
+	// nil ScaleFunc stores data values unscaled
+	buf := NewScaleBuffer[float64](1, 2, nil)
+	buf.Push(3)
+	buf.SetOffsetAndScale(2, 4)
+	if (buf.At(0) != 3) || (buf.AtRaw(0) != 3) {
+		t.Errorf("ScaleBuffer with nil ScaleFunc returned wrong value:%f", buf.At(0))
+	}
+	rbuf := NewScaleRingBuffer[float64](2, 1, 2, nil)
+	rbuf.Push(3)
+	rbuf.SetScale(4)
+	if (rbuf.At(0) != 3) || (rbuf.AtRaw(0) != 3) {
+		t.Errorf("ScaleRingBuffer with nil ScaleFunc returned wrong value:%f", rbuf.At(0))
+	}
+	rbuf.SetScaleFunc(ScaleFloat64)
+	if rbuf.At(0) != 8 {
+		t.Errorf("ScaleRingBuffer returned wrong value after setting ScaleFunc:%f", rbuf.At(0))
+	}
+}
+
 const tolerance = 1e-9
 
 // floatEquals checks if two float64 values are equal within tolerance tolerance
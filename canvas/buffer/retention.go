@@ -0,0 +1,191 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package buffer
+
+// File contains a ring buffer of data points retained
+// by a maximum number of data points and a maximum X age.
+
+import (
+	"github.com/NimbleMarkets/ntcharts/canvas"
+)
+
+// minRetentionSize is the initial capacity of RetentionRingBuffers.
+const minRetentionSize = 64
+
+// EvictFunc is called with each data point removed
+// from a RetentionRingBuffer by its retention limits.
+type EvictFunc func(canvas.Float64Point)
+
+// RetentionRingBuffer stores data points and their scaled versions
+// in a Float64PointScaleRingBuffer in increasing X order, which grows
+// as needed and removes the oldest data points exceeding its retention
+// limits.  The retention limits are a maximum number of data points and
+// a maximum X distance of data points from the newest data point, where
+// 0 is unlimited.  Data points are only added and removed through the
+// retention limits, such that every removed data point is evicted.
+type RetentionRingBuffer struct {
+	buf       *Float64PointScaleRingBuffer // data points in increasing X order
+	maxPoints int                          // maximum number of data points, 0 if unlimited
+	maxXAge   float64                      // maximum X distance of data points from newest data point, 0 if unlimited
+}
+
+// NewRetentionRingBuffer returns *RetentionRingBuffer without retention
+// limits initialized with given offset, scale factor and ScaleFunc,
+// which may be nil to store data points unscaled.
+func NewRetentionRingBuffer(o, sc canvas.Float64Point, fn ScaleFunc[canvas.Float64Point]) *RetentionRingBuffer {
+	return &RetentionRingBuffer{
+		buf: NewScaleRingBuffer(0, o, sc, fn),
+	}
+}
+
+// Clear removes all data points without evicting them.
+func (b *RetentionRingBuffer) Clear() {
+	b.buf.Clear()
+}
+
+// Length returns the number of data points.
+func (b *RetentionRingBuffer) Length() int {
+	return b.buf.Length()
+}
+
+// Size returns the capacity of the buffer.
+func (b *RetentionRingBuffer) Size() int {
+	return b.buf.Size()
+}
+
+// At returns the scaled data point at given index from the oldest data point.
+func (b *RetentionRingBuffer) At(i int) canvas.Float64Point {
+	return b.buf.At(i)
+}
+
+// AtRaw returns the original data point at given index from the oldest data point.
+func (b *RetentionRingBuffer) AtRaw(i int) canvas.Float64Point {
+	return b.buf.AtRaw(i)
+}
+
+// ReadAll returns all scaled data points from oldest to newest.
+func (b *RetentionRingBuffer) ReadAll() []canvas.Float64Point {
+	return b.buf.ReadAll()
+}
+
+// ReadAllRaw returns all original data points from oldest to newest.
+func (b *RetentionRingBuffer) ReadAllRaw() []canvas.Float64Point {
+	return b.buf.ReadAllRaw()
+}
+
+// Offset returns the data point offset.
+func (b *RetentionRingBuffer) Offset() canvas.Float64Point {
+	return b.buf.Offset()
+}
+
+// Scale returns the scaling factor.
+func (b *RetentionRingBuffer) Scale() canvas.Float64Point {
+	return b.buf.Scale()
+}
+
+// ScaleDatum returns a scaled data point using
+// the buffer scaling from a given data point.
+func (b *RetentionRingBuffer) ScaleDatum(f canvas.Float64Point) canvas.Float64Point {
+	return b.buf.ScaleDatum(f)
+}
+
+// SetOffset updates the offset and recomputes all scaled data points.
+func (b *RetentionRingBuffer) SetOffset(o canvas.Float64Point) {
+	b.buf.SetOffset(o)
+}
+
+// SetScale updates the scaling factor and recomputes all scaled data points.
+func (b *RetentionRingBuffer) SetScale(sc canvas.Float64Point) {
+	b.buf.SetScale(sc)
+}
+
+// SetOffsetAndScale updates both offset and scaling factor
+// and recomputes all scaled data points once.
+func (b *RetentionRingBuffer) SetOffsetAndScale(o, sc canvas.Float64Point) {
+	b.buf.SetOffsetAndScale(o, sc)
+}
+
+// SetScaleFunc updates the ScaleFunc and recomputes all scaled data points.
+func (b *RetentionRingBuffer) SetScaleFunc(fn ScaleFunc[canvas.Float64Point]) {
+	b.buf.SetScaleFunc(fn)
+}
+
+// MaxPoints returns the maximum number of data points, 0 if unlimited.
+func (b *RetentionRingBuffer) MaxPoints() int {
+	return b.maxPoints
+}
+
+// SetMaxPoints sets the maximum number of data points, 0 if unlimited,
+// and removes the oldest data points exceeding the new limit.
+// Removed data points are given to evict if not nil.
+func (b *RetentionRingBuffer) SetMaxPoints(n int, evict EvictFunc) {
+	b.maxPoints = max(n, 0)
+	if b.maxPoints == 0 {
+		return
+	}
+	for b.Length() > b.maxPoints {
+		b.evictOldest(evict)
+	}
+	if b.Size() > b.maxPoints {
+		b.buf.Resize(b.maxPoints)
+	}
+}
+
+// MaxXAge returns the maximum X distance of data points
+// from the newest data point, 0 if unlimited.
+func (b *RetentionRingBuffer) MaxXAge() float64 {
+	return b.maxXAge
+}
+
+// SetMaxXAge sets the maximum X distance of data points from the newest
+// data point, 0 if unlimited, and removes data points exceeding the new limit.
+// Removed data points are given to evict if not nil.
+func (b *RetentionRingBuffer) SetMaxXAge(a float64, evict EvictFunc) {
+	b.maxXAge = max(a, 0)
+	b.evictAged(evict)
+}
+
+// Push adds a data point to the back of the buffer, growing the buffer
+// when full, and removes the oldest data points exceeding the retention
+// limits.  Removed data points are given to evict if not nil.
+func (b *RetentionRingBuffer) Push(f canvas.Float64Point, evict EvictFunc) {
+	if b.maxPoints > 0 {
+		for b.Length() >= b.maxPoints {
+			b.evictOldest(evict)
+		}
+	}
+	if b.Length() == b.Size() { // grow buffer when full
+		sz := max(b.Size()*2, minRetentionSize)
+		if b.maxPoints > 0 {
+			sz = min(sz, b.maxPoints)
+		}
+		b.buf.Resize(sz)
+	}
+	b.buf.Push(f)
+	b.evictAged(evict)
+}
+
+// evictOldest removes the oldest data point and gives it to evict if not nil.
+func (b *RetentionRingBuffer) evictOldest(evict EvictFunc) {
+	if b.Length() == 0 {
+		return
+	}
+	f := b.AtRaw(0)
+	b.buf.Pop()
+	if evict != nil {
+		evict(f)
+	}
+}
+
+// evictAged removes the oldest data points with X values less than
+// the newest data point X value by more than the maximum X age.
+func (b *RetentionRingBuffer) evictAged(evict EvictFunc) {
+	l := b.Length()
+	if (b.maxXAge <= 0) || (l == 0) {
+		return
+	}
+	min := b.AtRaw(l-1).X - b.maxXAge
+	for (b.Length() > 0) && (b.AtRaw(0).X < min) {
+		b.evictOldest(evict)
+	}
+}
@@ -0,0 +1,65 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package buffer
+
+import (
+	"testing"
+
+	"github.com/NimbleMarkets/ntcharts/canvas"
+)
+
+func TestRetentionRingBuffer(t *testing.T) {
+	var evicted []float64
+	evict := func(f canvas.Float64Point) { evicted = append(evicted, f.X) }
+	buf := NewRetentionRingBuffer(canvas.Float64Point{}, canvas.Float64Point{X: 1, Y: 1}, ScaleFloat64Point)
+
+	// unlimited buffer grows as needed
+	for i := 0; i < 100; i++ {
+		buf.Push(canvas.Float64Point{X: float64(i), Y: float64(i)}, evict)
+	}
+	if (buf.Length() != 100) || (len(evicted) != 0) {
+		t.Errorf("unlimited buffer wrong length or evicted:%d,%v", buf.Length(), evicted)
+	}
+
+	// lowering maximum points removes oldest data points and shrinks buffer
+	buf.SetMaxPoints(10, evict)
+	if (buf.Length() != 10) || (buf.Size() != 10) || (len(evicted) != 90) || (evicted[89] != 89) {
+		t.Errorf("max points wrong length, size or evicted:%d,%d,%d", buf.Length(), buf.Size(), len(evicted))
+	}
+	evicted = nil
+	buf.Push(canvas.Float64Point{X: 100, Y: 100}, evict)
+	if (buf.Length() != 10) || (len(evicted) != 1) || (evicted[0] != 90) || (buf.AtRaw(0).X != 91) {
+		t.Errorf("push over max points wrong length or evicted:%d,%v", buf.Length(), evicted)
+	}
+
+	// maximum X age removes data points older than newest data point
+	evicted = nil
+	buf.SetMaxXAge(3, evict)
+	if (buf.Length() != 4) || (len(evicted) != 6) || (buf.AtRaw(0).X != 97) {
+		t.Errorf("max X age wrong length or evicted:%d,%v", buf.Length(), evicted)
+	}
+	evicted = nil
+	buf.Push(canvas.Float64Point{X: 110, Y: 110}, nil) // nil evict is ignored
+	if (buf.Length() != 1) || (len(evicted) != 0) || (buf.AtRaw(0).X != 110) {
+		t.Errorf("push over max X age wrong length:%d", buf.Length())
+	}
+}
+
+func TestRetentionRingBufferScale(t *testing.T) {
+	buf := NewRetentionRingBuffer(canvas.Float64Point{}, canvas.Float64Point{X: 1, Y: 1}, ScaleFloat64Point)
+	buf.Push(canvas.Float64Point{X: 2, Y: 3}, nil)
+	buf.SetOffsetAndScale(canvas.Float64Point{X: 1, Y: 1}, canvas.Float64Point{X: 2, Y: 2})
+	if f := buf.At(0); (f.X != 2) || (f.Y != 4) {
+		t.Errorf("wrong scaled data point:%v", f)
+	}
+	if f := buf.AtRaw(0); (f.X != 2) || (f.Y != 3) {
+		t.Errorf("wrong original data point:%v", f)
+	}
+
+	// nil ScaleFunc stores data points unscaled
+	buf = NewRetentionRingBuffer(canvas.Float64Point{X: 1, Y: 1}, canvas.Float64Point{X: 2, Y: 2}, nil)
+	buf.Push(canvas.Float64Point{X: 2, Y: 3}, nil)
+	if f := buf.At(0); (f.X != 2) || (f.Y != 3) {
+		t.Errorf("wrong data point with nil ScaleFunc:%v", f)
+	}
+}
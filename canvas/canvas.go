@@ -384,6 +384,58 @@ func (m *Model) FillLine(y int, c Cell) {
 	}
 }
 
+// FloodFill sets all Cells connected to the Cell at (X,Y) coordinates
+// to given Cell, where connected Cells share an edge and
+// contain the same rune as the Cell at (X,Y) coordinates.
+// Returns the number of Cells set.
+func (m *Model) FloodFill(p Point, c Cell) int {
+	return m.floodFill(p, func(q Point) {
+		m.content[q.Y][q.X] = c
+	})
+}
+
+// FloodFillStyle sets Cell.Style of all Cells connected to the Cell at (X,Y) coordinates
+// to given style, where connected Cells share an edge and
+// contain the same rune as the Cell at (X,Y) coordinates.
+// Cell runes are not modified.
+// Returns the number of Cells set.
+func (m *Model) FloodFillStyle(p Point, s lipgloss.Style) int {
+	return m.floodFill(p, func(q Point) {
+		m.content[q.Y][q.X].Style = s
+	})
+}
+
+// floodFill invokes set on all Points connected to the given Point
+// with Cells containing the same rune as the Cell at the given Point.
+// Returns the number of Points set.
+func (m *Model) floodFill(p Point, set func(Point)) (n int) {
+	if !p.In(m.area) {
+		return
+	}
+	target := m.content[p.Y][p.X].Rune
+	visited := make([][]bool, m.area.Dy())
+	for i := range visited {
+		visited[i] = make([]bool, m.area.Dx())
+	}
+	stack := []Point{p}
+	for len(stack) > 0 {
+		q := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !q.In(m.area) || visited[q.Y][q.X] || (m.content[q.Y][q.X].Rune != target) {
+			continue
+		}
+		visited[q.Y][q.X] = true
+		set(q)
+		n++
+		stack = append(stack,
+			Point{X: q.X + 1, Y: q.Y},
+			Point{X: q.X - 1, Y: q.Y},
+			Point{X: q.X, Y: q.Y + 1},
+			Point{X: q.X, Y: q.Y - 1})
+	}
+	return
+}
+
 // SetStyle applies a lipgloss.Style to all Cells to change
 // visual elements of each rune in the canvas.
 func (m *Model) SetStyle(s lipgloss.Style) {
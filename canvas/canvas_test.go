@@ -182,3 +182,36 @@ func TestFloat64Point(t *testing.T) {
 		t.Errorf("Float64Point Y value did not Sub correctly:%f", nf.Y)
 	}
 }
+
+func TestFloodFill(t *testing.T) {
+	w := 10
+	h := 5
+	c := New(w, h)
+
+	// vertical wall splitting canvas into two regions
+	for y := 0; y < h; y++ {
+		c.SetRune(Point{X: 4, Y: y}, '|')
+	}
+	n := c.FloodFill(Point{X: 0, Y: 0}, NewCell('#'))
+	if n != 4*h {
+		t.Errorf("FloodFill set wrong number of cells:%d", n)
+	}
+	if r := c.Cell(Point{X: 3, Y: h - 1}).Rune; r != '#' {
+		t.Errorf("FloodFill did not fill region:'%c'", r)
+	}
+	if r := c.Cell(Point{X: 5, Y: 0}).Rune; r != 0 {
+		t.Errorf("FloodFill crossed boundary:'%c'", r)
+	}
+	if r := c.Cell(Point{X: 4, Y: 0}).Rune; r != '|' {
+		t.Errorf("FloodFill replaced boundary:'%c'", r)
+	}
+
+	// filling with the same rune must terminate
+	n = c.FloodFillStyle(Point{X: 9, Y: 4}, c.Style)
+	if n != 5*h {
+		t.Errorf("FloodFillStyle set wrong number of cells:%d", n)
+	}
+	if c.FloodFill(Point{X: w, Y: h}, NewCell('#')) != 0 {
+		t.Error("FloodFill not bounded")
+	}
+}
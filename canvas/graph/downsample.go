@@ -0,0 +1,139 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package graph
+
+// File contains functions reducing the number of data points
+// to draw while preserving the visual shape of the data.
+
+// https://skemman.is/bitstream/1946/15343/3/SS_MSthesis.pdf
+
+import (
+	"math"
+
+	"github.com/NimbleMarkets/ntcharts/canvas"
+)
+
+// DownsampleFunc returns at most n data points
+// chosen from a given sequence of data points sorted by X value.
+type DownsampleFunc func(points []canvas.Float64Point, n int) []canvas.Float64Point
+
+// DownsampleLTTB returns at most n data points from a given sequence of
+// data points sorted by X value using the Largest-Triangle-Three-Buckets algorithm.
+// The first and last data points are always kept.  Each remaining bucket
+// of data points keeps the data point forming the largest triangle with
+// the previously kept data point and the average of the next bucket.
+// Returns the given data points if there are not more than n data points.
+func DownsampleLTTB(points []canvas.Float64Point, n int) []canvas.Float64Point {
+	l := len(points)
+	if (n >= l) || (l <= 2) {
+		return points
+	}
+	if n < 3 {
+		n = 3
+	}
+	r := make([]canvas.Float64Point, 0, n)
+	r = append(r, points[0])
+
+	// buckets exclude first and last data points
+	bSize := float64(l-2) / float64(n-2)
+	a := 0 // index of previously kept data point
+	for i := 0; i < n-2; i++ {
+		// average of next bucket, or last data point if there is no next bucket
+		nStart := int(float64(i+1)*bSize) + 1
+		nEnd := int(float64(i+2)*bSize) + 1
+		if nEnd > l-1 {
+			nEnd = l - 1
+		}
+		if nStart >= nEnd {
+			nStart = l - 1
+			nEnd = l
+		}
+		var avg canvas.Float64Point
+		for _, p := range points[nStart:nEnd] {
+			avg = avg.Add(p)
+		}
+		avg = avg.Mul(canvas.Float64Point{X: 1 / float64(nEnd-nStart), Y: 1 / float64(nEnd-nStart)})
+
+		// data point in current bucket with largest triangle area
+		start := int(float64(i)*bSize) + 1
+		end := int(float64(i+1)*bSize) + 1
+		if end > l-1 {
+			end = l - 1
+		}
+		pa := points[a]
+		maxArea := -1.0
+		maxIdx := start
+		for j := start; j < end; j++ {
+			p := points[j]
+			area := math.Abs((pa.X-avg.X)*(p.Y-pa.Y) - (pa.X-p.X)*(avg.Y-pa.Y))
+			if area > maxArea {
+				maxArea = area
+				maxIdx = j
+			}
+		}
+		r = append(r, points[maxIdx])
+		a = maxIdx
+	}
+	r = append(r, points[l-1])
+	return r
+}
+
+// DownsampleMinMax returns at most n data points from a given sequence of
+// data points sorted by X value by dividing the X value range of the data
+// points into n/2 buckets of equal width and keeping the data points with the
+// minimum and maximum Y values of each bucket in their original order.
+// If n/2 is the number of graph columns, then each bucket contains
+// the min/max envelope of a single column.
+// Returns the given data points if there are not more than n data points.
+func DownsampleMinMax(points []canvas.Float64Point, n int) []canvas.Float64Point {
+	l := len(points)
+	if (n >= l) || (l <= 2) {
+		return points
+	}
+	if n < 2 {
+		n = 2
+	}
+	buckets := n / 2
+	minX := points[0].X
+	width := points[l-1].X - minX
+	if width <= 0 {
+		width = 1
+	}
+	r := make([]canvas.Float64Point, 0, n)
+
+	// appends min and max data points of the current bucket
+	appendBucket := func(minIdx, maxIdx int) {
+		if minIdx > maxIdx {
+			minIdx, maxIdx = maxIdx, minIdx
+		}
+		r = append(r, points[minIdx])
+		if minIdx != maxIdx {
+			r = append(r, points[maxIdx])
+		}
+	}
+
+	bIdx := -1 // current bucket index
+	minIdx, maxIdx := 0, 0
+	for i, p := range points {
+		b := int(float64(buckets) * (p.X - minX) / width)
+		if b >= buckets {
+			b = buckets - 1
+		}
+		if b != bIdx {
+			if bIdx >= 0 {
+				appendBucket(minIdx, maxIdx)
+			}
+			bIdx = b
+			minIdx, maxIdx = i, i
+			continue
+		}
+		if p.Y < points[minIdx].Y {
+			minIdx = i
+		}
+		if p.Y > points[maxIdx].Y {
+			maxIdx = i
+		}
+	}
+	appendBucket(minIdx, maxIdx)
+	return r
+}
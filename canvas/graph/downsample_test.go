@@ -0,0 +1,69 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package graph
+
+import (
+	"testing"
+
+	"github.com/NimbleMarkets/ntcharts/canvas"
+)
+
+// spikeSeries returns n data points of value 0
+// except for a single spike value at index s.
+func spikeSeries(n, s int, v float64) []canvas.Float64Point {
+	r := make([]canvas.Float64Point, n)
+	for i := range r {
+		r[i] = canvas.Float64Point{X: float64(i), Y: 0}
+	}
+	r[s].Y = v
+	return r
+}
+
+func TestDownsampleLTTB(t *testing.T) {
+	points := spikeSeries(1000, 437, 50)
+	r := DownsampleLTTB(points, 20)
+	if len(r) != 20 {
+		t.Errorf("DownsampleLTTB wrong length:%d", len(r))
+	}
+	if (r[0] != points[0]) || (r[len(r)-1] != points[len(points)-1]) {
+		t.Errorf("DownsampleLTTB did not keep first and last points")
+	}
+	found := false
+	for i, p := range r {
+		if p == points[437] {
+			found = true
+		}
+		if (i > 0) && (p.X <= r[i-1].X) {
+			t.Errorf("DownsampleLTTB points not in order at index %d", i)
+		}
+	}
+	if !found {
+		t.Errorf("DownsampleLTTB did not keep spike point")
+	}
+	if r := DownsampleLTTB(points[:10], 20); len(r) != 10 {
+		t.Errorf("DownsampleLTTB modified short sequence:%d", len(r))
+	}
+}
+
+func TestDownsampleMinMax(t *testing.T) {
+	points := spikeSeries(1000, 437, -50)
+	r := DownsampleMinMax(points, 20)
+	if len(r) > 20 {
+		t.Errorf("DownsampleMinMax wrong length:%d", len(r))
+	}
+	found := false
+	for i, p := range r {
+		if p == points[437] {
+			found = true
+		}
+		if (i > 0) && (p.X <= r[i-1].X) {
+			t.Errorf("DownsampleMinMax points not in order at index %d", i)
+		}
+	}
+	if !found {
+		t.Errorf("DownsampleMinMax did not keep spike point")
+	}
+	if r := DownsampleMinMax(points[:10], 20); len(r) != 10 {
+		t.Errorf("DownsampleMinMax modified short sequence:%d", len(r))
+	}
+}
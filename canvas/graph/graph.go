@@ -86,6 +86,12 @@ func (g *BrailleGrid) Set(p canvas.Point) {
 	g.grid.Set(p.X, p.Y)
 }
 
+// FloodFill will set all unset points on grid connected to the given canvas Point.
+// Returns the number of points set.
+func (g *BrailleGrid) FloodFill(p canvas.Point) int {
+	return g.grid.FloodFill(p.X, p.Y)
+}
+
 // BraillePatterns returns [][]rune containing
 // braille pattern runes to draw on to the canvas.
 func (g *BrailleGrid) BraillePatterns() [][]rune {
@@ -242,6 +248,29 @@ func DrawLineSequence(m *canvas.Model, startYAxis bool, startX int, seqY []int,
 	}
 }
 
+// DrawLineSequenceWithGaps draws line runes on to the canvas like DrawLineSequence
+// for each run of consecutive Y coordinates of a sequence, where the lines are
+// broken at each index of the sequence with a true value in the given gaps.
+// Indices without a gaps value are not gaps.
+// `startYAxis` should be true if `startX` is the Y axis.
+func DrawLineSequenceWithGaps(m *canvas.Model, startYAxis bool, startX int, seqY []int, gaps []bool, ls runes.LineStyle, s lipgloss.Style) {
+	isGap := func(i int) bool {
+		return (i < len(gaps)) && gaps[i]
+	}
+	for i := 0; i < len(seqY); {
+		if isGap(i) {
+			i++
+			continue
+		}
+		j := i + 1
+		for (j < len(seqY)) && !isGap(j) {
+			j++
+		}
+		DrawLineSequence(m, startYAxis && (i == 0), startX+i, seqY[i:j], ls, s)
+		i = j
+	}
+}
+
 // DrawLineSequenceLeftToRight draws line runes from point A to point B where B.X = A.X+1.
 // Assumes point A has already been drawn and does not draw point A.
 // Applies style to all line runes.
@@ -469,6 +498,67 @@ func getColumnHeight(m *canvas.Model, p canvas.Point) int {
 	return i
 }
 
+// DrawColumnsTopToBottom draws columns going downwards on to canvas
+// starting from a given (X,Y) coordinate and a sequence of column lengths.
+// Columns will be drawn from left to right and
+// sequential column lengths will increment X coordinates for drawing.
+// Applies style to all block runes.
+// Coordinates (0,0) is top left of canvas.
+func DrawColumnsTopToBottom(m *canvas.Model, p canvas.Point, seqLen []float64, s lipgloss.Style) {
+	y := p.Y
+	x := p.X
+	for i, f := range seqLen {
+		DrawColumnTopToBottom(m, canvas.Point{X: x + i, Y: y}, f, s)
+	}
+}
+
+// DrawColumnTopToBottom draws block element runes going down from given point.
+// The value of float64 is the number of characters to draw going down.
+// A fractional value is used and fractional values will map to the nearest
+// 1/8th upper block for the last rune drawn.  Since Unicode lacks most upper block
+// elements, the last rune may be a lower block element drawn with reversed colors.
+// Existing runes will be replaced.
+// Applies style to all block runes.
+// Coordinates (0,0) is top left of canvas.
+func DrawColumnTopToBottom(m *canvas.Model, p canvas.Point, v float64, s lipgloss.Style) {
+	if v <= 0 {
+		return
+	}
+	x := p.X
+	y := p.Y
+	n := math.Floor(v) // number of full blocks to show
+	end := int(n)
+
+	fb := canvas.NewCellWithStyle(runes.FullBlock, s)
+	for i := 0; i < end; i++ {
+		m.SetCell(canvas.Point{X: x, Y: y + i}, fb)
+	}
+	// set column bottom rune
+	r, inverse := runes.UpperBlockElementFromFloat64(v - n)
+	if r != runes.Null {
+		rs := s
+		if inverse {
+			rs = s.Reverse(true)
+		}
+		m.SetCell(canvas.Point{X: x, Y: y + end}, canvas.NewCellWithStyle(r, rs))
+	}
+}
+
+// DrawColumnFromBaseline draws a column either going up or going down
+// from a baseline depending on the sign of the float64 value.
+// The given point is the first cell above the baseline, such that
+// positive values are drawn going up starting at the given point and
+// negative values are drawn going down starting at the cell below the given point.
+// Applies style to all block runes.
+// Coordinates (0,0) is top left of canvas.
+func DrawColumnFromBaseline(m *canvas.Model, p canvas.Point, v float64, s lipgloss.Style) {
+	if v >= 0 {
+		DrawColumnBottomToTop(m, p, v, s)
+	} else {
+		DrawColumnTopToBottom(m, canvas.Point{X: p.X, Y: p.Y + 1}, -v, s)
+	}
+}
+
 // DrawRows draws rows going right on to canvas
 // starting from a given (X,Y) coordinate and a sequence of row widths.
 // Rows will be drawn from top to bottom and
@@ -583,6 +673,67 @@ func getRowWidth(m *canvas.Model, p canvas.Point) int {
 	return i
 }
 
+// DrawRowsRightToLeft draws rows going left on to canvas
+// starting from a given (X,Y) coordinate and a sequence of row widths.
+// Rows will be drawn from top to bottom and
+// sequential row widths will increment Y coordinates for drawing.
+// Applies style to all block runes.
+// Coordinates (0,0) is top left of canvas.
+func DrawRowsRightToLeft(m *canvas.Model, p canvas.Point, seqLen []float64, s lipgloss.Style) {
+	y := p.Y
+	x := p.X
+	for i, f := range seqLen {
+		DrawRowRightToLeft(m, canvas.Point{X: x, Y: y + i}, f, s)
+	}
+}
+
+// DrawRowRightToLeft draws block element runes going left from given point.
+// The value of float64 is the number of characters to draw going left.
+// A fractional value is used and fractional values will map to the nearest
+// 1/8th right block for the last rune drawn.  Since Unicode lacks most right block
+// elements, the last rune may be a left block element drawn with reversed colors.
+// Existing runes will be replaced.
+// Applies style to all block runes.
+// Coordinates (0,0) is top left of canvas.
+func DrawRowRightToLeft(m *canvas.Model, p canvas.Point, v float64, s lipgloss.Style) {
+	if v <= 0 {
+		return
+	}
+	x := p.X
+	y := p.Y
+	n := math.Floor(v) // number of full blocks to show
+	end := int(n)
+
+	fb := canvas.NewCellWithStyle(runes.FullBlock, s)
+	for i := 0; i < end; i++ {
+		m.SetCell(canvas.Point{X: x - i, Y: y}, fb)
+	}
+	// set row leftmost rune
+	r, inverse := runes.RightBlockElementFromFloat64(v - n)
+	if r != runes.Null {
+		rs := s
+		if inverse {
+			rs = s.Reverse(true)
+		}
+		m.SetCell(canvas.Point{X: x - end, Y: y}, canvas.NewCellWithStyle(r, rs))
+	}
+}
+
+// DrawRowFromBaseline draws a row either going right or going left
+// from a baseline depending on the sign of the float64 value.
+// The given point is the first cell right of the baseline, such that
+// positive values are drawn going right starting at the given point and
+// negative values are drawn going left starting at the cell left of the given point.
+// Applies style to all block runes.
+// Coordinates (0,0) is top left of canvas.
+func DrawRowFromBaseline(m *canvas.Model, p canvas.Point, v float64, s lipgloss.Style) {
+	if v >= 0 {
+		DrawRowLeftToRight(m, p, v, s)
+	} else {
+		DrawRowRightToLeft(m, canvas.Point{X: p.X - 1, Y: p.Y}, -v, s)
+	}
+}
+
 // abs returns absolute value of given integer.
 func abs(i int) int {
 	if i < 0 {
@@ -809,6 +960,77 @@ func DrawCandlestickBottomToTop(m *canvas.Model, p canvas.Point, l, bl, bh, h fl
 	DrawCandlestickRune(m, canvas.Point{X: p.X, Y: p.Y - int(hf)}, hr, s)
 }
 
+// DrawHollowCandlestickBottomToTop draws a hollow candlestick from bottom to top
+// on to the canvas starting from given (X,Y) coordinates.
+// Arguments are the same as DrawCandlestickBottomToTop.
+// The body is drawn with double line runes at whole rune precision
+// and the wicks are drawn with 1/2th candlestick line segment runes.
+// Applies style to all runes.
+// Coordinates (0,0) is top left of canvas.
+func DrawHollowCandlestickBottomToTop(m *canvas.Model, p canvas.Point, l, bl, bh, h float64, s lipgloss.Style) {
+	blf := math.Floor(bl)
+	bhf := math.Floor(bh)
+
+	// bottom wick
+	lf := math.Floor(l)
+	if lf < blf {
+		lr := runes.LineVertical
+		if (l - lf) >= 0.5 {
+			lr = runes.LineUp
+		}
+		DrawCandlestickRune(m, canvas.Point{X: p.X, Y: p.Y - int(lf)}, lr, s)
+		for i := int(lf + 1); i < int(blf); i++ {
+			DrawCandlestickRune(m, canvas.Point{X: p.X, Y: p.Y - i}, runes.LineVertical, s)
+		}
+	}
+
+	// body
+	for i := int(blf); i <= int(bhf); i++ {
+		m.SetCell(canvas.Point{X: p.X, Y: p.Y - i}, canvas.NewCellWithStyle(runes.LineVerticalDouble, s))
+	}
+
+	// top wick
+	hf := math.Floor(h)
+	if bhf < hf {
+		for i := int(bhf + 1); i < int(hf); i++ {
+			DrawCandlestickRune(m, canvas.Point{X: p.X, Y: p.Y - i}, runes.LineVertical, s)
+		}
+		hr := runes.LineDown
+		if (h - hf) >= 0.5 {
+			hr = runes.LineVertical
+		}
+		DrawCandlestickRune(m, canvas.Point{X: p.X, Y: p.Y - int(hf)}, hr, s)
+	}
+}
+
+// DrawOHLCBarBottomToTop draws an OHLC bar from bottom to top
+// on to the canvas starting from given (X,Y) coordinates.
+// `l` and `h` are the low and high values drawn as a vertical line,
+// `o` is the open value drawn as a tick to the left of the line
+// and `c` is the close value drawn as a tick to the right of the line.
+// These values represent the height of the runes drawn going up.
+// Assumes `h` >= `l` and `o` and `c` are between `l` and `h`.
+// Applies style to all runes.
+// Coordinates (0,0) is top left of canvas.
+func DrawOHLCBarBottomToTop(m *canvas.Model, p canvas.Point, l, o, c, h float64, s lipgloss.Style) {
+	lf := int(math.Floor(l))
+	hf := int(math.Floor(h))
+	of := int(math.Floor(o))
+	cf := int(math.Floor(c))
+	for i := lf; i <= hf; i++ {
+		r := runes.LineVertical
+		switch {
+		case (i == of) && (i == cf):
+			r = runes.LineHorizontalVertical
+		case i == of:
+			r = runes.LineVerticalLeft
+		case i == cf:
+			r = runes.LineVerticalRight
+		}
+		m.SetCell(canvas.Point{X: p.X, Y: p.Y - i}, canvas.NewCellWithStyle(r, s))
+	}
+}
+
 // DrawCandlestickRune draws a canndlestick rune on to the canvas
 // at given (X,Y) coordinates with given style.
 // The function checks for existing candlestick runes already on the canvas and
@@ -0,0 +1,137 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package graph
+
+import (
+	"testing"
+
+	"github.com/NimbleMarkets/ntcharts/canvas"
+	"github.com/NimbleMarkets/ntcharts/canvas/runes"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestDrawColumnTopToBottom(t *testing.T) {
+	m := canvas.New(3, 5)
+	s := lipgloss.NewStyle()
+
+	DrawColumnTopToBottom(&m, canvas.Point{X: 0, Y: 1}, 2.5, s)
+	for y := 1; y <= 2; y++ {
+		if r := m.Cell(canvas.Point{X: 0, Y: y}).Rune; r != runes.FullBlock {
+			t.Errorf("expected full block at row %d:%q", y, r)
+		}
+	}
+	if r := m.Cell(canvas.Point{X: 0, Y: 3}).Rune; r != runes.UpperBlockFour {
+		t.Errorf("expected upper half block:%q", r)
+	}
+
+	// 3/8 has no upper block element, uses inversed 5/8 lower block
+	DrawColumnTopToBottom(&m, canvas.Point{X: 1, Y: 0}, 0.375, s)
+	c := m.Cell(canvas.Point{X: 1, Y: 0})
+	if (c.Rune != runes.LowerBlockFive) || !c.Style.GetReverse() {
+		t.Errorf("expected reversed lower block:%q", c.Rune)
+	}
+
+	DrawColumnFromBaseline(&m, canvas.Point{X: 2, Y: 2}, -1, s)
+	if r := m.Cell(canvas.Point{X: 2, Y: 3}).Rune; r != runes.FullBlock {
+		t.Errorf("expected negative column below baseline:%q", r)
+	}
+	if r := m.Cell(canvas.Point{X: 2, Y: 2}).Rune; r != runes.Null {
+		t.Errorf("unexpected rune above baseline:%q", r)
+	}
+}
+
+func TestDrawRowRightToLeft(t *testing.T) {
+	m := canvas.New(5, 2)
+	s := lipgloss.NewStyle()
+
+	DrawRowRightToLeft(&m, canvas.Point{X: 4, Y: 0}, 1.125, s)
+	if r := m.Cell(canvas.Point{X: 4, Y: 0}).Rune; r != runes.FullBlock {
+		t.Errorf("expected full block:%q", r)
+	}
+	if r := m.Cell(canvas.Point{X: 3, Y: 0}).Rune; r != runes.RightBlockOne {
+		t.Errorf("expected right one eighth block:%q", r)
+	}
+
+	DrawRowFromBaseline(&m, canvas.Point{X: 2, Y: 1}, -0.75, s)
+	c := m.Cell(canvas.Point{X: 1, Y: 1})
+	if (c.Rune != runes.LeftBlockTwo) || !c.Style.GetReverse() {
+		t.Errorf("expected reversed left block:%q", c.Rune)
+	}
+}
+
+func TestDrawLineSequenceWithGaps(t *testing.T) {
+	m := canvas.New(5, 3)
+	s := lipgloss.NewStyle()
+
+	DrawLineSequenceWithGaps(&m, false, 0, []int{1, 1, 0, 1, 1}, []bool{false, false, true}, runes.ArcLineStyle, s)
+	for _, x := range []int{0, 1, 3, 4} {
+		if r := m.Cell(canvas.Point{X: x, Y: 1}).Rune; r != runes.LineHorizontal {
+			t.Errorf("expected horizontal line at column %d:%q", x, r)
+		}
+	}
+	for y := 0; y < 3; y++ {
+		if r := m.Cell(canvas.Point{X: 2, Y: y}).Rune; r != runes.Null {
+			t.Errorf("unexpected rune in gap at row %d:%q", y, r)
+		}
+	}
+}
+
+func TestDrawHollowCandlestickBottomToTop(t *testing.T) {
+	m := canvas.New(1, 6)
+	s := lipgloss.NewStyle()
+
+	DrawHollowCandlestickBottomToTop(&m, canvas.Point{X: 0, Y: 5}, 0.6, 1.2, 3.4, 4.2, s)
+	expected := []rune{runes.LineUp, runes.LineVerticalDouble, runes.LineVerticalDouble,
+		runes.LineVerticalDouble, runes.LineDown}
+	for i, e := range expected {
+		if r := m.Cell(canvas.Point{X: 0, Y: 5 - i}).Rune; r != e {
+			t.Errorf("expected %q at height %d:%q", e, i, r)
+		}
+	}
+	if r := m.Cell(canvas.Point{X: 0, Y: 0}).Rune; r != runes.Null {
+		t.Errorf("unexpected rune above candlestick:%q", r)
+	}
+}
+
+func TestDrawOHLCBarBottomToTop(t *testing.T) {
+	m := canvas.New(1, 4)
+	s := lipgloss.NewStyle()
+
+	DrawOHLCBarBottomToTop(&m, canvas.Point{X: 0, Y: 3}, 0, 2.5, 1.5, 3, s)
+	expected := []rune{runes.LineVertical, runes.LineVerticalRight, runes.LineVerticalLeft, runes.LineVertical}
+	for i, e := range expected {
+		if r := m.Cell(canvas.Point{X: 0, Y: 3 - i}).Rune; r != e {
+			t.Errorf("expected %q at height %d:%q", e, i, r)
+		}
+	}
+
+	m.Clear()
+	DrawOHLCBarBottomToTop(&m, canvas.Point{X: 0, Y: 3}, 1, 1.2, 1.8, 1.9, s)
+	if r := m.Cell(canvas.Point{X: 0, Y: 2}).Rune; r != runes.LineHorizontalVertical {
+		t.Errorf("expected open and close ticks on same rune:%q", r)
+	}
+}
+
+func TestBrailleGridFloodFill(t *testing.T) {
+	g := NewBrailleGrid(2, 1, 0, 1, 0, 1) // grid of 4x4 dots
+	for y := 0; y < 4; y++ {
+		g.Set(canvas.Point{X: 2, Y: y}) // boundary splitting grid into two regions
+	}
+	if n := g.FloodFill(canvas.Point{X: 0, Y: 0}); n != 8 {
+		t.Errorf("FloodFill set wrong number of points:%d", n)
+	}
+	p := g.BraillePatterns()
+	if (p[0][0] != '⣿') || (p[0][1] != '⡇') {
+		t.Errorf("FloodFill crossed boundary:%q", string(p[0]))
+	}
+	if n := g.FloodFill(canvas.Point{X: 1, Y: 3}); n != 0 {
+		t.Errorf("FloodFill of set point set points:%d", n)
+	}
+	if n := g.FloodFill(canvas.Point{X: 4, Y: 0}); n != 0 {
+		t.Errorf("FloodFill not bounded:%d", n)
+	}
+	if n := g.FloodFill(canvas.Point{X: 3, Y: 2}); n != 4 {
+		t.Errorf("FloodFill set wrong number of points in second region:%d", n)
+	}
+}
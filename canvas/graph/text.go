@@ -0,0 +1,225 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package graph
+
+// File contains fonts and functions used to draw large text
+// on to the canvas using either block element runes or braille runes.
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/NimbleMarkets/ntcharts/canvas"
+	"github.com/NimbleMarkets/ntcharts/canvas/runes"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TextFontKind enumerates the different runes used to draw a TextFont.
+type TextFontKind int
+
+const (
+	// BlockTextFont draws each glyph dot as a full block rune.
+	BlockTextFont TextFontKind = iota
+	// BrailleTextFont draws each glyph dot as a braille pattern dot.
+	BrailleTextFont
+)
+
+// TextFont contains glyph bitmaps used to draw large text on to the canvas.
+// Each glyph is a []string of Height rows, and each row has Width characters
+// where the '#' character indicates that the dot is displayed.
+// Lowercase letters are drawn using the uppercase glyphs
+// if a lowercase glyph does not exist.
+type TextFont struct {
+	Kind    TextFontKind
+	Width   int // glyph width in dots
+	Height  int // glyph height in dots
+	Spacing int // number of empty dots between glyphs
+
+	glyphs map[rune][]string
+}
+
+// NewTextFont returns a new *TextFont of the given kind
+// using the glyph bitmaps with width and height number of dots.
+func NewTextFont(k TextFontKind, w, h int, glyphs map[rune][]string) *TextFont {
+	return &TextFont{
+		Kind:    k,
+		Width:   w,
+		Height:  h,
+		Spacing: 1,
+		glyphs:  glyphs,
+	}
+}
+
+// Glyph returns the glyph bitmap for a given rune
+// and whether the glyph exists in the font.
+func (f *TextFont) Glyph(r rune) ([]string, bool) {
+	g, ok := f.glyphs[r]
+	if !ok {
+		g, ok = f.glyphs[unicode.ToUpper(r)]
+	}
+	return g, ok
+}
+
+// glyphDot returns whether the dot at column x and row y of glyph is displayed.
+func glyphDot(g []string, x, y int) bool {
+	if (y < 0) || (y >= len(g)) || (x < 0) || (x >= len(g[y])) {
+		return false
+	}
+	return g[y][x] == '#'
+}
+
+// lineDots returns the number of dots wide a line of text is for the font.
+func (f *TextFont) lineDots(l string) int {
+	n := len([]rune(l))
+	if n == 0 {
+		return 0
+	}
+	return (n * (f.Width + f.Spacing)) - f.Spacing
+}
+
+// lineHeight returns the number of canvas rows used to draw a line of text.
+func (f *TextFont) lineHeight() int {
+	if f.Kind == BrailleTextFont {
+		return (f.Height + 3) / 4 // each braille rune is 4 dots high
+	}
+	return f.Height
+}
+
+// TextSize returns the width and height of the canvas area
+// used when drawing the given string with the given font.
+// Lines of text are separated by the newline character.
+func TextSize(f *TextFont, str string) (w int, h int) {
+	lines := strings.Split(str, "\n")
+	for _, l := range lines {
+		lw := f.lineDots(l)
+		if f.Kind == BrailleTextFont {
+			lw = (lw + 1) / 2 // each braille rune is 2 dots wide
+		}
+		if lw > w {
+			w = lw
+		}
+	}
+	h = (len(lines) * (f.lineHeight() + 1)) - 1 // empty row between lines
+	return
+}
+
+// DrawText draws the given string on to the canvas using the given font
+// starting with the top left of the text at the given Point.
+// Lines of text are separated by the newline character, and runes
+// that do not exist in the font are drawn as empty glyphs.
+// Canvas cells not covered by glyph dots are not modified.
+// Applies style to all runes drawn.
+// Coordinates (0,0) is top left of canvas.
+func DrawText(m *canvas.Model, p canvas.Point, f *TextFont, str string, s lipgloss.Style) {
+	y := p.Y
+	for _, l := range strings.Split(str, "\n") {
+		lp := canvas.Point{X: p.X, Y: y}
+		switch f.Kind {
+		case BrailleTextFont:
+			drawBrailleTextLine(m, lp, f, l, s)
+		default:
+			drawBlockTextLine(m, lp, f, l, s)
+		}
+		y += f.lineHeight() + 1
+	}
+}
+
+// drawBlockTextLine draws a single line of text using full block runes.
+func drawBlockTextLine(m *canvas.Model, p canvas.Point, f *TextFont, l string, s lipgloss.Style) {
+	c := canvas.NewCellWithStyle(runes.FullBlock, s)
+	x := p.X
+	for _, r := range l {
+		if g, ok := f.Glyph(r); ok {
+			for gy := 0; gy < f.Height; gy++ {
+				for gx := 0; gx < f.Width; gx++ {
+					if glyphDot(g, gx, gy) {
+						m.SetCell(canvas.Point{X: x + gx, Y: p.Y + gy}, c)
+					}
+				}
+			}
+		}
+		x += f.Width + f.Spacing
+	}
+}
+
+// drawBrailleTextLine draws a single line of text using braille runes.
+func drawBrailleTextLine(m *canvas.Model, p canvas.Point, f *TextFont, l string, s lipgloss.Style) {
+	w := f.lineDots(l)
+	if w <= 0 {
+		return
+	}
+	grid := runes.NewPatternDotsGrid(w, f.lineHeight()*4)
+	x := 0
+	for _, r := range l {
+		if g, ok := f.Glyph(r); ok {
+			for gy := 0; gy < f.Height; gy++ {
+				for gx := 0; gx < f.Width; gx++ {
+					if glyphDot(g, gx, gy) {
+						grid.Set(x+gx, gy)
+					}
+				}
+			}
+		}
+		x += f.Width + f.Spacing
+	}
+	DrawBraillePatterns(m, p, grid.BraillePatterns(), s)
+}
+
+// BlockFont is a 3x5 TextFont drawn with full block runes.
+// Each glyph uses 3 columns and 5 rows of the canvas.
+var BlockFont = NewTextFont(BlockTextFont, 3, 5, font3x5Glyphs)
+
+// BrailleFont is a 3x5 TextFont drawn with braille runes.
+// Each glyph uses 2 columns and 2 rows of the canvas.
+var BrailleFont = NewTextFont(BrailleTextFont, 3, 5, font3x5Glyphs)
+
+// font3x5Glyphs contains 3 dots wide and 5 dots high glyphs
+// for digits, uppercase letters and common symbols.
+var font3x5Glyphs = map[rune][]string{
+	' ': {"...", "...", "...", "...", "..."},
+	'0': {"###", "#.#", "#.#", "#.#", "###"},
+	'1': {".#.", "##.", ".#.", ".#.", "###"},
+	'2': {"###", "..#", "###", "#..", "###"},
+	'3': {"###", "..#", "###", "..#", "###"},
+	'4': {"#.#", "#.#", "###", "..#", "..#"},
+	'5': {"###", "#..", "###", "..#", "###"},
+	'6': {"###", "#..", "###", "#.#", "###"},
+	'7': {"###", "..#", "..#", ".#.", ".#."},
+	'8': {"###", "#.#", "###", "#.#", "###"},
+	'9': {"###", "#.#", "###", "..#", "###"},
+	'A': {".#.", "#.#", "###", "#.#", "#.#"},
+	'B': {"##.", "#.#", "##.", "#.#", "##."},
+	'C': {".##", "#..", "#..", "#..", ".##"},
+	'D': {"##.", "#.#", "#.#", "#.#", "##."},
+	'E': {"###", "#..", "##.", "#..", "###"},
+	'F': {"###", "#..", "##.", "#..", "#.."},
+	'G': {".##", "#..", "#.#", "#.#", ".##"},
+	'H': {"#.#", "#.#", "###", "#.#", "#.#"},
+	'I': {"###", ".#.", ".#.", ".#.", "###"},
+	'J': {"..#", "..#", "..#", "#.#", ".#."},
+	'K': {"#.#", "#.#", "##.", "#.#", "#.#"},
+	'L': {"#..", "#..", "#..", "#..", "###"},
+	'M': {"#.#", "###", "###", "#.#", "#.#"},
+	'N': {"##.", "#.#", "#.#", "#.#", "#.#"},
+	'O': {".#.", "#.#", "#.#", "#.#", ".#."},
+	'P': {"##.", "#.#", "##.", "#..", "#.."},
+	'Q': {".#.", "#.#", "#.#", "##.", ".##"},
+	'R': {"##.", "#.#", "##.", "#.#", "#.#"},
+	'S': {".##", "#..", ".#.", "..#", "##."},
+	'T': {"###", ".#.", ".#.", ".#.", ".#."},
+	'U': {"#.#", "#.#", "#.#", "#.#", ".##"},
+	'V': {"#.#", "#.#", "#.#", ".#.", ".#."},
+	'W': {"#.#", "#.#", "###", "###", "#.#"},
+	'X': {"#.#", "#.#", ".#.", "#.#", "#.#"},
+	'Y': {"#.#", "#.#", ".#.", ".#.", ".#."},
+	'Z': {"###", "..#", ".#.", "#..", "###"},
+	'$': {".##", "##.", ".#.", ".##", "##."},
+	'%': {"#.#", "..#", ".#.", "#..", "#.#"},
+	'.': {"...", "...", "...", "...", ".#."},
+	',': {"...", "...", "...", ".#.", "#.."},
+	':': {"...", ".#.", "...", ".#.", "..."},
+	'-': {"...", "...", "###", "...", "..."},
+	'+': {"...", ".#.", "###", ".#.", "..."},
+	'/': {"..#", "..#", ".#.", "#..", "#.."},
+}
@@ -0,0 +1,62 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package graph
+
+import (
+	"testing"
+
+	"github.com/NimbleMarkets/ntcharts/canvas"
+	"github.com/NimbleMarkets/ntcharts/canvas/runes"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestTextSize(t *testing.T) {
+	w, h := TextSize(BlockFont, "12.5%")
+	if (w != 19) || (h != 5) {
+		t.Errorf("BlockFont wrong size:%dx%d", w, h)
+	}
+	w, h = TextSize(BlockFont, "$1\n-2")
+	if (w != 7) || (h != 11) {
+		t.Errorf("BlockFont wrong multiline size:%dx%d", w, h)
+	}
+	w, h = TextSize(BrailleFont, "+42")
+	if (w != 6) || (h != 2) {
+		t.Errorf("BrailleFont wrong size:%dx%d", w, h)
+	}
+}
+
+func TestDrawBlockText(t *testing.T) {
+	m := canvas.New(10, 6)
+	DrawText(&m, canvas.Point{X: 1, Y: 0}, BlockFont, "-1", lipgloss.NewStyle())
+	// '-' middle row is filled
+	for x := 1; x <= 3; x++ {
+		if r := m.Cell(canvas.Point{X: x, Y: 2}).Rune; r != runes.FullBlock {
+			t.Errorf("missing '-' block at column %d:%q", x, r)
+		}
+	}
+	if r := m.Cell(canvas.Point{X: 1, Y: 0}).Rune; r != runes.Null {
+		t.Errorf("unexpected rune drawn for empty dot:%q", r)
+	}
+	// '1' bottom row is filled after glyph spacing
+	for x := 5; x <= 7; x++ {
+		if r := m.Cell(canvas.Point{X: x, Y: 4}).Rune; r != runes.FullBlock {
+			t.Errorf("missing '1' block at column %d:%q", x, r)
+		}
+	}
+}
+
+func TestDrawBrailleText(t *testing.T) {
+	m := canvas.New(4, 2)
+	DrawText(&m, canvas.Point{X: 0, Y: 0}, BrailleFont, "a", lipgloss.NewStyle())
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 2; x++ {
+			if r := m.Cell(canvas.Point{X: x, Y: y}).Rune; !runes.IsBraillePattern(r) {
+				t.Errorf("expected braille rune at (%d,%d):%q", x, y, r)
+			}
+		}
+	}
+	if r := m.Cell(canvas.Point{X: 2, Y: 0}).Rune; r != runes.Null {
+		t.Errorf("unexpected rune drawn outside glyph:%q", r)
+	}
+}
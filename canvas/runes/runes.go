@@ -12,6 +12,7 @@ const (
 	LineHorizontal         = '\u2500' // ─
 	LineVertical           = '\u2502' // │
 	LineVerticalHeavy      = '\u2503' // ┃
+	LineVerticalDouble     = '\u2551' // ║
 	LineDownRight          = '\u250C' // ┌
 	LineDownLeft           = '\u2510' // ┐
 	LineUpRight            = '\u2514' // └
@@ -43,6 +44,7 @@ const (
 	LowerBlockSix   = '\u2586' // ▆
 	LowerBlockSeven = '\u2587' // ▇
 	FullBlock       = '\u2588' // █
+	LightShade      = '\u2591' // ░
 	LeftBlockSeven  = '\u2589' // ▉
 	LeftBlockSix    = '\u258A' // ▊
 	LeftBlockFive   = '\u258B' // ▋
@@ -50,6 +52,10 @@ const (
 	LeftBlockThree  = '\u258D' // ▍
 	LeftBlockTwo    = '\u258E' // ▎
 	LeftBlockOne    = '\u258F' // ▏
+	UpperBlockFour  = '\u2580' // ▀
+	RightBlockFour  = '\u2590' // ▐
+	UpperBlockOne   = '\u2594' // ▔
+	RightBlockOne   = '\u2595' // ▕
 )
 
 /*
@@ -125,6 +131,38 @@ func (g *PatternDotsGrid) Unset(x int, y int) {
 	g.g[y][x] = false
 }
 
+// IsSet returns whether value in grid at given column and row is set.
+// Returns false if column and row are out of bounds.
+func (g *PatternDotsGrid) IsSet(x int, y int) bool {
+	if (x < 0) || (x >= g.w) || (y < 0) || (y >= g.h) {
+		return false
+	}
+	return g.g[y][x]
+}
+
+// FloodFill will set all unset values in grid connected to the given column and row,
+// where connected values are adjacent either horizontally or vertically.
+// Does nothing if the value at the given column and row is already set.
+// Returns the number of values set.
+func (g *PatternDotsGrid) FloodFill(x int, y int) (n int) {
+	if (x < 0) || (x >= g.w) || (y < 0) || (y >= g.h) {
+		return
+	}
+	stack := [][2]int{{x, y}}
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		px, py := p[0], p[1]
+		if (px < 0) || (px >= g.w) || (py < 0) || (py >= g.h) || g.g[py][px] {
+			continue
+		}
+		g.g[py][px] = true
+		n++
+		stack = append(stack, [2]int{px + 1, py}, [2]int{px - 1, py}, [2]int{px, py + 1}, [2]int{px, py - 1})
+	}
+	return
+}
+
 // BraillePatterns returns a [][]rune containing Braille Pattern
 // runes based on internal grid values.
 func (g *PatternDotsGrid) BraillePatterns() (p [][]rune) {
@@ -248,17 +286,7 @@ func IsLowerBlockElement(r rune) bool {
 // corresponding to the float value. An empty rune will be returned if
 // float64 does not round to lowest 1/8 lower block.
 func LowerBlockElementFromFloat64(f float64) rune {
-	if f >= 1 {
-		return lowerBlockElements[8]
-	} else if f <= 0 {
-		return lowerBlockElements[0]
-	}
-	e := int(f / .125) // number of 1/8s blocks to show
-	// round remaining fraction smaller than 1/8 to nearest 1/16
-	if n := f - (float64(e) * .125); n >= 0.0625 {
-		e++
-	}
-	return lowerBlockElements[e]
+	return lowerBlockElements[eighthsFromFloat64(f)]
 }
 
 var leftBlockElements = [9]rune{
@@ -288,17 +316,83 @@ func IsLeftBlockElement(r rune) bool {
 // corresponding to the float value. An empty rune will be returned if
 // float64 does not round to lowest 1/8 left block.
 func LeftBlockElementFromFloat64(f float64) rune {
+	return leftBlockElements[eighthsFromFloat64(f)]
+}
+
+// eighthsFromFloat64 returns the number of 1/8s blocks
+// nearest to the given float64 bounded between 0 and 8.
+func eighthsFromFloat64(f float64) int {
 	if f >= 1 {
-		return leftBlockElements[8]
+		return 8
 	} else if f <= 0 {
-		return leftBlockElements[0]
+		return 0
 	}
 	e := int(f / .125) // number of 1/8s blocks to show
 	// round remaining fraction smaller than 1/8 to nearest 1/16
 	if n := f - (float64(e) * .125); n >= 0.0625 {
 		e++
 	}
-	return leftBlockElements[e]
+	return e
+}
+
+// IsUpperBlockElement returns whether a given rune is
+// considered an upper block or full block element.
+func IsUpperBlockElement(r rune) bool {
+	return (r == UpperBlockFour) || (r == UpperBlockOne) || (r == FullBlock)
+}
+
+// UpperBlockElementFromFloat64 returns either an empty rune
+// or a Block Element rune displaying the upper portion of a cell using given float64,
+// and whether the rune must be displayed with inversed
+// foreground and background colors.
+// Since Unicode only contains the 1/8 and 1/2 upper block elements,
+// other 1/8s will return the complementing lower block element to be displayed inversed.
+// A float64 < 1.0 will return the nearest one eights upper block element
+// corresponding to the float value. An empty rune will be returned if
+// float64 does not round to lowest 1/8 upper block.
+func UpperBlockElementFromFloat64(f float64) (r rune, inverse bool) {
+	switch e := eighthsFromFloat64(f); e {
+	case 0:
+		return Null, false
+	case 1:
+		return UpperBlockOne, false
+	case 4:
+		return UpperBlockFour, false
+	case 8:
+		return FullBlock, false
+	default:
+		return lowerBlockElements[8-e], true
+	}
+}
+
+// IsRightBlockElement returns whether a given rune is
+// considered a right block or full block element.
+func IsRightBlockElement(r rune) bool {
+	return (r == RightBlockFour) || (r == RightBlockOne) || (r == FullBlock)
+}
+
+// RightBlockElementFromFloat64 returns either an empty rune
+// or a Block Element rune displaying the right portion of a cell using given float64,
+// and whether the rune must be displayed with inversed
+// foreground and background colors.
+// Since Unicode only contains the 1/8 and 1/2 right block elements,
+// other 1/8s will return the complementing left block element to be displayed inversed.
+// A float64 < 1.0 will return the nearest one eights right block element
+// corresponding to the float value. An empty rune will be returned if
+// float64 does not round to lowest 1/8 right block.
+func RightBlockElementFromFloat64(f float64) (r rune, inverse bool) {
+	switch e := eighthsFromFloat64(f); e {
+	case 0:
+		return Null, false
+	case 1:
+		return RightBlockOne, false
+	case 4:
+		return RightBlockFour, false
+	case 8:
+		return FullBlock, false
+	default:
+		return leftBlockElements[8-e], true
+	}
 }
 
 // LineStyle enumerates the different style of line runes to display.
@@ -12,14 +12,17 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"math"
 	"os"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/NimbleMarkets/ntcharts/canvas/runes"
+	"github.com/NimbleMarkets/ntcharts/legend"
+	"github.com/NimbleMarkets/ntcharts/linechart"
+	"github.com/NimbleMarkets/ntcharts/linechart/panes"
 	tslc "github.com/NimbleMarkets/ntcharts/linechart/timeserieslinechart"
-	spark "github.com/NimbleMarkets/ntcharts/sparkline"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -48,6 +51,9 @@ var axisStyle = lipgloss.NewStyle().
 var labelStyle = lipgloss.NewStyle().
 	Foreground(lipgloss.Color("6")) // cyan
 
+var volumeStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("8")) // gray
+
 const ( // used for flag options and data set names
 	OpenOptionName     = "open"
 	HighOptionName     = "high"
@@ -80,6 +86,12 @@ type displayOptions struct {
 	LineStyle  runes.LineStyle
 	UseBraille bool // whether to draw braille lines
 	UseCandle  bool // whether to draw candlesticks instead of lines
+
+	CandleType tslc.CandleType // how candlesticks are drawn
+
+	Sessions *tslc.SessionCalendar // compresses X axis to trading days, nil to display all days
+
+	AutoFit bool // whether Y values are fitted to the displayed days
 }
 
 var displayOpts displayOptions
@@ -149,43 +161,63 @@ func NewRecord(s []string) (r record) {
 }
 
 type model struct {
-	chart       tslc.Model
-	sparkline   spark.Model
+	chart       *tslc.Model // price pane
+	volume      *tslc.Model // volume pane
+	panes       panes.Model
+	legend      legend.Model // legend inside price pane
 	zoneManager *zone.Manager
 
-	vol  map[int64]float64
 	minV float64
 	maxV float64
 }
 
-func newModel(minTime, maxTime time.Time, minY, maxY float64, tsm map[string][]tslc.TimePoint) *model {
+func newModel(minTime, maxTime time.Time, minY, maxY float64, tsm map[string][]tslc.TimePoint, recs []record) *model {
+	chart := tslc.New(20, 10,
+		tslc.WithTimeRange(minTime, maxTime),
+		tslc.WithYRange(minY, maxY),
+		tslc.WithAxesStyles(axisStyle, labelStyle),
+		tslc.WithCandleType(displayOpts.CandleType),
+		tslc.WithCandleStyles(highLineStyle, lowLineStyle),
+		tslc.WithHover(),
+		tslc.WithHoverTimeLayout("2006-01-02"),
+		tslc.WithHoverStyles(axisStyle, labelStyle),
+	)
+	volume := tslc.New(20, 5,
+		tslc.WithTimeRange(minTime, maxTime),
+		tslc.WithAxesStyles(axisStyle, labelStyle),
+		tslc.WithYLabelFormatter(func(i int, v float64) string {
+			return fmt.Sprintf("%.0fM", v/mil)
+		}),
+		tslc.WithStyle(volumeStyle),
+		tslc.WithAggregation(tslc.AggregateSum),
+		tslc.WithHover(),
+		tslc.WithHoverTimeLayout("2006-01-02"),
+		tslc.WithHoverStyles(axisStyle, labelStyle),
+	)
+	volume.HoverYFormatter = func(i int, v float64) string {
+		return fmt.Sprintf("%.2fM", v/mil)
+	}
 	m := model{
-		chart: tslc.New(20, 10,
-			tslc.WithTimeRange(minTime, maxTime),
-			tslc.WithYRange(minY, maxY),
-			tslc.WithAxesStyles(axisStyle, labelStyle),
-		),
-		sparkline:   spark.New(20, 10),
+		chart:       &chart,
+		volume:      &volume,
 		zoneManager: zone.New(),
-		vol:         make(map[int64]float64),
-		minV:        float64(minTime.Unix()),
-		maxV:        float64(maxTime.Unix()),
+		minV:        math.Inf(1),
+		maxV:        math.Inf(-1),
+	}
+	if displayOpts.Sessions != nil {
+		m.chart.SetSessionCalendar(displayOpts.Sessions)
+		m.volume.SetSessionCalendar(displayOpts.Sessions)
 	}
-	m.chart.Focus()
 
 	// set time series data for each line
 	for name, tsd := range tsm {
 		if name == VolumeOptionName {
 			for _, p := range tsd {
-				m.vol[p.Time.Unix()] = p.Value
-				switch {
-				case p.Value < m.minV:
-					m.minV = p.Value
-				case p.Value > m.maxV:
-					m.maxV = p.Value
-				}
+				m.volume.Push(p)
+				m.minV = math.Min(m.minV, p.Value)
+				m.maxV = math.Max(m.maxV, p.Value)
 			}
-		} else {
+		} else if !displayOpts.UseCandle {
 			m.chart.SetDataSetStyle(name, dataSetStyles[name])
 			if !displayOpts.UseBraille {
 				m.chart.SetDataSetLineStyle(name, displayOpts.LineStyle)
@@ -196,16 +228,57 @@ func newModel(minTime, maxTime time.Time, minY, maxY float64, tsm map[string][]t
 		}
 	}
 
-	// set X values such that each column is a single day
-	m.resetTimeRange()
+	// set candles from each record
+	if displayOpts.UseCandle {
+		for _, r := range recs {
+			c := r.Close
+			if displayOpts.AdjClose {
+				c = r.AdjustedClose
+			}
+			m.chart.PushCandle(r.Date, r.Open, r.High, r.Low, c, float64(r.Volume))
+		}
+	}
+
+	// fit Y values to the displayed days once all data is pushed,
+	// the first fit occurs when the displayed time range is set below
+	if displayOpts.AutoFit {
+		m.chart.SetAutoFitY(true)
+		m.chart.SetAutoFitYPadding(0.05)
+		m.volume.SetAutoFitY(true)
+		m.volume.SetAutoFitYZero(true) // volume columns are drawn from zero
+	}
+
+	// display legend inside the corner of the chart avoiding data
+	m.legend = legend.New(
+		legend.WithPosition(legend.Auto),
+		legend.WithLayout(legend.Vertical),
+		legend.WithSource(m.chart),
+	)
 
 	// replace default update handler with handler that
-	// moves graph left and right with mouse wheel
-	// incrementing by 10 days at a time
-	m.chart.UpdateHandler = tslc.DateNoZoomUpdateHandler(10)
+	// zooms into times selected with the mouse and
+	// moves graph left and right incrementing by 10 days at a time
+	newHandler := func() linechart.UpdateHandler {
+		if displayOpts.Sessions != nil {
+			return tslc.SessionBoxZoomUpdateHandler(displayOpts.Sessions, 10)
+		}
+		return tslc.DateBoxZoomUpdateHandler(10)
+	}
+	m.chart.UpdateHandler = newHandler()
+	m.volume.UpdateHandler = newHandler()
 
-	// use bubblezone to handle mouse events
-	m.chart.SetZoneManager(m.zoneManager)
+	// stack volume below prices sharing the time axis,
+	// using bubblezone to handle mouse events
+	opts := []panes.Option{panes.WithPane(m.chart, 4)}
+	if displayOpts.Volume {
+		opts = append(opts, panes.WithPane(m.volume, 1))
+	}
+	opts = append(opts, panes.WithZoneManager(m.zoneManager))
+	m.panes = panes.New(20, 10, opts...)
+	m.panes.Focus()
+
+	// set X values such that each column is a single day
+	m.resetTimeRange()
 	return &m
 }
 
@@ -213,26 +286,15 @@ func newModel(minTime, maxTime time.Time, minY, maxY float64, tsm map[string][]t
 func (m *model) resetTimeRange() {
 	viewMin := time.Unix(int64(m.chart.ViewMinX()), 0)
 	viewMax := viewMin.Add(time.Hour * time.Duration(24*m.chart.GraphWidth()))
+	if cal := displayOpts.Sessions; cal != nil { // each column is a single trading day
+		viewMax = cal.WallTime(cal.SessionTime(viewMin) + float64(daySeconds*m.chart.GraphWidth()))
+	}
 	if viewMax.Unix() > int64(m.chart.MaxX()) {
 		viewMax = time.Unix(int64(m.chart.MaxX()), 0)
 	}
 	m.chart.SetViewTimeRange(viewMin, viewMax)
-}
-
-// drawVolume draws the data set Volume onto the sparkline such that
-// each column below the corresponding date for that volume
-func (m *model) drawVolume() {
-	m.sparkline.Clear()
-	startX := int64(m.chart.ViewMinX())
-	endX := int64(m.chart.ViewMaxX())
-	for i := startX; i < endX; i += daySeconds {
-		v := m.vol[i]
-		if v != 0 {
-			v -= m.minV
-		}
-		m.sparkline.Push(float64(v))
-	}
-	m.sparkline.Draw()
+	m.panes.SetActive(0)
+	m.panes.Sync()
 }
 
 func (m model) Init() tea.Cmd {
@@ -244,63 +306,54 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		// resize window to terminal screen sizes
 		if displayOpts.Volume {
-			cHeight := (msg.Height * 4 / 6)
-			sHeight := (msg.Height * 1 / 6)
-			extra := msg.Height - (sHeight + cHeight) - 5
-			m.chart.Resize(msg.Width-2, cHeight+extra)
-			m.resetTimeRange()
-			m.sparkline.Resize(msg.Width-2, sHeight)
+			m.panes.Resize(msg.Width-2, msg.Height-5) // extra line for volume range
 		} else {
-			m.chart.Resize(msg.Width-2, msg.Height-4)
-			m.resetTimeRange()
+			m.panes.Resize(msg.Width-2, msg.Height-4)
 		}
+		m.resetTimeRange()
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "q", "ctrl+c":
 			return m, tea.Quit
 		}
 	}
+	// number keys and clicking legend entries hide and show lines
+	m.legend, _ = m.legend.Update(msg)
+	m.panes, _ = m.panes.Update(msg)
 	// choose which rune drawing method to use based on user options
-	m.chart, _ = m.chart.Update(msg)
 	switch {
 	case displayOpts.UseCandle:
-		// candlesticks for timeserieschart require four data sets containing
-		// the open, close, high and low values.
-		// DrawCandle() assumes that each data set contains the same number of data points
-		// and the TimePoint.Time value is the same at a specific index for each data set.
-		if displayOpts.AdjClose {
-			m.chart.DrawCandle(OpenOptionName, HighOptionName, LowOptionName, AdjCloseOptionName, highLineStyle, lowLineStyle)
-		} else {
-			m.chart.DrawCandle(OpenOptionName, HighOptionName, LowOptionName, CloseOptionName, highLineStyle, lowLineStyle)
-		}
+		m.chart.DrawCandles()
 	case displayOpts.UseBraille:
 		m.chart.DrawBrailleAll()
 	default:
 		m.chart.DrawAll()
 	}
+	m.legend.Draw(&m.chart.Canvas, m.chart.GraphArea())
+	m.chart.DrawCursor()    // data cursor moved with the keyboard
+	m.chart.DrawSelection() // box zoom selected with the mouse
+	m.chart.DrawHover()     // crosshair and values under the mouse
 	if displayOpts.Volume {
-		m.drawVolume()
+		m.volume.DrawColumns()
+		m.volume.DrawSelection()
+		m.volume.DrawHover()
 	}
 	return m, nil
 }
 
 func (m model) View() string {
 	// combine line chart and sparkline if showing volume
-	var graphView string
+	graphView := m.panes.View()
 	if displayOpts.Volume {
 		graphView = lipgloss.JoinVertical(lipgloss.Left,
-			m.chart.View(),
-			fmt.Sprintf("Daily Volume Range: %.02fM - %0.2fM\n", m.minV/mil, m.maxV/mil),
-			m.sparkline.View(),
+			graphView,
+			fmt.Sprintf("Daily Volume Range: %.02fM - %0.2fM", m.minV/mil, m.maxV/mil),
 		)
-	} else {
-		graphView = m.chart.View()
 	}
 
-	legend := getChartLegend()
 	startDate := time.Unix(int64(m.chart.MinX()), 0).UTC()
 	endDate := time.Unix(int64(m.chart.MaxX()), 0).UTC()
-	header := fmt.Sprintf("OHLC Chart from %s to %s. Legend [%s ]\n", startDate, endDate, legend)
+	header := fmt.Sprintf("OHLC Chart from %s to %s\n", startDate, endDate)
 	s := defaultStyle.Render(header + graphView)
 
 	// wrap output string in bubblezone.Manager.Scan()
@@ -308,33 +361,6 @@ func (m model) View() string {
 	return m.zoneManager.Scan(s)
 }
 
-// getChartLegend returns string containing legend for chart
-// depending on user display options
-func getChartLegend() (l string) {
-	if displayOpts.UseCandle {
-		l += highLineStyle.Render(" BULLISH")
-		l += lowLineStyle.Render(" BEARISH")
-	} else {
-		if displayOpts.All || displayOpts.Open {
-			l += openLineStyle.Render(" OPEN")
-		}
-		if displayOpts.All || displayOpts.High {
-			l += highLineStyle.Render(" HIGH")
-		}
-		if displayOpts.All || displayOpts.Low {
-			l += lowLineStyle.Render(" LOW")
-		}
-		if displayOpts.All || displayOpts.Close {
-			if displayOpts.AdjClose {
-				l += closeLineStyle.Render(" ADJCLOSE")
-			} else {
-				l += closeLineStyle.Render(" CLOSE")
-			}
-		}
-	}
-	return
-}
-
 // recordsFromCSV reads from a io.Reader and returns
 // a slice of record objects
 func recordsFromCSV(r io.Reader) (s []record) {
@@ -441,25 +467,66 @@ func timeseriesFromRecords(r []record) (s map[string][]tslc.TimePoint, minY floa
 	return
 }
 
+// sessionsFromFlags returns a SessionCalendar with daily sessions
+// every weekday in UTC excluding holidays given by
+// comma separated dates such as "2024-01-01,2024-12-25".
+func sessionsFromFlags(holidays string) *tslc.SessionCalendar {
+	cal := tslc.NewWeekdaySessionCalendar(time.UTC, 0, daySeconds*time.Second)
+	for _, s := range strings.Split(holidays, ",") {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		t, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			log.Fatalf("Wrong holiday date format for value: %s\n", s)
+		}
+		cal.AddHolidays(t)
+	}
+	return cal
+}
+
 func main() {
 	var useThinStyle bool
+	var useSessions bool
+	var holidays string
+	var candleType string
 	var filePath string
 	flag.StringVar(&filePath, "filepath", "", "filepath to OHLC csv file, '-' to read from stdin")
 	flag.BoolVar(&useThinStyle, "thin", false, "use thin lines (default: arc lines)")
 	flag.BoolVar(&displayOpts.UseBraille, "braille", false, "use braille lines (default: arc lines)")
 	flag.BoolVar(&displayOpts.UseCandle, "candle", false, "use candlesticks (shows all lines) (default: arc lines)")
+	flag.StringVar(&candleType, "candletype", "filled", "how to draw candlesticks: filled, hollow, ohlc or heikinashi (only used if --candle enabled)")
 	flag.BoolVar(&displayOpts.Open, OpenOptionName, false, "whether to display OPEN line")
 	flag.BoolVar(&displayOpts.High, HighOptionName, false, "whether to display HIGH line")
 	flag.BoolVar(&displayOpts.Low, LowOptionName, false, "whether to display LOW line")
 	flag.BoolVar(&displayOpts.Close, CloseOptionName, false, "whether to display CLOSE line")
 	flag.BoolVar(&displayOpts.AdjClose, AdjCloseOptionName, false, "whether to replace CLOSE line with Adjusted CLOSE line (only used if --close enabled)")
 	flag.BoolVar(&displayOpts.Volume, VolumeOptionName, false, "whether to display sparkline containing VOLUME")
+	flag.BoolVar(&useSessions, "sessions", false, "skip weekends and holidays on the time axis")
+	flag.StringVar(&holidays, "holidays", "", "comma separated holiday dates (YYYY-MM-DD) to skip (only used if --sessions enabled)")
+	flag.BoolVar(&displayOpts.AutoFit, "autofit", false, "fit the Y axes to the displayed days")
 	flag.Parse()
 
 	// if nothing specified, default to display all OHLC lines (automatically display all lines if showing candlesticks)
 	if displayOpts.UseCandle || (!displayOpts.Open && !displayOpts.High && !displayOpts.Low && !displayOpts.Close) {
 		displayOpts.All = true
 	}
+	if useSessions {
+		displayOpts.Sessions = sessionsFromFlags(holidays)
+	}
+	switch candleType {
+	case "filled":
+		displayOpts.CandleType = tslc.CandleFilled
+	case "hollow":
+		displayOpts.CandleType = tslc.CandleHollow
+	case "ohlc":
+		displayOpts.CandleType = tslc.CandleOHLC
+	case "heikinashi":
+		displayOpts.CandleType = tslc.CandleHeikinAshi
+	default:
+		fmt.Println("Unknown candle type:", candleType)
+		os.Exit(1)
+	}
 	if useThinStyle {
 		displayOpts.LineStyle = runes.ThinLineStyle
 	} else {
@@ -489,8 +556,8 @@ func main() {
 	ts, minY, maxY, minTime, maxTime := timeseriesFromRecords(records)
 
 	// create model and start bubbletea Program
-	m := newModel(minTime, maxTime, minY, maxY, ts)
-	if _, err := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion()).Run(); err != nil {
+	m := newModel(minTime, maxTime, minY, maxY, ts, records)
+	if _, err := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseAllMotion()).Run(); err != nil {
 		fmt.Println("Error running program:", err)
 		os.Exit(1)
 	}
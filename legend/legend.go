@@ -0,0 +1,576 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+// Package legend implements a legend displaying a marker and name for each
+// data set of a chart, drawn either inside the graphing area of the chart
+// or rendered outside of the chart.  Entries are read from charts
+// implementing Source, such as streamlinechart, timeserieslinechart,
+// wavelinechart and barchart Models:
+//
+//	lg := legend.New(legend.WithPosition(legend.Auto), legend.WithSource(&chart))
+//	chart.DrawAll()
+//	lg.Draw(&chart.Canvas, chart.GraphArea())
+//
+// Data sets of Sources implementing Toggler can be hidden and shown
+// by pressing number keys or clicking legend entries with Update.
+package legend
+
+import (
+	"image"
+
+	"github.com/NimbleMarkets/ntcharts/canvas"
+	"github.com/NimbleMarkets/ntcharts/canvas/runes"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+)
+
+// Ellipsis is the rune displayed in place of truncated runes.
+const Ellipsis = '…' // …
+
+// DefaultGap is the default number of spaces between
+// entries of legends with horizontal layout.
+const DefaultGap = 2
+
+var defaultStyle = lipgloss.NewStyle()
+var defaultHiddenStyle = lipgloss.NewStyle().Faint(true)
+
+// Entry is a legend entry with a name and a marker
+// displayed with the style of a data set.
+type Entry struct {
+	Name    string
+	Marker  string         // runes displayed before the name
+	Style   lipgloss.Style // style applied when drawing the marker
+	DataSet string         // name of data set hidden and shown by the entry, empty if none
+	Hidden  bool           // whether the data set is hidden
+}
+
+// Source is a chart providing legend entries for its data sets.
+type Source interface {
+	LegendEntries() []Entry
+}
+
+// Toggler is a Source with data sets that can be hidden and shown.
+type Toggler interface {
+	Source
+	SetDataSetVisible(n string, b bool)
+	DataSetVisible(n string) bool
+}
+
+// zoned is a Source displayed within a bubblezone zone.
+type zoned interface {
+	ZoneManager() *zone.Manager
+	ZoneID() string
+}
+
+// LineMarker returns the marker of lines drawn with given LineStyle.
+func LineMarker(ls runes.LineStyle) string {
+	if ls == runes.ArcLineStyle {
+		return string([]rune{runes.ArcDownRight, runes.ArcUpLeft})
+	}
+	return string([]rune{runes.LineHorizontal, runes.LineHorizontal})
+}
+
+// Position is the position of the legend relative to the chart.
+type Position int
+
+const (
+	TopRight    Position = iota // inside top right corner of graphing area
+	TopLeft                     // inside top left corner of graphing area
+	BottomRight                 // inside bottom right corner of graphing area
+	BottomLeft                  // inside bottom left corner of graphing area
+	Auto                        // inside corner overlapping the fewest drawn cells
+	Top                         // outside above the chart
+	Bottom                      // outside below the chart
+	Left                        // outside left of the chart
+	Right                       // outside right of the chart
+)
+
+// Inside returns whether the Position is inside the graphing area.
+func (p Position) Inside() bool {
+	return p <= Auto
+}
+
+// Layout is the arrangement of legend entries.
+type Layout int
+
+const (
+	Horizontal Layout = iota // entries in a single row
+	Vertical                 // one entry for each row
+)
+
+// Model contains state of a legend.
+type Model struct {
+	NameStyle   lipgloss.Style // style applied when drawing entry names
+	HiddenStyle lipgloss.Style // style applied when drawing entries of hidden data sets
+
+	source   Source // provides entries if not nil
+	entries  []Entry
+	position Position
+	layout   Layout
+	gap      int  // number of spaces between entries of horizontal layout
+	maxWidth int  // maximum width of legends left or right of the chart, 0 if unlimited
+	keys     bool // whether number keys hide and show data sets
+
+	// canvas coordinates of each displayed entry
+	// from the last time the legend was drawn or rendered
+	bounds []image.Rectangle
+	inside bool // whether the legend was last drawn inside the chart
+
+	zoneManager *zone.Manager // provides mouse functionality for legends outside the chart
+	zoneID      string
+}
+
+// New returns a legend Model initialized with various options.
+// By default, the legend is drawn horizontally in the
+// top right corner of the graphing area.
+func New(opts ...Option) Model {
+	m := Model{
+		NameStyle:   defaultStyle,
+		HiddenStyle: defaultHiddenStyle,
+		position:    TopRight,
+		layout:      Horizontal,
+		gap:         DefaultGap,
+		keys:        true,
+	}
+	for _, opt := range opts {
+		opt(&m)
+	}
+	return m
+}
+
+// Entries returns the legend entries.
+func (m *Model) Entries() []Entry {
+	return m.entries
+}
+
+// SetEntries sets the legend entries, replacing the Source if any.
+func (m *Model) SetEntries(e []Entry) {
+	m.source = nil
+	m.entries = e
+}
+
+// SetSource sets the Source providing the legend entries, such as
+// a pointer to a chart Model.  Entries are read from the Source
+// whenever the legend is drawn or rendered.
+func (m *Model) SetSource(s Source) {
+	m.source = s
+	m.refresh()
+}
+
+// Source returns the Source providing the legend entries, or nil.
+func (m *Model) Source() Source {
+	return m.source
+}
+
+// refresh reads the legend entries from the Source if any.
+func (m *Model) refresh() {
+	if m.source != nil {
+		m.entries = m.source.LegendEntries()
+	}
+}
+
+// Position returns the legend Position.
+func (m *Model) Position() Position {
+	return m.position
+}
+
+// SetPosition sets the legend Position.
+func (m *Model) SetPosition(p Position) {
+	m.position = p
+}
+
+// Layout returns the legend Layout.
+func (m *Model) Layout() Layout {
+	return m.layout
+}
+
+// SetLayout sets the legend Layout.
+func (m *Model) SetLayout(l Layout) {
+	m.layout = l
+}
+
+// Gap returns the number of spaces between entries of horizontal layout.
+func (m *Model) Gap() int {
+	return m.gap
+}
+
+// SetGap sets the number of spaces between entries of horizontal layout.
+func (m *Model) SetGap(g int) {
+	m.gap = max(g, 0)
+}
+
+// MaxWidth returns the maximum width of legends left or right
+// of the chart, or 0 if unlimited.
+func (m *Model) MaxWidth() int {
+	return m.maxWidth
+}
+
+// SetMaxWidth sets the maximum width of legends left
+// or right of the chart, with 0 being unlimited.
+func (m *Model) SetMaxWidth(w int) {
+	m.maxWidth = max(w, 0)
+}
+
+// SetToggleKeys sets whether pressing number keys 1 to 9 hides and shows
+// the data sets of the legend entries with data sets in order.
+func (m *Model) SetToggleKeys(b bool) {
+	m.keys = b
+}
+
+// ToggleKeys returns whether pressing number keys hides and shows data sets.
+func (m *Model) ToggleKeys() bool {
+	return m.keys
+}
+
+// SetZoneManager enables mouse functionality of legends
+// rendered outside of the chart by setting a bubblezone Manager.
+// Legends drawn inside the chart use the bubblezone Manager
+// of the Source.
+func (m *Model) SetZoneManager(zm *zone.Manager) {
+	m.zoneManager = zm
+	if (zm != nil) && (m.zoneID == "") {
+		m.zoneID = zm.NewPrefix()
+	}
+}
+
+// ZoneManager will return legend zone Manager.
+func (m *Model) ZoneManager() *zone.Manager {
+	return m.zoneManager
+}
+
+// ZoneID will return legend zone ID used by zone Manager.
+func (m *Model) ZoneID() string {
+	return m.zoneID
+}
+
+// Lines returns the cells of each line of the legend bounded by given
+// width and height, with 0 being unlimited.  Lines have the same width.
+// Entry names are truncated and entries are replaced by an ellipsis
+// if there is not enough space to display them.
+func (m *Model) Lines(w, h int) []canvas.CellLine {
+	r, _ := m.lines(w, h)
+	return r
+}
+
+// lines returns the lines of the legend bounded by given width and height
+// and the coordinates of each entry within the lines.
+func (m *Model) lines(w, h int) (r []canvas.CellLine, bounds []image.Rectangle) {
+	if (len(m.entries) == 0) || (w < 0) || (h < 0) {
+		return nil, nil
+	}
+	bounds = make([]image.Rectangle, len(m.entries))
+	if m.layout == Vertical {
+		r = m.verticalLines(w, h, bounds)
+	} else {
+		r = []canvas.CellLine{m.horizontalLine(w, bounds)}
+		if h > 0 {
+			r = r[:min(len(r), h)]
+		}
+	}
+	// pad lines to the same width
+	width := 0
+	for _, l := range r {
+		width = max(width, len(l))
+	}
+	for i, l := range r {
+		for len(l) < width {
+			l = append(l, canvas.NewCellWithStyle(' ', m.NameStyle))
+		}
+		r[i] = l
+	}
+	return
+}
+
+// verticalLines returns a line for each entry bounded by given width
+// and height, setting the coordinates of each entry in bounds.
+func (m *Model) verticalLines(w, h int, bounds []image.Rectangle) (r []canvas.CellLine) {
+	entries := m.entries
+	truncated := false
+	if (h > 0) && (len(entries) > h) {
+		entries = entries[:h-1]
+		truncated = true
+	}
+	for i, e := range entries {
+		l := m.truncateLine(m.entryCells(e, 0), w)
+		bounds[i] = image.Rect(0, i, len(l), i+1)
+		r = append(r, l)
+	}
+	if truncated {
+		r = append(r, canvas.CellLine{canvas.NewCellWithStyle(Ellipsis, m.NameStyle)})
+	}
+	return
+}
+
+// horizontalLine returns a line containing all entries bounded by given width,
+// truncating names to the same maximum length and then removing entries
+// until the line fits, setting the coordinates of each entry in bounds.
+func (m *Model) horizontalLine(w int, bounds []image.Rectangle) canvas.CellLine {
+	maxName := 0
+	for _, e := range m.entries {
+		maxName = max(maxName, len([]rune(e.Name)))
+	}
+	for n := max(maxName, 1); n > 0; n-- {
+		if l := m.joinEntries(m.entries, n, bounds); (w == 0) || (len(l) <= w) {
+			return l
+		}
+	}
+	// remove last entries until remaining entries fit
+	for i := len(m.entries) - 1; i > 0; i-- {
+		clear(bounds)
+		l := m.joinEntries(m.entries[:i], 1, bounds)
+		l = append(l, m.gapCells()...)
+		l = append(l, canvas.NewCellWithStyle(Ellipsis, m.NameStyle))
+		if len(l) <= w {
+			return l
+		}
+	}
+	clear(bounds)
+	l := m.truncateLine(m.joinEntries(m.entries[:1], 1, bounds), w)
+	bounds[0].Max.X = len(l)
+	return l
+}
+
+// joinEntries returns a line of given entries with names truncated to n runes,
+// setting the coordinates of each entry in bounds.
+func (m *Model) joinEntries(entries []Entry, n int, bounds []image.Rectangle) (r canvas.CellLine) {
+	for i, e := range entries {
+		if i > 0 {
+			r = append(r, m.gapCells()...)
+		}
+		start := len(r)
+		r = append(r, m.entryCells(e, n)...)
+		bounds[i] = image.Rect(start, 0, len(r), 1)
+	}
+	return
+}
+
+// gapCells returns the cells between entries of horizontal layout.
+func (m *Model) gapCells() (r canvas.CellLine) {
+	for i := 0; i < m.gap; i++ {
+		r = append(r, canvas.NewCellWithStyle(' ', m.NameStyle))
+	}
+	return
+}
+
+// entryCells returns the cells of the marker and name of given entry,
+// with the name truncated to n runes if n is greater than 0.
+func (m *Model) entryCells(e Entry, n int) (r canvas.CellLine) {
+	ms, ns := e.Style, m.NameStyle
+	if e.Hidden {
+		ms, ns = m.HiddenStyle, m.HiddenStyle
+	}
+	for _, c := range e.Marker {
+		r = append(r, canvas.NewCellWithStyle(c, ms))
+	}
+	name := []rune(e.Name)
+	if len(name) == 0 {
+		return
+	}
+	if len(r) > 0 {
+		r = append(r, canvas.NewCellWithStyle(' ', m.NameStyle))
+	}
+	if (n > 0) && (len(name) > n) {
+		name = append(name[:n-1], Ellipsis)
+	}
+	for _, c := range name {
+		r = append(r, canvas.NewCellWithStyle(c, ns))
+	}
+	return
+}
+
+// truncateLine returns given line truncated to width w ending with
+// an ellipsis if it is longer than w, with 0 being unlimited.
+func (m *Model) truncateLine(l canvas.CellLine, w int) canvas.CellLine {
+	if (w == 0) || (len(l) <= w) {
+		return l
+	}
+	l = l[:w]
+	l[w-1] = canvas.NewCellWithStyle(Ellipsis, m.NameStyle)
+	return l
+}
+
+// Draw draws the legend on given canvas inside given area, such as the
+// graphing area of a chart, if the legend Position is inside the graphing area.
+// Should be called after drawing the chart, since cells behind the
+// legend are replaced and the Auto Position avoids drawn cells.
+func (m *Model) Draw(c *canvas.Model, area image.Rectangle) {
+	if !m.position.Inside() {
+		return
+	}
+	m.refresh()
+	lines, bounds := m.lines(area.Dx(), area.Dy())
+	m.bounds = nil
+	if len(lines) == 0 {
+		return
+	}
+	p := m.corner(c, area, len(lines[0]), len(lines))
+	for i := range bounds {
+		bounds[i] = bounds[i].Add(p)
+	}
+	m.bounds = bounds
+	m.inside = true
+	for y, l := range lines {
+		for x, cell := range l {
+			c.SetCell(canvas.Point{X: p.X + x, Y: p.Y + y}, cell)
+		}
+	}
+}
+
+// corner returns the canvas coordinates of the top left of a legend with given
+// width and height in the corner of given area given by the legend Position.
+func (m *Model) corner(c *canvas.Model, area image.Rectangle, w, h int) canvas.Point {
+	corners := map[Position]canvas.Point{
+		TopRight:    {X: area.Max.X - w, Y: area.Min.Y},
+		TopLeft:     {X: area.Min.X, Y: area.Min.Y},
+		BottomRight: {X: area.Max.X - w, Y: area.Max.Y - h},
+		BottomLeft:  {X: area.Min.X, Y: area.Max.Y - h},
+	}
+	if m.position != Auto {
+		return corners[m.position]
+	}
+	// choose corner with the fewest drawn cells
+	best := TopRight
+	fewest := -1
+	for _, pos := range []Position{TopRight, TopLeft, BottomRight, BottomLeft} {
+		p := corners[pos]
+		n := 0
+		for y := p.Y; y < p.Y+h; y++ {
+			for x := p.X; x < p.X+w; x++ {
+				if r := c.Cell(canvas.Point{X: x, Y: y}).Rune; (r != runes.Null) && (r != ' ') {
+					n++
+				}
+			}
+		}
+		if (fewest < 0) || (n < fewest) {
+			best = pos
+			fewest = n
+		}
+	}
+	return corners[best]
+}
+
+// Render returns the legend as a string bounded
+// by given width and height, with 0 being unlimited.
+// The string is marked with the legend zone ID if
+// the legend has a bubblezone Manager.
+func (m *Model) Render(w, h int) string {
+	m.refresh()
+	lines, bounds := m.lines(w, h)
+	m.bounds = bounds
+	m.inside = false
+	if len(lines) == 0 {
+		return ""
+	}
+	c := canvas.New(len(lines[0]), len(lines))
+	for y, l := range lines {
+		for x, cell := range l {
+			c.SetCell(canvas.Point{X: x, Y: y}, cell)
+		}
+	}
+	if m.zoneManager != nil {
+		return m.zoneManager.Mark(m.zoneID, c.View())
+	}
+	return c.View()
+}
+
+// Join returns the given chart view string joined with the legend
+// rendered outside of the chart if the legend Position is outside
+// of the graphing area, or the chart view string otherwise.
+// Legends above or below the chart are bounded by the chart width,
+// and legends left or right of the chart are bounded by the chart
+// height and maximum width.
+func (m *Model) Join(chart string) string {
+	var s string
+	switch m.position {
+	case Top, Bottom:
+		s = m.Render(lipgloss.Width(chart), 0)
+	case Left, Right:
+		s = m.Render(m.maxWidth, lipgloss.Height(chart))
+	}
+	if s == "" {
+		return chart
+	}
+	switch m.position {
+	case Top:
+		return lipgloss.JoinVertical(lipgloss.Left, s, chart)
+	case Bottom:
+		return lipgloss.JoinVertical(lipgloss.Left, chart, s)
+	case Left:
+		return lipgloss.JoinHorizontal(lipgloss.Top, s, chart)
+	default:
+		return lipgloss.JoinHorizontal(lipgloss.Top, chart, s)
+	}
+}
+
+// EntryAt returns the entry displayed at given coordinates of the canvas
+// the legend was last drawn on, or of the string the legend was last
+// rendered as, and whether there is an entry at the coordinates.
+func (m *Model) EntryAt(p canvas.Point) (Entry, bool) {
+	for i, b := range m.bounds {
+		if (i < len(m.entries)) && p.In(b) {
+			return m.entries[i], true
+		}
+	}
+	return Entry{}, false
+}
+
+// Update processes bubbletea Msg to hide and show data sets of a Source
+// implementing Toggler.  Pressing number keys 1 to 9 toggles the data sets
+// of the entries with data sets in order, and clicking an entry with
+// the left mouse button toggles its data set using bubblezone.
+// Legends drawn inside the chart require the Source to implement
+// ZoneManager() and ZoneID(), and legends rendered outside
+// of the chart require SetZoneManager().
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	t, ok := m.source.(Toggler)
+	if !ok {
+		return m, nil
+	}
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if !m.keys || (msg.Type != tea.KeyRunes) || (len(msg.Runes) != 1) {
+			break
+		}
+		i := int(msg.Runes[0] - '1')
+		if (i < 0) || (i > 8) {
+			break
+		}
+		for _, e := range m.entries {
+			if e.DataSet == "" {
+				continue
+			}
+			if i == 0 {
+				m.toggle(t, e)
+				break
+			}
+			i--
+		}
+	case tea.MouseMsg:
+		if (msg.Action != tea.MouseActionPress) || (msg.Button != tea.MouseButtonLeft) {
+			break
+		}
+		zm, id := m.zoneManager, m.zoneID
+		if z, ok := m.source.(zoned); ok && m.inside {
+			zm, id = z.ZoneManager(), z.ZoneID()
+		}
+		if zm == nil {
+			break
+		}
+		zInfo := zm.Get(id)
+		if !zInfo.InBounds(msg) {
+			break
+		}
+		x, y := zInfo.Pos(msg)
+		if e, ok := m.EntryAt(canvas.Point{X: x, Y: y}); ok && (e.DataSet != "") {
+			m.toggle(t, e)
+		}
+	}
+	return m, nil
+}
+
+// toggle hides or shows the data set of given entry.
+func (m *Model) toggle(t Toggler, e Entry) {
+	t.SetDataSetVisible(e.DataSet, !t.DataSetVisible(e.DataSet))
+	m.refresh()
+}
@@ -0,0 +1,113 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package legend
+
+import (
+	"image"
+	"testing"
+
+	"github.com/NimbleMarkets/ntcharts/canvas"
+	"github.com/NimbleMarkets/ntcharts/canvas/runes"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var testEntries = []Entry{
+	{Name: "alpha", Marker: "─", Style: lipgloss.NewStyle()},
+	{Name: "beta", Marker: "─", Style: lipgloss.NewStyle()},
+	{Name: "gamma", Marker: "─", Style: lipgloss.NewStyle()},
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout Layout
+		w, h   int
+		want   string
+	}{
+		{"unlimited", Horizontal, 0, 0, "─ alpha  ─ beta  ─ gamma"},
+		{"truncated names", Horizontal, 20, 0, "─ al…  ─ be…  ─ ga…"},
+		{"removed entries", Horizontal, 12, 0, "─ …  ─ …  …"},
+		{"vertical", Vertical, 0, 0, "─ alpha\n─ beta \n─ gamma"},
+		{"vertical truncated", Vertical, 5, 2, "─ al…\n…    "},
+	}
+	for _, tc := range tests {
+		lg := New(WithEntries(testEntries), WithLayout(tc.layout))
+		if got := lg.Render(tc.w, tc.h); got != tc.want {
+			t.Errorf("%s: got\n%q\nexpected\n%q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestDrawAuto(t *testing.T) {
+	c := canvas.New(10, 4)
+	// occupy top right and bottom left corners
+	c.SetRune(canvas.Point{X: 9, Y: 0}, runes.FullBlock)
+	c.SetRune(canvas.Point{X: 0, Y: 3}, runes.FullBlock)
+	lg := New(WithEntries(testEntries[:1]), WithPosition(Auto))
+	lg.Draw(&c, image.Rect(0, 0, 10, 4))
+	if got := c.View(); got != "─ alpha  █\n          \n          \n█         " {
+		t.Errorf("legend not drawn in top left corner:\n%s", got)
+	}
+}
+
+func TestJoin(t *testing.T) {
+	lg := New(WithEntries(testEntries[:2]), WithPosition(Bottom))
+	if got := lg.Join("0123456789"); got != "0123456789\n─ a…  ─ b…" {
+		t.Errorf("wrong joined legend:\n%q", got)
+	}
+	lg.SetPosition(TopRight)
+	if got := lg.Join("chart"); got != "chart" {
+		t.Errorf("inside legend joined:\n%q", got)
+	}
+}
+
+// testToggler is a Toggler with a data set for each entry except the first.
+type testToggler struct {
+	hidden map[string]bool
+}
+
+func (t *testToggler) LegendEntries() []Entry {
+	r := []Entry{{Name: "candles", Marker: "┃"}}
+	for _, n := range []string{"a", "b"} {
+		r = append(r, Entry{Name: n, Marker: "─", DataSet: n, Hidden: t.hidden[n]})
+	}
+	return r
+}
+
+func (t *testToggler) SetDataSetVisible(n string, b bool) {
+	t.hidden[n] = !b
+}
+
+func (t *testToggler) DataSetVisible(n string) bool {
+	return !t.hidden[n]
+}
+
+func TestToggle(t *testing.T) {
+	tt := &testToggler{hidden: make(map[string]bool)}
+	lg := New(WithSource(tt))
+	// number keys skip entries without data sets
+	lg, _ = lg.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'2'}})
+	if !tt.hidden["b"] || tt.hidden["a"] {
+		t.Errorf("wrong data set hidden:%v", tt.hidden)
+	}
+	if e := lg.Entries()[2]; !e.Hidden {
+		t.Errorf("entries not refreshed after toggling")
+	}
+	lg, _ = lg.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'2'}})
+	if tt.hidden["b"] {
+		t.Errorf("data set not shown after toggling twice")
+	}
+
+	// entries are found at coordinates of the rendered legend
+	if got := lg.Render(0, 0); got != "┃ candles  ─ a  ─ b" {
+		t.Errorf("wrong rendered legend:%q", got)
+	}
+	if e, ok := lg.EntryAt(canvas.Point{X: 12, Y: 0}); !ok || (e.DataSet != "a") {
+		t.Errorf("wrong entry at coordinates:%v,%v", e, ok)
+	}
+	if _, ok := lg.EntryAt(canvas.Point{X: 9, Y: 0}); ok {
+		t.Errorf("entry found between entries")
+	}
+}
@@ -0,0 +1,85 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package legend
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+)
+
+// Option is used to set options when initializing a legend. Example:
+//
+//	lg := New(WithPosition(Auto), WithLayout(Vertical))
+type Option func(*Model)
+
+// WithEntries sets the legend entries.
+func WithEntries(e []Entry) Option {
+	return func(m *Model) {
+		m.SetEntries(e)
+	}
+}
+
+// WithSource sets the Source providing the legend entries.
+func WithSource(s Source) Option {
+	return func(m *Model) {
+		m.SetSource(s)
+	}
+}
+
+// WithPosition sets the legend Position.
+func WithPosition(p Position) Option {
+	return func(m *Model) {
+		m.SetPosition(p)
+	}
+}
+
+// WithLayout sets the legend Layout.
+func WithLayout(l Layout) Option {
+	return func(m *Model) {
+		m.SetLayout(l)
+	}
+}
+
+// WithGap sets the number of spaces between entries of horizontal layout.
+func WithGap(g int) Option {
+	return func(m *Model) {
+		m.SetGap(g)
+	}
+}
+
+// WithMaxWidth sets the maximum width of legends left
+// or right of the chart, with 0 being unlimited.
+func WithMaxWidth(w int) Option {
+	return func(m *Model) {
+		m.SetMaxWidth(w)
+	}
+}
+
+// WithNameStyle sets the style applied when drawing entry names.
+func WithNameStyle(s lipgloss.Style) Option {
+	return func(m *Model) {
+		m.NameStyle = s
+	}
+}
+
+// WithHiddenStyle sets the style applied when drawing entries of hidden data sets.
+func WithHiddenStyle(s lipgloss.Style) Option {
+	return func(m *Model) {
+		m.HiddenStyle = s
+	}
+}
+
+// WithToggleKeys sets whether pressing number keys hides and shows data sets.
+func WithToggleKeys(b bool) Option {
+	return func(m *Model) {
+		m.SetToggleKeys(b)
+	}
+}
+
+// WithZoneManager sets the bubblezone Manager used when processing
+// bubbletea Msg mouse events in Update() for legends outside the chart.
+func WithZoneManager(zm *zone.Manager) Option {
+	return func(m *Model) {
+		m.SetZoneManager(zm)
+	}
+}
@@ -0,0 +1,80 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package linechart
+
+// File contains fitting the displayed Y values to the data values
+// within the displayed X values, performed by the linechart wrappers.
+
+import (
+	"math"
+)
+
+// SetAutoFitY sets whether the displayed Y values are fitted to the data
+// values within the displayed X values whenever the displayed X values
+// change, and once when drawing or updating after data values are pushed.
+// Fitting is performed by the streamlinechart, timeserieslinechart and
+// wavelinechart, which store the data values of the linechart.
+func (m *Model) SetAutoFitY(b bool) {
+	m.autoFitY = b
+}
+
+// AutoFitY returns whether the displayed Y values are fitted
+// to the data values within the displayed X values.
+func (m *Model) AutoFitY() bool {
+	return m.autoFitY
+}
+
+// SetAutoFitYPadding sets the fraction of the range of fitted data values
+// added above and below the data values in the transformed space of the
+// Y axis, such as 0.05 for a margin of 5%.  The default padding is 0.
+func (m *Model) SetAutoFitYPadding(p float64) {
+	m.autoFitPad = math.Max(p, 0)
+}
+
+// AutoFitYPadding returns the fraction of the range of
+// fitted data values added above and below the data values.
+func (m *Model) AutoFitYPadding() float64 {
+	return m.autoFitPad
+}
+
+// SetAutoFitYZero sets whether the fitted Y values include zero,
+// such as for volumes drawn as columns from zero.
+func (m *Model) SetAutoFitYZero(b bool) {
+	m.autoFitZero = b
+}
+
+// AutoFitYZero returns whether the fitted Y values include zero.
+func (m *Model) AutoFitYZero() bool {
+	return m.autoFitZero
+}
+
+// FitViewYRange updates the displayed Y values to given minimum and maximum
+// data values with the auto fit padding, including zero if enabled.
+// Constant data values are displayed in the middle of the graphing area.
+// Expected Y values are extended to the fitted Y values if automatically
+// adjusted, and otherwise bound the fitted Y values.
+// Returns whether the displayed Y values have updated.
+func (m *Model) FitViewYRange(min, max float64) bool {
+	yt := m.YTransform()
+	if !isFinite(min) || !isFinite(max) || !yt.Valid(min) || !yt.Valid(max) || (min > max) {
+		return false
+	}
+	tMin, tMax := yt.Forward(min), yt.Forward(max)
+	pad := (tMax - tMin) * m.autoFitPad
+	if tMin == tMax {
+		pad = 0.5
+	}
+	min, max = yt.Inverse(tMin-pad), yt.Inverse(tMax+pad)
+	if m.autoFitZero && yt.Valid(0) {
+		min = math.Min(min, 0)
+		max = math.Max(max, 0)
+	}
+	if m.AutoMinY && (min < m.minY) {
+		m.minY = min
+	}
+	if m.AutoMaxY && (max > m.maxY) {
+		m.maxY = max
+	}
+	prevMin, prevMax := m.viewMinY, m.viewMaxY
+	return m.SetViewYRange(min, max) && ((m.viewMinY != prevMin) || (m.viewMaxY != prevMax))
+}
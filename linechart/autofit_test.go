@@ -0,0 +1,39 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package linechart
+
+import (
+	"testing"
+)
+
+func TestFitViewYRange(t *testing.T) {
+	lc := New(30, 12, 0, 10, 0, 100)
+	lc.SetAutoFitYPadding(0.1)
+	tests := []struct {
+		name     string
+		zero     bool
+		min, max float64
+		wantMin  float64
+		wantMax  float64
+	}{
+		{"padding", false, 20, 40, 18, 42},
+		{"zero", true, 20, 40, 0, 42},
+		{"constant", false, 50, 50, 49.5, 50.5},
+		{"bounded", false, 90, 100, 89, 100},
+	}
+	for _, tc := range tests {
+		lc.SetAutoFitYZero(tc.zero)
+		lc.FitViewYRange(tc.min, tc.max)
+		if (lc.ViewMinY() != tc.wantMin) || (lc.ViewMaxY() != tc.wantMax) {
+			t.Errorf("%s: displayed %f,%f expected %f,%f", tc.name, lc.ViewMinY(), lc.ViewMaxY(), tc.wantMin, tc.wantMax)
+		}
+	}
+
+	// expected Y values are extended if automatically adjusted
+	lc = New(30, 12, 0, 10, 0, 100, WithAutoYRange())
+	lc.SetAutoFitYPadding(0.1)
+	lc.FitViewYRange(90, 100)
+	if (lc.ViewMinY() != 89) || (lc.ViewMaxY() != 101) || (lc.MaxY() != 101) {
+		t.Errorf("auto range: displayed %f,%f expected maximum %f", lc.ViewMinY(), lc.ViewMaxY(), lc.MaxY())
+	}
+}
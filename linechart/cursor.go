@@ -0,0 +1,158 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package linechart
+
+// File contains the key bindings, data set cycling, readouts and drawing
+// of keyboard data cursors, which are moved between data points by the
+// linechart wrappers such as streamlinechart, timeserieslinechart
+// and wavelinechart.
+
+import (
+	"image"
+	"slices"
+
+	"github.com/NimbleMarkets/ntcharts/canvas"
+	"github.com/NimbleMarkets/ntcharts/canvas/runes"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// DataCursor is implemented by the linechart wrappers, which locate
+// the data points of the keyboard data cursor within their data sets.
+type DataCursor interface {
+	CursorDataSets() []string  // names of data sets the data cursor can be placed on in order
+	CursorDataSet() string     // name of the data set of the data cursor
+	SetCursorDataSet(n string) // moves the data cursor to the data set given by name string
+	CursorNext()               // moves the data cursor to the next data point of its data set
+	CursorPrev()               // moves the data cursor to the previous data point of its data set
+}
+
+// CursorKeyMap contains the key bindings of the keyboard data cursor.
+// Key bindings of the data cursor take precedence over
+// the Canvas KeyMap while the data cursor is shown.
+type CursorKeyMap struct {
+	Toggle      key.Binding // show or hide the data cursor
+	Prev        key.Binding // move to the previous data point
+	Next        key.Binding // move to the next data point
+	NextDataSet key.Binding // move to the next data set
+	PrevDataSet key.Binding // move to the previous data set
+}
+
+// DefaultCursorKeyMap returns a default CursorKeyMap for linechart.
+func DefaultCursorKeyMap() CursorKeyMap {
+	return CursorKeyMap{
+		Toggle: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "toggle cursor"),
+		),
+		Prev: key.NewBinding(
+			key.WithKeys("left", "h"),
+			key.WithHelp("←/h", "previous point"),
+		),
+		Next: key.NewBinding(
+			key.WithKeys("right", "l"),
+			key.WithHelp("→/l", "next point"),
+		),
+		NextDataSet: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "next data set"),
+		),
+		PrevDataSet: key.NewBinding(
+			key.WithKeys("shift+tab"),
+			key.WithHelp("shift+tab", "previous data set"),
+		),
+	}
+}
+
+// SetCursor shows or hides the keyboard data cursor.
+func (m *Model) SetCursor(b bool) {
+	m.cursor = b
+}
+
+// Cursor returns whether the keyboard data cursor is shown.
+func (m *Model) Cursor() bool {
+	return m.cursor
+}
+
+// DrawCursorAt draws the data cursor at given canvas coordinates of
+// a data point and a readout of given lines of text with the tooltip style
+// in the top corner of the graphing area away from the data cursor.
+// Draws a vertical line with the crosshair style through the data point
+// on empty cells, and applies the cursor style to the data point.
+func (m *Model) DrawCursorAt(p canvas.Point, lines []string) {
+	a := m.GraphArea()
+	if (p.X < a.Min.X) || (p.X >= a.Max.X) {
+		return
+	}
+	for y := a.Min.Y; y < a.Max.Y; y++ {
+		m.setEmptyCell(canvas.Point{X: p.X, Y: y}, runes.LineVertical)
+	}
+	if p.In(a) {
+		m.Canvas.SetCellStyle(p, m.CursorStyle)
+	}
+	w, _ := textBoxSize(lines)
+	pos := image.Pt(a.Max.X-w, a.Min.Y)
+	if p.X >= a.Min.X+a.Dx()/2 {
+		pos.X = a.Min.X
+	}
+	m.drawTextBox(pos, lines)
+}
+
+// UpdateDataCursor processes bubbletea key Msg matching the CursorKeyMap to
+// show, hide and move the data cursor located by given DataCursor, and
+// returns whether the Msg was used by the data cursor.
+func (m *Model) UpdateDataCursor(c DataCursor, tm tea.Msg) bool {
+	msg, ok := tm.(tea.KeyMsg)
+	if !ok {
+		return false
+	}
+	km := m.CursorKeyMap
+	switch {
+	case key.Matches(msg, km.Toggle):
+		m.SetCursor(!m.Cursor())
+	case !m.Cursor():
+		return false
+	case key.Matches(msg, km.Prev):
+		c.CursorPrev()
+	case key.Matches(msg, km.Next):
+		c.CursorNext()
+	case key.Matches(msg, km.NextDataSet):
+		NextCursorDataSet(c, 1)
+	case key.Matches(msg, km.PrevDataSet):
+		NextCursorDataSet(c, -1)
+	default:
+		return false
+	}
+	return true
+}
+
+// NextCursorDataSet moves the data cursor located by given DataCursor
+// to the data set given number of data sets after the data set of the
+// data cursor, or before if negative, wrapping around at either end.
+func NextCursorDataSet(c DataCursor, d int) {
+	names := c.CursorDataSets()
+	if len(names) == 0 {
+		return
+	}
+	i := max(slices.Index(names, c.CursorDataSet()), 0)
+	i = ((i+d)%len(names) + len(names)) % len(names)
+	c.SetCursorDataSet(names[i])
+}
+
+// FormatCursorReadout returns the lines of text of a data cursor readout
+// displaying given data set name and lines describing the data point of the
+// data cursor, followed by the difference of given value from given previous
+// value of the data set if there is one, formatted with HoverYFormatter.
+func (m *Model) FormatCursorReadout(n string, lines []string, v, prev float64, hasPrev bool) []string {
+	r := append([]string{n}, lines...)
+	if hasPrev {
+		d := v - prev
+		s := m.HoverYFormatter(0, d)
+		if d >= 0 {
+			s = "+" + s
+		}
+		r = append(r, "Δ: "+s)
+	}
+	return r
+}
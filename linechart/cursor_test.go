@@ -0,0 +1,108 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package linechart
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/NimbleMarkets/ntcharts/canvas"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDrawCursorAt(t *testing.T) {
+	lc := New(12, 4, 0, 10, 0, 10, WithXYSteps(0, 0))
+	lc.Canvas.SetRune(canvas.Point{X: 2, Y: 2}, '*')
+
+	// readout is drawn away from the data cursor
+	lc.DrawCursorAt(canvas.Point{X: 2, Y: 2}, []string{"V: 1"})
+	want := "  │    V: 1 \n" +
+		"  │         \n" +
+		"  *         \n" +
+		"  │         "
+	if got := lc.Canvas.View(); got != want {
+		t.Errorf("wrong data cursor:\n%s\nexpected:\n%s", got, want)
+	}
+	if s := lc.Canvas.Cell(canvas.Point{X: 2, Y: 2}).Style; !s.GetReverse() {
+		t.Errorf("cursor style not applied to data point")
+	}
+
+	lc.Clear()
+	lc.DrawCursorAt(canvas.Point{X: 9, Y: 2}, []string{"V: 1"})
+	if got := strings.Split(lc.Canvas.View(), "\n")[0]; got != " V: 1    │  " {
+		t.Errorf("readout not drawn left of data cursor:%q", got)
+	}
+}
+
+// testCursor is a DataCursor stepping between the indices of named data sets.
+type testCursor struct {
+	names []string
+	set   string
+	i     int
+}
+
+func (c *testCursor) CursorDataSets() []string  { return c.names }
+func (c *testCursor) CursorDataSet() string     { return c.set }
+func (c *testCursor) SetCursorDataSet(n string) { c.set = n }
+func (c *testCursor) CursorNext()               { c.i++ }
+func (c *testCursor) CursorPrev()               { c.i-- }
+
+func TestUpdateDataCursor(t *testing.T) {
+	lc := New(12, 4, 0, 10, 0, 10)
+	c := &testCursor{names: []string{"a", "b", "c"}, set: "a"}
+
+	// keys are ignored until the data cursor is shown
+	if lc.UpdateDataCursor(c, tea.KeyMsg{Type: tea.KeyRight}) || (c.i != 0) {
+		t.Error("hidden data cursor used key")
+	}
+	if !lc.UpdateDataCursor(c, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")}) || !lc.Cursor() {
+		t.Fatal("data cursor not shown")
+	}
+	lc.UpdateDataCursor(c, tea.KeyMsg{Type: tea.KeyRight})
+	lc.UpdateDataCursor(c, tea.KeyMsg{Type: tea.KeyRight})
+	lc.UpdateDataCursor(c, tea.KeyMsg{Type: tea.KeyLeft})
+	if c.i != 1 {
+		t.Errorf("wrong data cursor steps:%d", c.i)
+	}
+	if lc.UpdateDataCursor(c, tea.KeyMsg{Type: tea.KeyUp}) {
+		t.Error("data cursor used unbound key")
+	}
+
+	// data sets wrap around at either end
+	for _, want := range []string{"b", "c", "a"} {
+		lc.UpdateDataCursor(c, tea.KeyMsg{Type: tea.KeyTab})
+		if c.set != want {
+			t.Errorf("wrong next data set:%s expected:%s", c.set, want)
+		}
+	}
+	for _, want := range []string{"c", "b"} {
+		lc.UpdateDataCursor(c, tea.KeyMsg{Type: tea.KeyShiftTab})
+		if c.set != want {
+			t.Errorf("wrong previous data set:%s expected:%s", c.set, want)
+		}
+	}
+
+	// data cursor on a missing data set moves from the first data set
+	c.set = "d"
+	NextCursorDataSet(c, 1)
+	if c.set != "b" {
+		t.Errorf("wrong next data set from missing data set:%s", c.set)
+	}
+}
+
+func TestFormatCursorReadout(t *testing.T) {
+	lc := New(12, 4, 0, 10, 0, 10)
+	got := strings.Join(lc.FormatCursorReadout("a", []string{"V: 3.0"}, 3, 1, true), ",")
+	if want := "a,V: 3.0,Δ: +2"; got != want {
+		t.Errorf("wrong readout:%s expected:%s", got, want)
+	}
+	got = strings.Join(lc.FormatCursorReadout("a", []string{"V: 1.0"}, 1, 3, true), ",")
+	if want := "a,V: 1.0,Δ: -2"; got != want {
+		t.Errorf("wrong readout:%s expected:%s", got, want)
+	}
+	got = strings.Join(lc.FormatCursorReadout("a", []string{"V: 1.0"}, 1, 0, false), ",")
+	if want := "a,V: 1.0"; got != want {
+		t.Errorf("wrong readout without previous value:%s expected:%s", got, want)
+	}
+}
@@ -0,0 +1,109 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package linechart
+
+// File contains the visibility and z-order of named data sets
+// shared by line charts drawing multiple data sets.
+
+import (
+	"sort"
+)
+
+// DataSetVisibility contains whether named data sets are drawn, the order
+// in which they are drawn, and whether hidden data sets are excluded from
+// automatic adjustment of the value ranges.  Data sets are visible with
+// a z-order of 0 by default.  It is embedded by line charts drawing
+// multiple data sets, which adjust their value ranges when data sets
+// are shown or hidden.
+type DataSetVisibility struct {
+	hidden        map[string]bool // names of hidden data sets
+	zOrder        map[string]int  // z-order of data sets, 0 if not set
+	excludeHidden bool            // whether hidden data sets are excluded from automatic range adjustment
+}
+
+// SetDataSetVisible sets whether the data set given by name string
+// is drawn, and returns whether its visibility changed.
+func (v *DataSetVisibility) SetDataSetVisible(n string, b bool) bool {
+	if v.DataSetVisible(n) == b {
+		return false
+	}
+	if b {
+		delete(v.hidden, n)
+	} else {
+		if v.hidden == nil {
+			v.hidden = make(map[string]bool)
+		}
+		v.hidden[n] = true
+	}
+	return true
+}
+
+// DataSetVisible returns whether the data set given by name string is drawn.
+func (v *DataSetVisibility) DataSetVisible(n string) bool {
+	return !v.hidden[n]
+}
+
+// SetDataSetZOrder sets the z-order of the data set given by name string.
+// Data sets are drawn in increasing z-order, such that data sets with
+// greater z-order are drawn over others, and data sets with the same
+// z-order are drawn in alphabetical order.  The default z-order is 0.
+func (v *DataSetVisibility) SetDataSetZOrder(n string, z int) {
+	if z == 0 {
+		delete(v.zOrder, n)
+		return
+	}
+	if v.zOrder == nil {
+		v.zOrder = make(map[string]int)
+	}
+	v.zOrder[n] = z
+}
+
+// DataSetZOrder returns the z-order of the data set given by name string.
+func (v *DataSetVisibility) DataSetZOrder(n string) int {
+	return v.zOrder[n]
+}
+
+// SetExcludeHiddenRange sets whether data values of hidden
+// data sets are excluded from automatic adjustment of the value ranges.
+func (v *DataSetVisibility) SetExcludeHiddenRange(b bool) {
+	v.excludeHidden = b
+}
+
+// ExcludeHiddenRange returns whether data values of hidden data sets
+// are excluded from automatic adjustment of the value ranges.
+func (v *DataSetVisibility) ExcludeHiddenRange() bool {
+	return v.excludeHidden
+}
+
+// DataSetAdjustsRange returns whether data values of the data set
+// given by name string automatically adjust the value ranges.
+func (v *DataSetVisibility) DataSetAdjustsRange(n string) bool {
+	return !v.excludeHidden || v.DataSetVisible(n)
+}
+
+// SortDrawOrder sorts given data set names by increasing z-order and then by name.
+func (v *DataSetVisibility) SortDrawOrder(names []string) {
+	sort.Slice(names, func(i, j int) bool {
+		zi, zj := v.zOrder[names[i]], v.zOrder[names[j]]
+		if zi != zj {
+			return zi < zj
+		}
+		return names[i] < names[j]
+	})
+}
+
+// RenameDataSet moves the visibility and z-order of the data set
+// given by old name string to the new name string.
+func (v *DataSetVisibility) RenameDataSet(old, new string) {
+	hidden, z := !v.DataSetVisible(old), v.DataSetZOrder(old)
+	v.RemoveDataSet(old)
+	v.SetDataSetVisible(new, !hidden)
+	v.SetDataSetZOrder(new, z)
+}
+
+// RemoveDataSet resets the visibility and z-order
+// of the data set given by name string.
+func (v *DataSetVisibility) RemoveDataSet(n string) {
+	delete(v.hidden, n)
+	delete(v.zOrder, n)
+}
@@ -0,0 +1,75 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package linechart
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/NimbleMarkets/ntcharts/canvas"
+)
+
+func TestDataSetVisibility(t *testing.T) {
+	var v DataSetVisibility
+	if !v.DataSetVisible("a") || (v.DataSetZOrder("a") != 0) || !v.DataSetAdjustsRange("a") {
+		t.Error("expected visible data sets with z-order 0 by default")
+	}
+	if !v.SetDataSetVisible("a", false) || v.SetDataSetVisible("a", false) || v.DataSetVisible("a") {
+		t.Error("expected hiding to change visibility once")
+	}
+	if !v.DataSetAdjustsRange("a") {
+		t.Error("expected hidden data sets to adjust ranges by default")
+	}
+	v.SetExcludeHiddenRange(true)
+	if v.DataSetAdjustsRange("a") || !v.DataSetAdjustsRange("b") {
+		t.Error("expected only hidden data sets excluded from ranges")
+	}
+
+	v.SetDataSetZOrder("a", 2)
+	v.SetDataSetZOrder("c", -1)
+	names := []string{"d", "a", "c", "b"}
+	v.SortDrawOrder(names)
+	if s := strings.Join(names, ","); s != "c,b,d,a" {
+		t.Errorf("wrong draw order:%s", s)
+	}
+
+	v.RenameDataSet("a", "e")
+	if !v.DataSetVisible("a") || (v.DataSetZOrder("a") != 0) {
+		t.Error("expected old name reset after renaming")
+	}
+	if v.DataSetVisible("e") || (v.DataSetZOrder("e") != 2) {
+		t.Error("expected new name to keep visibility and z-order")
+	}
+	v.RemoveDataSet("e")
+	if !v.DataSetVisible("e") || (v.DataSetZOrder("e") != 0) {
+		t.Error("expected removed data set reset")
+	}
+}
+
+func TestResetAutoYRange(t *testing.T) {
+	lc := New(30, 12, 0, 10, 0, 10, WithAutoYRange())
+	lc.AutoAdjustRange(canvas.Float64Point{X: 5, Y: -20})
+	lc.AutoAdjustRange(canvas.Float64Point{X: 5, Y: 50})
+	if !lc.ResetAutoYRange(2, 30) {
+		t.Fatal("expected expected Y values to update")
+	}
+	if (lc.MinY() != 2) || (lc.MaxY() != 30) || (lc.ViewMinY() != 2) || (lc.ViewMaxY() != 30) {
+		t.Errorf("wrong Y ranges:%f %f %f %f", lc.MinY(), lc.MaxY(), lc.ViewMinY(), lc.ViewMaxY())
+	}
+	if lc.ResetAutoYRange(2, 30) || lc.ResetAutoYRange(5, 5) {
+		t.Error("expected unchanged and empty ranges ignored")
+	}
+
+	// zoomed in displayed Y values are kept within the expected Y values
+	lc.SetViewYRange(10, 20)
+	lc.ResetAutoYRange(0, 15)
+	if (lc.ViewMinY() != 10) || (lc.ViewMaxY() != 15) {
+		t.Errorf("wrong zoomed Y range:%f %f", lc.ViewMinY(), lc.ViewMaxY())
+	}
+
+	// only automatically adjusted Y values are replaced
+	lc = New(30, 12, 0, 10, 0, 10)
+	if lc.ResetAutoYRange(2, 8) || (lc.MinY() != 0) || (lc.MaxY() != 10) {
+		t.Error("expected fixed Y range kept")
+	}
+}
@@ -0,0 +1,193 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package linechart
+
+// File contains the hover mode of the linechart tracking the mouse
+// over the graphing area with a bubblezone Manager, and drawing
+// a crosshair and a tooltip of the data values under the mouse.
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/NimbleMarkets/ntcharts/canvas"
+	"github.com/NimbleMarkets/ntcharts/canvas/runes"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// DefaultHoverFormatter returns a LabelFormatter
+// that converts float64 to strings with up to 6 significant digits.
+func DefaultHoverFormatter() LabelFormatter {
+	return func(i int, v float64) string {
+		return fmt.Sprintf("%.6g", v)
+	}
+}
+
+// SetHover enables or disables tracking the mouse over the graphing
+// area during Update.  Requires a bubblezone Manager and mouse motion
+// events, such as from tea.WithMouseAllMotion().
+func (m *Model) SetHover(b bool) {
+	m.hover = b
+	if !b {
+		m.hovering = false
+	}
+}
+
+// Hover returns whether the mouse is tracked over the graphing area.
+func (m *Model) Hover() bool {
+	return m.hover
+}
+
+// UpdateHover updates the tracked mouse position from a bubbletea
+// mouse Msg if hover is enabled.  Called by Update, and should be called
+// for linecharts receiving mouse messages without Update.
+func (m *Model) UpdateHover(tm tea.Msg) {
+	msg, ok := tm.(tea.MouseMsg)
+	if !ok || !m.hover || (m.zoneManager == nil) {
+		return
+	}
+	zInfo := m.zoneManager.Get(m.zoneID)
+	if !zInfo.InBounds(msg) {
+		m.hovering = false
+		return
+	}
+	x, y := zInfo.Pos(msg)
+	m.hoverPos = canvas.Point{X: x, Y: y}
+	m.hovering = m.hoverPos.In(m.GraphArea())
+}
+
+// HoverPosition returns the canvas coordinates of the mouse
+// and whether the mouse is over the graphing area.
+func (m *Model) HoverPosition() (canvas.Point, bool) {
+	return m.hoverPos, m.hovering
+}
+
+// HoverPoint returns the data point under the mouse
+// and whether the mouse is over the graphing area.
+func (m *Model) HoverPoint() (canvas.Float64Point, bool) {
+	return m.DataPoint(m.hoverPos), m.hovering
+}
+
+// UnscaleFloat64Point returns a Float64Point data point from a Float64Point
+// scaled to the graph size of the linechart.  Inverse of ScaleFloat64Point.
+func (m *Model) UnscaleFloat64Point(f canvas.Float64Point) (r canvas.Float64Point) {
+	xt := m.XTransform()
+	yt := m.YTransform()
+	tMinX := xt.Forward(m.viewMinX)
+	tMinY := yt.Forward(m.viewMinY)
+	dx := xt.Forward(m.viewMaxX) - tMinX
+	dy := yt.Forward(m.viewMaxY) - tMinY
+	r.X = m.viewMinX
+	r.Y = m.viewMinY
+	if w := m.graphWidth - 1; w > 0 {
+		r.X = xt.Inverse(tMinX + f.X*dx/float64(w))
+	}
+	if h := m.graphHeight - 1; h > 0 {
+		r.Y = yt.Inverse(tMinY + f.Y*dy/float64(h))
+	}
+	return
+}
+
+// DataPoint returns the data point drawn at given canvas coordinates
+// by DrawRune.  Inverse of the canvas coordinates of DrawRune.
+func (m *Model) DataPoint(p canvas.Point) canvas.Float64Point {
+	// undo drawing runes avoiding the axes
+	if m.yStep > 0 {
+		p.X--
+	}
+	if m.xStep > 0 {
+		p.Y++
+	}
+	return m.UnscaleFloat64Point(canvas.Float64Point{
+		X: float64(p.X - m.origin.X),
+		Y: float64(m.origin.Y - p.Y),
+	})
+}
+
+// DrawCrosshair draws vertical and horizontal lines across the graphing
+// area through the mouse position if the mouse is over the graphing area.
+// Lines are only drawn on empty cells to avoid hiding data.
+func (m *Model) DrawCrosshair() {
+	if !m.hovering {
+		return
+	}
+	a := m.GraphArea()
+	for y := a.Min.Y; y < a.Max.Y; y++ {
+		m.setEmptyCell(canvas.Point{X: m.hoverPos.X, Y: y}, runes.LineVertical)
+	}
+	for x := a.Min.X; x < a.Max.X; x++ {
+		m.setEmptyCell(canvas.Point{X: x, Y: m.hoverPos.Y}, runes.LineHorizontal)
+	}
+	c := m.Canvas.Cell(m.hoverPos)
+	if (c.Rune == runes.LineVertical) || (c.Rune == runes.LineHorizontal) {
+		m.Canvas.SetCell(m.hoverPos, canvas.NewCellWithStyle(runes.LineHorizontalVertical, m.CrosshairStyle))
+	}
+}
+
+// setEmptyCell sets rune with the crosshair style at given
+// canvas coordinates if the cell does not contain a rune.
+func (m *Model) setEmptyCell(p canvas.Point, r rune) {
+	if c := m.Canvas.Cell(p).Rune; (c == runes.Null) || (c == ' ') {
+		m.Canvas.SetCell(p, canvas.NewCellWithStyle(r, m.CrosshairStyle))
+	}
+}
+
+// DrawTooltip draws given lines of text with the tooltip style next to
+// the mouse position if the mouse is over the graphing area.
+// The tooltip is placed below and right of the mouse, and moves to
+// the other sides of the mouse to stay inside of the graphing area.
+func (m *Model) DrawTooltip(lines []string) {
+	if !m.hovering || (len(lines) == 0) {
+		return
+	}
+	w, h := textBoxSize(lines)
+	a := m.GraphArea()
+	p := m.hoverPos.Add(image.Pt(1, 1))
+	if p.X+w > a.Max.X {
+		p.X = m.hoverPos.X - w
+	}
+	if p.Y+h > a.Max.Y {
+		p.Y = m.hoverPos.Y - h
+	}
+	m.drawTextBox(p, lines)
+}
+
+// textBoxSize returns the width and height of the
+// box of given lines of text padded with a space on each side.
+func textBoxSize(lines []string) (w, h int) {
+	for _, l := range lines {
+		w = max(w, len([]rune(l)))
+	}
+	return w + 2, len(lines)
+}
+
+// drawTextBox draws given lines of text with the tooltip style
+// starting at given canvas coordinates moved inside of the graphing area.
+func (m *Model) drawTextBox(p canvas.Point, lines []string) {
+	w, h := textBoxSize(lines)
+	a := m.GraphArea()
+	p.X = max(min(p.X, a.Max.X-w), a.Min.X)
+	p.Y = max(min(p.Y, a.Max.Y-h), a.Min.Y)
+	for i, l := range lines {
+		r := []rune(fmt.Sprintf(" %-*s ", w-2, l))
+		for j := 0; (j < len(r)) && (p.X+j < a.Max.X) && (p.Y+i < a.Max.Y); j++ {
+			m.Canvas.SetCell(canvas.Point{X: p.X + j, Y: p.Y + i}, canvas.NewCellWithStyle(r[j], m.TooltipStyle))
+		}
+	}
+}
+
+// DrawHover draws the crosshair and a tooltip of the X and Y values under
+// the mouse if the mouse is over the graphing area.  Should be called
+// after drawing data to overlay the graphing area.
+func (m *Model) DrawHover() {
+	f, ok := m.HoverPoint()
+	if !ok {
+		return
+	}
+	m.DrawCrosshair()
+	m.DrawTooltip([]string{
+		"X: " + m.HoverXFormatter(0, f.X),
+		"Y: " + m.HoverYFormatter(0, f.Y),
+	})
+}
@@ -0,0 +1,58 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package linechart
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/NimbleMarkets/ntcharts/canvas"
+)
+
+func TestDataPoint(t *testing.T) {
+	lc := New(30, 12, 0, 100, 1, 1000, WithYTransform(NewLog10Transform()))
+	lc.SetViewXRange(20, 80)
+	for _, f := range []canvas.Float64Point{{X: 20, Y: 1}, {X: 50, Y: 10}, {X: 80, Y: 1000}} {
+		// canvas coordinates of runes drawn with DrawRune
+		sf := lc.ScaleFloat64Point(f)
+		p := canvas.CanvasPointFromFloat64Point(lc.Origin(), sf)
+		p.X++
+		p.Y--
+		if !p.In(lc.GraphArea()) {
+			t.Errorf("point %v drawn outside of graph area:%v", f, p)
+		}
+		r := lc.DataPoint(p)
+		// rounding to canvas coordinates loses at most half a cell
+		dx := 0.5 * (lc.ViewMaxX() - lc.ViewMinX()) / float64(lc.GraphWidth()-1)
+		dy := 0.5 * 3 / float64(lc.GraphHeight()-1)
+		if (math.Abs(r.X-f.X) > dx) || (math.Abs(math.Log10(r.Y)-math.Log10(f.Y)) > dy) {
+			t.Errorf("data point at %v returned %v expected %v", p, r, f)
+		}
+	}
+}
+
+func TestDrawTooltip(t *testing.T) {
+	lc := New(12, 6, 0, 10, 0, 10, WithXYSteps(0, 0))
+	lc.hovering = true
+	lc.hoverPos = canvas.Point{X: 1, Y: 1}
+	lc.DrawTooltip([]string{"ab"})
+	if got := strings.Split(lc.Canvas.View(), "\n")[2]; got != "   ab       " {
+		t.Errorf("wrong tooltip below right of the mouse:%q", got)
+	}
+
+	// tooltip moves left and above the mouse near the bottom right corner
+	lc.Clear()
+	lc.hoverPos = canvas.Point{X: 10, Y: 5}
+	lc.DrawCrosshair()
+	lc.DrawTooltip([]string{"ab"})
+	want := "          │ \n" +
+		"          │ \n" +
+		"          │ \n" +
+		"          │ \n" +
+		"       ab │ \n" +
+		"──────────┼─"
+	if got := lc.Canvas.View(); got != want {
+		t.Errorf("wrong tooltip near corner:\n%s\nexpected:\n%s", got, want)
+	}
+}
@@ -6,6 +6,7 @@ package linechart
 
 import (
 	"fmt"
+	"image"
 	"math"
 
 	"github.com/NimbleMarkets/ntcharts/canvas"
@@ -42,10 +43,26 @@ type Model struct {
 	LabelStyle      lipgloss.Style // style applied when drawing X and Y number value
 	XLabelFormatter LabelFormatter // convert to X number values display string
 	YLabelFormatter LabelFormatter // convert to Y number values display string
+	HoverXFormatter LabelFormatter // convert to X number values display string of hover tooltips
+	HoverYFormatter LabelFormatter // convert to Y number values display string of hover tooltips
+	CrosshairStyle  lipgloss.Style // style applied when drawing the hover crosshair
+	TooltipStyle    lipgloss.Style // style applied when drawing hover tooltips and cursor readouts
+	CursorStyle     lipgloss.Style // style applied to the data point of the keyboard data cursor
+	CursorKeyMap    CursorKeyMap   // key bindings of the keyboard data cursor
+	SelectionStyle  lipgloss.Style // style applied when drawing the box zoom selection
+	ZoomKeyMap      ZoomKeyMap     // key bindings of the zoom history
 	xStep           int            // number of steps when displaying X axis values
 	yStep           int            // number of steps when displaying Y axis values
 	focus           bool
 
+	// locate X and Y axis values instead of using steps if not nil
+	xTickLocator TickLocator
+	yTickLocator TickLocator
+	xMinorTicks  int // number of minor tick intervals between X axis ticks
+	yMinorTicks  int // number of minor tick intervals between Y axis ticks
+
+	minYLabelWidth int // minimum width reserved left of the Y axis for values
+
 	// the expected min and max values
 	minX float64
 	maxX float64
@@ -58,6 +75,10 @@ type Model struct {
 	viewMinY float64
 	viewMaxY float64
 
+	// transforms of X and Y axes values, linear if nil
+	xTransform AxisTransform
+	yTransform AxisTransform
+
 	// whether to automatically set expected values
 	// when a value appears beyond the existing bounds
 	AutoMinX bool
@@ -71,6 +92,25 @@ type Model struct {
 
 	zoneManager *zone.Manager // provides mouse functionality
 	zoneID      string
+
+	linkGroup *LinkGroup // synchronizes displayed ranges with other linecharts
+	linkID    int        // identifies the linechart in its LinkGroup
+
+	hover    bool         // whether the mouse is tracked over the graphing area
+	hovering bool         // whether the mouse is over the graphing area
+	hoverPos canvas.Point // canvas coordinates of the mouse
+
+	cursor bool // whether the keyboard data cursor is shown
+
+	selecting   bool         // whether a box zoom selection is being dragged
+	selStart    canvas.Point // canvas coordinates of start of box zoom selection
+	selEnd      canvas.Point // canvas coordinates of end of box zoom selection
+	zoomBack    [][4]float64 // displayed ranges restored by ZoomBack
+	zoomForward [][4]float64 // displayed ranges restored by ZoomForward
+
+	autoFitY    bool    // whether displayed Y values are fitted to displayed data values
+	autoFitPad  float64 // fraction of fitted Y values range added above and below data values
+	autoFitZero bool    // whether fitted Y values include zero
 }
 
 // New returns a linechart Model initialized with given width, height,
@@ -87,6 +127,14 @@ func New(w, h int, minX, maxX, minY, maxY float64, opts ...Option) Model {
 		LabelStyle:      defaultStyle,
 		XLabelFormatter: DefaultLabelFormatter(),
 		YLabelFormatter: DefaultLabelFormatter(),
+		HoverXFormatter: DefaultHoverFormatter(),
+		HoverYFormatter: DefaultHoverFormatter(),
+		CrosshairStyle:  defaultStyle,
+		TooltipStyle:    defaultStyle,
+		CursorStyle:     defaultStyle.Reverse(true),
+		CursorKeyMap:    DefaultCursorKeyMap(),
+		SelectionStyle:  defaultStyle,
+		ZoomKeyMap:      DefaultZoomKeyMap(),
 		yStep:           2,
 		xStep:           2,
 		minX:            minX,
@@ -106,7 +154,8 @@ func New(w, h int, minX, maxX, minY, maxY float64, opts ...Option) Model {
 }
 
 // getGraphSizeAndOrigin calculates and returns the linechart origin and graph width and height
-func getGraphSizeAndOrigin(w, h int, minY, maxY float64, xStep, yStep int, yFmter LabelFormatter) (canvas.Point, int, int) {
+// reserving at least minYLabelWidth spaces left of the Y axis for values.
+func getGraphSizeAndOrigin(w, h int, minY, maxY float64, xStep, yStep int, yFmter LabelFormatter, yt AxisTransform, yLoc TickLocator, minYLabelWidth int) (canvas.Point, int, int) {
 	// graph width and height exclude area used by axes
 	// origin point is canvas coordinates of where axes are drawn
 	origin := canvas.Point{X: 0, Y: h - 1}
@@ -117,16 +166,29 @@ func getGraphSizeAndOrigin(w, h int, minY, maxY float64, xStep, yStep int, yFmte
 		origin.Y -= 1
 		gHeight -= 2
 	}
-	if yStep > 0 {
+	if (yStep > 0) && (yLoc != nil) {
+		// reserve spaces left of the Y axis for located tick values
+		valueLen := 0
+		major, _ := yTicks(minY, maxY, gHeight, yt, yLoc, 0)
+		for i, t := range major {
+			if s := yFmter(i, t.value); len(s) > valueLen {
+				valueLen = len(s)
+			}
+		}
+		valueLen = max(valueLen, minYLabelWidth)
+		origin.X += valueLen
+		gWidth -= (valueLen + 1) // ignore Y axis and tick values
+	} else if yStep > 0 {
 		// find out how many spaces left of the Y axis
 		// to reserve for axis tick value by checking the string length
 		// of all values to be displayed
 		var lastVal string
 		valueLen := 0
-		rangeSz := maxY - minY // range of possible expected values
+		tMinY := yt.Forward(minY)
+		rangeSz := yt.Forward(maxY) - tMinY // range of possible expected values
 		increment := rangeSz / float64(gHeight)
 		for i := 0; i <= gHeight; {
-			v := minY + (increment * float64(i)) // value to set left of Y axis
+			v := yt.Inverse(tMinY + (increment * float64(i))) // value to set left of Y axis
 			s := yFmter(i, v)
 			if lastVal != s {
 				if len(s) > valueLen {
@@ -136,6 +198,7 @@ func getGraphSizeAndOrigin(w, h int, minY, maxY float64, xStep, yStep int, yFmte
 			}
 			i += yStep
 		}
+		valueLen = max(valueLen, minYLabelWidth)
 		origin.X += valueLen
 		gWidth -= (valueLen + 1) // ignore Y axis and tick values
 	}
@@ -154,12 +217,35 @@ func (m *Model) UpdateGraphSizes() {
 		m.xStep,
 		m.yStep,
 		m.YLabelFormatter,
+		m.YTransform(),
+		m.yTickLocator,
+		m.minYLabelWidth,
 	)
 	m.origin = origin
 	m.graphWidth = gWidth
 	m.graphHeight = gHeight
 }
 
+// SetMinYLabelWidth sets the minimum number of spaces reserved left of
+// the Y axis for values, such that the graphing areas of linecharts
+// with different Y axis values can be aligned.
+func (m *Model) SetMinYLabelWidth(w int) {
+	m.minYLabelWidth = w
+	m.UpdateGraphSizes()
+}
+
+// MinYLabelWidth returns the minimum number of spaces
+// reserved left of the Y axis for values.
+func (m *Model) MinYLabelWidth() int {
+	return m.minYLabelWidth
+}
+
+// LineChart returns the linechart Model, which is
+// the embedded linechart Model of charts embedding it.
+func (m *Model) LineChart() *Model {
+	return m
+}
+
 // Width returns linechart width.
 func (m *Model) Width() int {
 	return m.Canvas.Width()
@@ -180,6 +266,13 @@ func (m *Model) GraphHeight() int {
 	return m.graphHeight
 }
 
+// GraphArea returns the canvas area of the graphing area,
+// which excludes the axes and their values.
+func (m *Model) GraphArea() image.Rectangle {
+	w := m.Canvas.Width()
+	return image.Rect(w-m.graphWidth, 0, w, m.graphHeight)
+}
+
 // MinX returns linechart expected minimum X value.
 func (m *Model) MinX() float64 {
 	return m.minX
@@ -324,28 +417,34 @@ func (m *Model) Resize(w, h int) {
 func (m *Model) AutoAdjustRange(f canvas.Float64Point) (b bool) {
 	// adjusts both expected range and
 	// the display range (if not zoomed in)
-	if m.AutoMinX && (f.X < m.minX) {
+	// NaN and infinite values and values that cannot be transformed
+	// are ignored, and expected values that cannot be transformed are replaced
+	xt := m.XTransform()
+	yt := m.YTransform()
+	validX := isFinite(f.X) && xt.Valid(f.X)
+	validY := isFinite(f.Y) && yt.Valid(f.Y)
+	if m.AutoMinX && validX && ((f.X < m.minX) || !xt.Valid(m.minX)) {
 		if m.minX == m.viewMinX {
 			m.viewMinX = f.X
 			b = true
 		}
 		m.minX = f.X
 	}
-	if m.AutoMaxX && (f.X > m.maxX) {
+	if m.AutoMaxX && validX && ((f.X > m.maxX) || !xt.Valid(m.maxX)) {
 		if m.maxX == m.viewMaxX {
 			m.viewMaxX = f.X
 			b = true
 		}
 		m.maxX = f.X
 	}
-	if m.AutoMinY && (f.Y < m.minY) {
+	if m.AutoMinY && validY && ((f.Y < m.minY) || !yt.Valid(m.minY)) {
 		if m.minY == m.viewMinY {
 			m.viewMinY = f.Y
 			b = true
 		}
 		m.minY = f.Y
 	}
-	if m.AutoMaxY && (f.Y > m.maxY) {
+	if m.AutoMaxY && validY && ((f.Y > m.maxY) || !yt.Valid(m.maxY)) {
 		if m.maxY == m.viewMaxY {
 			m.viewMaxY = f.Y
 			b = true
@@ -355,6 +454,40 @@ func (m *Model) AutoAdjustRange(f canvas.Float64Point) (b bool) {
 	return
 }
 
+// ResetAutoYRange replaces the minimum and maximum expected Y values
+// automatically adjusted by AutoMinY and AutoMaxY with given data values,
+// such as after data values are removed from the adjusted range.
+// The displayed Y values are reset to the expected Y values if not zoomed in.
+// Returns whether the expected Y values have updated.
+func (m *Model) ResetAutoYRange(min, max float64) bool {
+	yt := m.YTransform()
+	if !isFinite(min) || !isFinite(max) || !yt.Valid(min) || !yt.Valid(max) {
+		return false
+	}
+	minY, maxY := m.minY, m.maxY
+	if m.AutoMinY {
+		minY = min
+	}
+	if m.AutoMaxY {
+		maxY = max
+	}
+	if !(minY < maxY) || ((minY == m.minY) && (maxY == m.maxY)) {
+		return false
+	}
+	zoomed := (m.viewMinY != m.minY) || (m.viewMaxY != m.maxY)
+	m.minY, m.maxY = minY, maxY
+	if !zoomed || !m.SetViewYRange(m.viewMinY, m.viewMaxY) {
+		m.viewMinY, m.viewMaxY = minY, maxY
+	}
+	m.UpdateGraphSizes()
+	return true
+}
+
+// isFinite returns whether given value is neither NaN nor infinite.
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
 // SetZoneManager enables mouse functionality
 // by setting a bubblezone.Manager to the linechart.
 // The bubblezone.Manager can check bubbletea mouse event Msgs
@@ -379,7 +512,8 @@ func (m *Model) ZoneID() string {
 	return m.zoneID
 }
 
-// drawYLabel draws Y axis values left of the Y axis every n step.
+// drawYLabel draws Y axis values left of the Y axis every n step,
+// or at values placed by the Y TickLocator if set.
 // Repeating values will be hidden.
 // Does nothing if n <= 0.
 func (m *Model) drawYLabel(n int) {
@@ -388,11 +522,17 @@ func (m *Model) drawYLabel(n int) {
 	if n <= 0 {
 		return
 	}
+	if m.yTickLocator != nil {
+		m.drawYTicks()
+		return
+	}
 	var lastVal string
-	rangeSz := m.viewMaxY - m.viewMinY // range of possible expected values
+	yt := m.YTransform()
+	tMinY := yt.Forward(m.viewMinY)
+	rangeSz := yt.Forward(m.viewMaxY) - tMinY // range of possible expected values
 	increment := rangeSz / float64(m.graphHeight)
 	for i := 0; i <= m.graphHeight; {
-		v := m.viewMinY + (increment * float64(i)) // value to set left of Y axis
+		v := yt.Inverse(tMinY + (increment * float64(i))) // value to set left of Y axis
 		s := m.YLabelFormatter(i, v)
 		if lastVal != s {
 			m.Canvas.SetStringWithStyle(canvas.Point{m.origin.X - len(s), m.origin.Y - i}, s, m.LabelStyle)
@@ -402,7 +542,8 @@ func (m *Model) drawYLabel(n int) {
 	}
 }
 
-// drawXLabel draws X axis values below the X axis every n step.
+// drawXLabel draws X axis values below the X axis every n step,
+// or at values placed by the X TickLocator if set.
 // Repeating values will be hidden.
 // Does nothing if n <= 0.
 func (m *Model) drawXLabel(n int) {
@@ -410,13 +551,19 @@ func (m *Model) drawXLabel(n int) {
 	if n <= 0 {
 		return
 	}
+	if m.xTickLocator != nil {
+		m.drawXTicks()
+		return
+	}
 	var lastVal string
-	rangeSz := m.viewMaxX - m.viewMinX // range of possible expected values
+	xt := m.XTransform()
+	tMinX := xt.Forward(m.viewMinX)
+	rangeSz := xt.Forward(m.viewMaxX) - tMinX // range of possible expected values
 	increment := rangeSz / float64(m.graphWidth)
 	for i := 0; i < m.graphWidth; {
 		// can only set if rune to the left of target coordinates is empty
 		if c := m.Canvas.Cell(canvas.Point{m.origin.X + i - 1, m.origin.Y + 1}); c.Rune == runes.Null {
-			v := m.viewMinX + (increment * float64(i)) // value to set under X axis
+			v := xt.Inverse(tMinX + (increment * float64(i))) // value to set under X axis
 			s := m.XLabelFormatter(i, v)
 			// dont display if number will be cut off or value repeats
 			sLen := len(s) + m.origin.X + i
@@ -449,20 +596,35 @@ func (m *Model) DrawXYAxisAndLabel() {
 
 // scalePoint returns a Float64Point scaled to the graph size
 // of the linechart from a Float64Point data point, width and height.
+// Scaling is done in the transformed space of the X and Y axes.
 func (m *Model) scalePoint(f canvas.Float64Point, w, h int) (r canvas.Float64Point) {
-	dx := m.viewMaxX - m.viewMinX
-	dy := m.viewMaxY - m.viewMinY
+	xt := m.XTransform()
+	yt := m.YTransform()
+	tMinX := xt.Forward(m.viewMinX)
+	tMinY := yt.Forward(m.viewMinY)
+	dx := xt.Forward(m.viewMaxX) - tMinX
+	dy := yt.Forward(m.viewMaxY) - tMinY
 	if dx > 0 {
 		xs := float64(w) / dx
-		r.X = (f.X - m.viewMinX) * xs
+		r.X = (xt.Forward(f.X) - tMinX) * xs
 	}
 	if dy > 0 {
 		ys := float64(h) / dy
-		r.Y = (f.Y - m.viewMinY) * ys
+		r.Y = (yt.Forward(f.Y) - tMinY) * ys
 	}
 	return
 }
 
+// newBrailleGrid returns a new BrailleGrid of the graph size with
+// the expected X and Y values range in transformed space.
+// Data points must be transformed with TransformFloat64Point
+// before getting braille grid points.
+func (m *Model) newBrailleGrid() *graph.BrailleGrid {
+	return graph.NewBrailleGrid(m.graphWidth, m.graphHeight,
+		m.TransformX(m.minX), m.TransformX(m.maxX),
+		m.TransformY(m.minY), m.TransformY(m.maxY))
+}
+
 // ScaleFloat64Point returns a Float64Point scaled to the graph size
 // of the linechart from a Float64Point data point.
 func (m *Model) ScaleFloat64Point(f canvas.Float64Point) (r canvas.Float64Point) {
@@ -636,11 +798,11 @@ func (m *Model) DrawBrailleLineWithStyle(f1 canvas.Float64Point, f2 canvas.Float
 		m.UpdateGraphSizes()
 	}
 
-	bGrid := graph.NewBrailleGrid(m.graphWidth, m.graphHeight, m.minX, m.maxX, m.minY, m.maxY)
+	bGrid := m.newBrailleGrid()
 
 	// get braille grid points from two Float64Point data points
-	p1 := bGrid.GridPoint(f1)
-	p2 := bGrid.GridPoint(f2)
+	p1 := bGrid.GridPoint(m.TransformFloat64Point(f1))
+	p2 := bGrid.GridPoint(m.TransformFloat64Point(f2))
 
 	// set all points in the braille grid between two points that approximates a line
 	points := graph.GetLinePoints(p1, p2)
@@ -674,14 +836,14 @@ func (m *Model) DrawBrailleCircleWithStyle(c canvas.Float64Point, f float64, s l
 	radius := int(math.Round(f))                 // round radius to nearest integer
 
 	// set braille grid points from computed circle points around center
-	bGrid := graph.NewBrailleGrid(m.graphWidth, m.graphHeight, m.minX, m.maxX, m.minY, m.maxY)
+	bGrid := m.newBrailleGrid()
 	points := graph.GetCirclePoints(center, radius)
 	for _, p := range points {
 		np := canvas.NewFloat64PointFromPoint(p)
 		if m.AutoAdjustRange(np) {
 			m.UpdateGraphSizes()
 		}
-		bGrid.Set(bGrid.GridPoint(np))
+		bGrid.Set(bGrid.GridPoint(m.TransformFloat64Point(np)))
 	}
 
 	// get all rune patterns for braille grid and draw them on to the canvas
@@ -719,7 +881,9 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	if !m.focus {
 		return m, nil
 	}
+	m.UpdateHover(msg)
 	m.UpdateHandler(&m, msg)
+	m.SyncLinkGroup()
 	return m, nil
 }
 
@@ -3,7 +3,10 @@
 package linechart
 
 import (
+	"math"
 	"testing"
+
+	"github.com/NimbleMarkets/ntcharts/canvas"
 )
 
 func TestNew(t *testing.T) {
@@ -44,3 +47,31 @@ func TestNew(t *testing.T) {
 		t.Errorf("MaxY not initialized:%f", lc.MaxY())
 	}
 }
+
+func TestAutoAdjustRangeNonFinite(t *testing.T) {
+	lc := New(30, 12, 0, 1, 0, 1, WithAutoXYRange())
+	for _, v := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		if lc.AutoAdjustRange(canvas.Float64Point{X: v, Y: v}) {
+			t.Errorf("non-finite value %f adjusted range", v)
+		}
+	}
+	if (lc.MinX() != 0) || (lc.MaxX() != 1) || (lc.MinY() != 0) || (lc.MaxY() != 1) {
+		t.Errorf("non-finite values changed range:%f,%f,%f,%f", lc.MinX(), lc.MaxX(), lc.MinY(), lc.MaxY())
+	}
+}
+
+func TestSetMinYLabelWidth(t *testing.T) {
+	lc := New(30, 12, 0, 1, 0, 1)
+	x := lc.Origin().X
+	lc.SetMinYLabelWidth(x + 3)
+	if lc.Origin().X != x+3 {
+		t.Errorf("expected origin after reserved Y label width:%d", lc.Origin().X)
+	}
+	if lc.GraphWidth() != 30-(x+3)-1 {
+		t.Errorf("expected graph width to exclude reserved Y label width:%d", lc.GraphWidth())
+	}
+	lc.SetMinYLabelWidth(0)
+	if lc.Origin().X != x {
+		t.Errorf("expected origin of Y label width:%d", lc.Origin().X)
+	}
+}
@@ -0,0 +1,148 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package linechart
+
+// File contains link groups synchronizing the displayed
+// X and Y value ranges of independent linecharts.
+
+// LinkAxes are the axes synchronized by a LinkGroup.
+type LinkAxes int
+
+const (
+	LinkX LinkAxes = 1 << iota // synchronize displayed X values
+	LinkY                      // synchronize displayed Y values
+
+	LinkXY = LinkX | LinkY // synchronize displayed X and Y values
+)
+
+// Linkable is a chart that can join a LinkGroup, such as pointers to
+// linechart, streamlinechart, timeserieslinechart and wavelinechart Models.
+// Setting the displayed ranges of the Linkable is expected to rescale existing data.
+type Linkable interface {
+	LineChart() *Model
+	SetViewXYRange(minX, maxX, minY, maxY float64)
+}
+
+// LinkGroup synchronizes the displayed value ranges of the linked axes
+// of its members, such as side by side linecharts zooming together.
+// Changes of the displayed ranges of a member are propagated to all other
+// members after the member processes a bubbletea Msg with Update,
+// or whenever SyncLinkGroup is called on the member.
+// Members are pointers to the Models stored by the caller, which must
+// store the Models returned by Update in the same location.
+type LinkGroup struct {
+	axes    LinkAxes
+	members []Linkable
+	nextID  int
+	syncing bool       // whether propagating ranges to members
+	view    [4]float64 // last propagated ranges of linked axes
+}
+
+// NewLinkGroup returns a new *LinkGroup synchronizing given axes.
+func NewLinkGroup(axes LinkAxes) *LinkGroup {
+	return &LinkGroup{axes: axes}
+}
+
+// Axes returns the axes synchronized by the LinkGroup.
+func (g *LinkGroup) Axes() LinkAxes {
+	return g.axes
+}
+
+// Members returns the members of the LinkGroup.
+func (g *LinkGroup) Members() []Linkable {
+	return g.members
+}
+
+// Join adds given Linkable to the LinkGroup, leaving its existing LinkGroup.
+// The displayed ranges of the linked axes of the Linkable are set
+// to the displayed ranges of the existing members.
+func (g *LinkGroup) Join(c Linkable) {
+	lc := c.LineChart()
+	if lc.linkGroup != nil {
+		lc.linkGroup.Leave(c)
+	}
+	g.nextID++
+	lc.linkGroup = g
+	lc.linkID = g.nextID
+	if len(g.members) > 0 {
+		g.apply(c, g.members[0].LineChart())
+	}
+	g.members = append(g.members, c)
+}
+
+// Leave removes given Linkable from the LinkGroup.
+func (g *LinkGroup) Leave(c Linkable) {
+	lc := c.LineChart()
+	for i, e := range g.members {
+		if e.LineChart().linkID == lc.linkID {
+			g.members = append(g.members[:i], g.members[i+1:]...)
+			break
+		}
+	}
+	if lc.linkGroup == g {
+		lc.linkGroup = nil
+		lc.linkID = 0
+	}
+}
+
+// Sync propagates the displayed ranges of the linked axes of given
+// source linechart to all other members if they have changed since
+// the last propagation.  Members setting their displayed ranges
+// while propagating do not propagate again.
+func (g *LinkGroup) Sync(src *Model) {
+	if g.syncing {
+		return
+	}
+	view := g.linkedView(src)
+	if view == g.view {
+		return
+	}
+	g.syncing = true
+	defer func() { g.syncing = false }()
+	g.view = view
+	for _, c := range g.members {
+		// the source may be a copy of a member made by Update
+		if c.LineChart().linkID != src.linkID {
+			g.apply(c, src)
+		}
+	}
+}
+
+// linkedView returns the displayed ranges of the linked axes of given linechart.
+func (g *LinkGroup) linkedView(lc *Model) (v [4]float64) {
+	if g.axes&LinkX != 0 {
+		v[0], v[1] = lc.viewMinX, lc.viewMaxX
+	}
+	if g.axes&LinkY != 0 {
+		v[2], v[3] = lc.viewMinY, lc.viewMaxY
+	}
+	return
+}
+
+// apply sets the displayed ranges of the linked axes
+// of given Linkable to those of given source linechart.
+func (g *LinkGroup) apply(c Linkable, src *Model) {
+	lc := c.LineChart()
+	minX, maxX := lc.viewMinX, lc.viewMaxX
+	minY, maxY := lc.viewMinY, lc.viewMaxY
+	if g.axes&LinkX != 0 {
+		minX, maxX = src.viewMinX, src.viewMaxX
+	}
+	if g.axes&LinkY != 0 {
+		minY, maxY = src.viewMinY, src.viewMaxY
+	}
+	c.SetViewXYRange(minX, maxX, minY, maxY)
+}
+
+// LinkGroup returns the LinkGroup joined by the linechart, or nil.
+func (m *Model) LinkGroup() *LinkGroup {
+	return m.linkGroup
+}
+
+// SyncLinkGroup propagates the displayed ranges of the linechart
+// to the other members of its LinkGroup, if any.
+func (m *Model) SyncLinkGroup() {
+	if m.linkGroup != nil {
+		m.linkGroup.Sync(m)
+	}
+}
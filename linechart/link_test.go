@@ -0,0 +1,42 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package linechart
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestLinkGroup(t *testing.T) {
+	a := New(30, 12, 0, 100, 0, 10)
+	b := New(30, 12, 0, 100, 0, 20)
+	a.Focus()
+	g := NewLinkGroup(LinkX)
+	g.Join(&a)
+	g.Join(&b)
+
+	// zooming with Update propagates X but not Y ranges
+	a, _ = a.Update(tea.KeyMsg{Type: tea.KeyPgUp})
+	if (b.ViewMinX() != a.ViewMinX()) || (b.ViewMaxX() != a.ViewMaxX()) {
+		t.Errorf("X range not propagated:%f,%f expected %f,%f", b.ViewMinX(), b.ViewMaxX(), a.ViewMinX(), a.ViewMaxX())
+	}
+	if (a.ViewMaxX() >= 100) || (b.ViewMinY() != 0) || (b.ViewMaxY() != 20) {
+		t.Errorf("wrong ranges after zooming:%f,%f,%f", a.ViewMaxX(), b.ViewMinY(), b.ViewMaxY())
+	}
+
+	// setting ranges directly propagates with SyncLinkGroup
+	b.SetViewXRange(10, 20)
+	b.SyncLinkGroup()
+	if (a.ViewMinX() != 10) || (a.ViewMaxX() != 20) {
+		t.Errorf("X range not propagated:%f,%f", a.ViewMinX(), a.ViewMaxX())
+	}
+
+	// members leaving no longer receive ranges
+	g.Leave(&b)
+	a.SetViewXRange(30, 40)
+	a.SyncLinkGroup()
+	if (b.LinkGroup() != nil) || (len(g.Members()) != 1) || (b.ViewMinX() != 10) {
+		t.Errorf("wrong state after leaving:%d,%f", len(g.Members()), b.ViewMinX())
+	}
+}
@@ -101,3 +101,76 @@ func WithAutoYRange() Option {
 		m.AutoMaxY = true
 	}
 }
+
+// WithXTransform sets the X axis AxisTransform.
+func WithXTransform(t AxisTransform) Option {
+	return func(m *Model) {
+		m.xTransform = t
+	}
+}
+
+// WithYTransform sets the Y axis AxisTransform.
+func WithYTransform(t AxisTransform) Option {
+	return func(m *Model) {
+		m.yTransform = t
+	}
+}
+
+// WithXYTickLocators sets the TickLocators used to place
+// X and Y axes values instead of every number of steps.
+// X and Y steps must be greater than 0 to display axes.
+// If a TickLocator is nil, then axis values are placed using steps.
+func WithXYTickLocators(x, y TickLocator) Option {
+	return func(m *Model) {
+		m.xTickLocator = x
+		m.yTickLocator = y
+	}
+}
+
+// WithXYMinorTicks sets the number of minor tick intervals between
+// X and Y axes ticks placed by TickLocators.
+func WithXYMinorTicks(x, y int) Option {
+	return func(m *Model) {
+		m.xMinorTicks = x
+		m.yMinorTicks = y
+	}
+}
+
+// WithHover enables tracking the mouse over the graphing area
+// for drawing a crosshair and tooltip with DrawHover.
+func WithHover() Option {
+	return func(m *Model) {
+		m.SetHover(true)
+	}
+}
+
+// WithHoverStyles sets the styles of the hover crosshair and tooltips.
+func WithHoverStyles(crosshair, tooltip lipgloss.Style) Option {
+	return func(m *Model) {
+		m.CrosshairStyle = crosshair
+		m.TooltipStyle = tooltip
+	}
+}
+
+// WithHoverFormatters sets the formatters for displaying
+// X and Y values of hover tooltips as strings.
+func WithHoverFormatters(x, y LabelFormatter) Option {
+	return func(m *Model) {
+		m.HoverXFormatter = x
+		m.HoverYFormatter = y
+	}
+}
+
+// WithZoomKeyMap sets the key bindings of the zoom history.
+func WithZoomKeyMap(k ZoomKeyMap) Option {
+	return func(m *Model) {
+		m.ZoomKeyMap = k
+	}
+}
+
+// WithSelectionStyle sets the style of the box zoom selection.
+func WithSelectionStyle(s lipgloss.Style) Option {
+	return func(m *Model) {
+		m.SelectionStyle = s
+	}
+}
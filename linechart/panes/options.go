@@ -0,0 +1,29 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package panes
+
+// File contains options used by the panes during initialization with New().
+
+import (
+	zone "github.com/lrstanley/bubblezone"
+)
+
+// Option is used to set options when initializing panes. Example:
+//
+//	p := New(width, height, WithPane(&priceChart, 3), WithPane(&volumeChart, 1))
+type Option func(*Model)
+
+// WithPane adds a Chart below the existing panes with given relative height.
+func WithPane(c Chart, weight int) Option {
+	return func(m *Model) {
+		m.AddPane(c, weight)
+	}
+}
+
+// WithZoneManager sets the bubblezone Manager used
+// when processing bubbletea Msg mouse events in Update().
+func WithZoneManager(zm *zone.Manager) Option {
+	return func(m *Model) {
+		m.SetZoneManager(zm)
+	}
+}
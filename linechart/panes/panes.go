@@ -0,0 +1,299 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+// Package panes implements a container stacking linecharts vertically
+// with a single shared X axis displayed below the bottom pane, such as
+// price, volume and indicator panes of a timeserieslinechart.
+// Moving and zooming the viewport of any pane is synchronized to all panes.
+package panes
+
+import (
+	"github.com/NimbleMarkets/ntcharts/linechart"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	zone "github.com/lrstanley/bubblezone"
+)
+
+// Chart is a chart displayed in a pane, such as pointers to
+// linechart, streamlinechart, timeserieslinechart and wavelinechart Models.
+// Charts are drawn by the caller, and setting the displayed ranges
+// of the Chart is expected to rescale existing data.
+type Chart interface {
+	LineChart() *linechart.Model
+	SetViewXYRange(minX, maxX, minY, maxY float64)
+	Resize(w, h int)
+	View() string
+}
+
+// CursorChart is a Chart with a keyboard data cursor, such as pointers
+// to streamlinechart, timeserieslinechart and wavelinechart Models.
+// Key messages are given to the data cursor of the active pane
+// before its linechart UpdateHandler.
+type CursorChart interface {
+	Chart
+	UpdateCursor(msg tea.Msg) bool
+}
+
+// pane contains a Chart and its relative height.
+type pane struct {
+	chart  Chart
+	weight int
+	xStep  int // number of X steps of the Chart when it is the bottom pane
+}
+
+// Model contains state of a stack of panes sharing the X axis
+// of the bottom pane.  Uses the data cursor of a CursorChart and then
+// the linechart UpdateHandler of the active pane for processing keyboard
+// and mouse messages, where the active pane is the last pane receiving
+// a mouse press.
+type Model struct {
+	panes  []pane
+	width  int
+	height int
+	active int // index of pane receiving messages
+	focus  bool
+
+	zoneManager *zone.Manager // provides mouse functionality
+}
+
+// New returns a panes Model initialized with given width, height and various options.
+func New(w, h int, opts ...Option) Model {
+	m := Model{width: w, height: h}
+	for _, opt := range opts {
+		opt(&m)
+	}
+	m.Resize(w, h)
+	return m
+}
+
+// AddPane adds a Chart below the existing panes with given relative height,
+// which becomes the bottom pane displaying the shared X axis.
+// X axes of other panes are hidden.
+func (m *Model) AddPane(c Chart, weight int) {
+	lc := c.LineChart()
+	m.panes = append(m.panes, pane{chart: c, weight: max(weight, 1), xStep: lc.XStep()})
+	if m.zoneManager != nil {
+		lc.SetZoneManager(m.zoneManager)
+	}
+	m.Resize(m.width, m.height)
+}
+
+// Len returns the number of panes.
+func (m *Model) Len() int {
+	return len(m.panes)
+}
+
+// Pane returns the Chart of the pane at given index from the top.
+func (m *Model) Pane(i int) Chart {
+	return m.panes[i].chart
+}
+
+// SetPaneWeight sets the relative height of the pane at given index.
+func (m *Model) SetPaneWeight(i, weight int) {
+	m.panes[i].weight = max(weight, 1)
+	m.Resize(m.width, m.height)
+}
+
+// Active returns the index of the pane receiving messages.
+func (m *Model) Active() int {
+	return m.active
+}
+
+// SetActive sets the index of the pane receiving messages.
+func (m *Model) SetActive(i int) {
+	if (i >= 0) && (i < len(m.panes)) {
+		m.active = i
+	}
+}
+
+// Width returns the width of the panes.
+func (m *Model) Width() int {
+	return m.width
+}
+
+// Height returns the total height of the panes.
+func (m *Model) Height() int {
+	return m.height
+}
+
+// Resize will change the width and total height of the panes.
+// The height excluding the shared X axis is divided between
+// the panes in proportion to their weights.
+func (m *Model) Resize(w, h int) {
+	m.width = w
+	m.height = h
+	n := len(m.panes)
+	if n == 0 {
+		return
+	}
+	// only the bottom pane displays the X axis
+	for i, p := range m.panes {
+		if i < n-1 {
+			p.chart.LineChart().SetXStep(0)
+		} else {
+			p.chart.LineChart().SetXStep(p.xStep)
+		}
+	}
+	axis := 0
+	if m.panes[n-1].xStep > 0 {
+		axis = 2 // X axis and values below the graphing area
+	}
+	heights := paneHeights(m.panes, h-axis)
+	for i, p := range m.panes {
+		if i == n-1 {
+			heights[i] += axis
+		}
+		p.chart.Resize(w, heights[i])
+	}
+	m.Sync()
+}
+
+// paneHeights returns the heights of each pane dividing given total height
+// in proportion to their weights, where each pane has a height of at least 1
+// if there are enough rows.  Remaining rows are given to the top panes, and
+// rows exceeding the total height are taken from the tallest panes, then
+// from the bottom panes if there are more panes than rows.
+func paneHeights(panes []pane, h int) []int {
+	h = max(h, 0)
+	total := 0
+	for _, p := range panes {
+		total += p.weight
+	}
+	r := make([]int, len(panes))
+	rem := h
+	for i, p := range panes {
+		r[i] = max(h*p.weight/total, 1)
+		rem -= r[i]
+	}
+	for i := 0; rem > 0; i = (i + 1) % len(r) {
+		r[i]++
+		rem--
+	}
+	for ; rem < 0; rem++ {
+		j := 0
+		for i := range r {
+			if r[i] > r[j] {
+				j = i
+			}
+		}
+		if r[j] <= 1 { // more panes than rows
+			j = len(r) - 1
+			for r[j] == 0 {
+				j--
+			}
+		}
+		r[j]--
+	}
+	return r
+}
+
+// Sync updates all panes to display the X values displayed by the
+// active pane within the combined expected X values of all panes, and
+// aligns the graphing areas of all panes.  Sync is called after processing
+// messages in Update, and should be called after pushing data to any pane
+// that may have changed its X ranges.
+func (m *Model) Sync() {
+	if len(m.panes) == 0 {
+		return
+	}
+	src := m.panes[m.active].chart.LineChart()
+	minX, maxX := src.MinX(), src.MaxX()
+	for _, p := range m.panes {
+		lc := p.chart.LineChart()
+		minX = min(minX, lc.MinX())
+		maxX = max(maxX, lc.MaxX())
+	}
+	viewMinX, viewMaxX := src.ViewMinX(), src.ViewMaxX()
+	if (viewMinX == src.MinX()) && (viewMaxX == src.MaxX()) { // not zoomed in
+		viewMinX, viewMaxX = minX, maxX
+	}
+	// reserve the same space for Y axis values of all panes
+	w := 0
+	for _, p := range m.panes {
+		lc := p.chart.LineChart()
+		lc.SetMinYLabelWidth(0)
+		w = max(w, lc.Origin().X)
+	}
+	for _, p := range m.panes {
+		lc := p.chart.LineChart()
+		lc.SetMinYLabelWidth(w)
+		lc.SetXRange(minX, maxX)
+		p.chart.SetViewXYRange(viewMinX, viewMaxX, lc.ViewMinY(), lc.ViewMaxY())
+	}
+}
+
+// SetZoneManager enables mouse functionality of all panes
+// by setting a bubblezone Manager to each Chart.
+func (m *Model) SetZoneManager(zm *zone.Manager) {
+	m.zoneManager = zm
+	for _, p := range m.panes {
+		p.chart.LineChart().SetZoneManager(zm)
+	}
+}
+
+// ZoneManager will return the panes zone Manager.
+func (m *Model) ZoneManager() *zone.Manager {
+	return m.zoneManager
+}
+
+// Focused returns whether panes are being focused.
+func (m *Model) Focused() bool {
+	return m.focus
+}
+
+// Focus enables Update events processing.
+func (m *Model) Focus() {
+	m.focus = true
+}
+
+// Blur disables Update events processing.
+func (m *Model) Blur() {
+	m.focus = false
+}
+
+// Init initializes the panes.
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update processes bubbletea Msg by invoking the data cursor of a CursorChart
+// and then the UpdateHandler of the active pane if focused, and synchronizes
+// the displayed X values of all panes.  Messages used by the data cursor
+// are not given to the UpdateHandler.
+// Pressing a mouse button or the mouse wheel over a pane makes it active.
+// The mouse position is tracked by all panes with hover enabled.
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if !m.focus || (len(m.panes) == 0) {
+		return m, nil
+	}
+	if msg, ok := msg.(tea.MouseMsg); ok && (msg.Action == tea.MouseActionPress) && (m.zoneManager != nil) {
+		for i, p := range m.panes {
+			if m.zoneManager.Get(p.chart.LineChart().ZoneID()).InBounds(msg) {
+				m.active = i
+				break
+			}
+		}
+	}
+	for _, p := range m.panes {
+		p.chart.LineChart().UpdateHover(msg)
+	}
+	c := m.panes[m.active].chart
+	lc := c.LineChart()
+	cc, ok := c.(CursorChart)
+	if (!ok || !cc.UpdateCursor(msg)) && (lc.UpdateHandler != nil) { // data cursor keys take precedence
+		lc.UpdateHandler(lc, msg)
+	}
+	m.Sync()
+	lc.SyncLinkGroup()
+	return m, nil
+}
+
+// View returns a string used by the bubbletea framework
+// to display the panes stacked vertically.
+func (m Model) View() string {
+	views := make([]string, len(m.panes))
+	for i, p := range m.panes {
+		views[i] = p.chart.View()
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, views...)
+}
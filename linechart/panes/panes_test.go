@@ -0,0 +1,112 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package panes
+
+import (
+	"testing"
+
+	"github.com/NimbleMarkets/ntcharts/canvas"
+	"github.com/NimbleMarkets/ntcharts/linechart"
+	"github.com/NimbleMarkets/ntcharts/linechart/wavelinechart"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPanesLayout(t *testing.T) {
+	top := linechart.New(10, 10, 0, 10, 0, 1000)
+	bottom := linechart.New(10, 10, 5, 20, 0, 1)
+	m := New(20, 12, WithPane(&top, 3), WithPane(&bottom, 1))
+
+	if h := top.Height() + bottom.Height(); h != 12 {
+		t.Errorf("expected total height of 12:%d", h)
+	}
+	if (top.Height() != 8) || (bottom.Height() != 4) {
+		t.Errorf("expected heights in proportion to weights:%d %d", top.Height(), bottom.Height())
+	}
+	if top.XStep() != 0 {
+		t.Errorf("expected X axis of top pane to be hidden:%d", top.XStep())
+	}
+	if top.Origin().X != bottom.Origin().X {
+		t.Errorf("expected aligned graphing areas:%d %d", top.Origin().X, bottom.Origin().X)
+	}
+	if (top.MinX() != 0) || (bottom.MaxX() != 20) || (bottom.ViewMinX() != 0) {
+		t.Errorf("expected combined X ranges:%v %v %v", top.MinX(), bottom.MaxX(), bottom.ViewMinX())
+	}
+
+	m.SetActive(1)
+	bottom.SetViewXRange(8, 12)
+	m.Sync()
+	if (top.ViewMinX() != 8) || (top.ViewMaxX() != 12) {
+		t.Errorf("expected X view of active pane:%v %v", top.ViewMinX(), top.ViewMaxX())
+	}
+}
+
+func TestPaneHeights(t *testing.T) {
+	tests := []struct {
+		weights []int
+		h       int
+		want    []int
+	}{
+		{[]int{3, 1}, 12, []int{9, 3}},
+		{[]int{2, 1}, 10, []int{7, 3}},
+		{[]int{10, 1, 1}, 4, []int{2, 1, 1}},
+		{[]int{1, 1, 1}, 3, []int{1, 1, 1}},
+		{[]int{1, 1, 1}, 2, []int{1, 1, 0}},
+		{[]int{1, 5, 1}, 1, []int{1, 0, 0}},
+		{[]int{1, 1}, 0, []int{0, 0}},
+		{[]int{1, 1}, -2, []int{0, 0}},
+	}
+	for _, tc := range tests {
+		panes := make([]pane, len(tc.weights))
+		for i, w := range tc.weights {
+			panes[i].weight = w
+		}
+		got := paneHeights(panes, tc.h)
+		for i := range tc.want {
+			if got[i] != tc.want[i] {
+				t.Errorf("wrong heights of weights %v in height %d:%v expected %v", tc.weights, tc.h, got, tc.want)
+				break
+			}
+		}
+	}
+
+	// more panes than rows
+	a := linechart.New(10, 10, 0, 10, 0, 1)
+	b := linechart.New(10, 10, 0, 10, 0, 1)
+	c := linechart.New(10, 10, 0, 10, 0, 1)
+	New(20, 4, WithPane(&a, 1), WithPane(&b, 1), WithPane(&c, 1))
+	if h := a.Height() + b.Height() + c.Height(); h > 4 {
+		t.Errorf("panes exceed total height:%d %d %d", a.Height(), b.Height(), c.Height())
+	}
+}
+
+func TestPanesCursor(t *testing.T) {
+	top := wavelinechart.New(10, 10)
+	for i := 0; i < 10; i++ {
+		top.Plot(canvas.Float64Point{X: float64(i), Y: float64(i)})
+	}
+	top.SetViewXRange(2, 8)
+	bottom := linechart.New(10, 10, 0, 10, 0, 1)
+	m := New(20, 12, WithPane(&top, 3), WithPane(&bottom, 1))
+	m.Focus()
+
+	// data cursor keys of the active pane take precedence over moving the view
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	if !top.Cursor() {
+		t.Fatal("data cursor of active pane not shown")
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	if f, _ := top.CursorPoint(); f.X != 7 {
+		t.Errorf("data cursor not moved:%v", f)
+	}
+	if (top.ViewMinX() != 2) || (top.ViewMaxX() != 8) {
+		t.Errorf("view moved by data cursor key:%f %f", top.ViewMinX(), top.ViewMaxX())
+	}
+
+	// keys are given to the UpdateHandler while the data cursor is hidden
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	m.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	if top.ViewMinX() >= 2 {
+		t.Errorf("view not moved without data cursor:%f", top.ViewMinX())
+	}
+}
@@ -0,0 +1,174 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package streamlinechart
+
+// File contains the keyboard data cursor of the streamlinechart
+// moving between the data values of the visible data sets.
+
+import (
+	"math"
+	"slices"
+
+	"github.com/NimbleMarkets/ntcharts/canvas"
+	"github.com/NimbleMarkets/ntcharts/linechart"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// UpdateCursor processes bubbletea key Msg matching the CursorKeyMap to
+// show, hide and move the data cursor, and returns whether the Msg was
+// used by the data cursor.  Called by Update before the UpdateHandler, and
+// should be called for charts receiving messages without Update.
+func (m *Model) UpdateCursor(tm tea.Msg) bool {
+	return m.UpdateDataCursor(m, tm)
+}
+
+// CursorDataSet returns the name of the data set of the data cursor.
+func (m *Model) CursorDataSet() string {
+	m.cursorIndex()
+	return m.cursorSet
+}
+
+// SetCursorDataSet moves the data cursor to the data set given by name string.
+func (m *Model) SetCursorDataSet(n string) {
+	m.cursorSet = n
+}
+
+// CursorNextDataSet moves the data cursor to the visible data set
+// given number of data sets after the data set of the data cursor
+// by name, or before if negative.
+func (m *Model) CursorNextDataSet(d int) {
+	linechart.NextCursorDataSet(m, d)
+}
+
+// CursorNext moves the data cursor to the next newer data value of its data set.
+func (m *Model) CursorNext() {
+	m.cursorStep(1)
+}
+
+// CursorPrev moves the data cursor to the next older data value of its data set.
+func (m *Model) CursorPrev() {
+	m.cursorStep(-1)
+}
+
+// CursorValue returns the data value of the data cursor,
+// the number of data values pushed to its data set after it,
+// and whether there is a data value to display.
+func (m *Model) CursorValue() (float64, int, bool) {
+	ds, i, ok := m.cursorIndex()
+	if !ok {
+		return 0, 0, false
+	}
+	return ds.sBuf.AtRaw(i), m.cursorAge, true
+}
+
+// CursorReadout returns lines of text displaying the data set name,
+// value and difference from the previous value of the data cursor.
+func (m *Model) CursorReadout() []string {
+	ds, i, ok := m.cursorIndex()
+	if !ok {
+		return nil
+	}
+	v := ds.sBuf.AtRaw(i)
+	var prev float64
+	j, hasPrev := ds.validStep(i, -1)
+	if hasPrev {
+		prev = ds.sBuf.AtRaw(j)
+	}
+	lines := []string{"V: " + m.HoverYFormatter(0, v)}
+	return m.FormatCursorReadout(m.cursorSet, lines, v, prev, hasPrev)
+}
+
+// DrawCursor draws the data cursor and its readout if the data cursor
+// is shown.  Should be called after drawing data to overlay the graphing area.
+func (m *Model) DrawCursor() {
+	if !m.Cursor() {
+		return
+	}
+	ds, i, ok := m.cursorIndex()
+	if !ok {
+		return
+	}
+	// data values are drawn from the right of the canvas
+	p := canvas.Point{
+		X: m.Canvas.Width() - ds.sBuf.Length() + i,
+		Y: canvas.CanvasYCoordinate(m.Origin().Y, int(math.Round(ds.sBuf.At(i)))),
+	}
+	m.DrawCursorAt(p, m.CursorReadout())
+}
+
+// CursorDataSets returns the names of visible data sets containing
+// data values, which the data cursor can be placed on, sorted by name.
+func (m *Model) CursorDataSets() []string {
+	names := m.DataSetNames()
+	r := names[:0]
+	for _, n := range names {
+		if m.DataSetVisible(n) && (m.dSets[n].sBuf.Length() > 0) {
+			r = append(r, n)
+		}
+	}
+	return r
+}
+
+// cursorIndex returns the data set and index of the data value of the data
+// cursor, and whether there is such a data value.  The data cursor is placed
+// on the data value nearest to its number of newer data values of its
+// data set or of the first visible data set.
+func (m *Model) cursorIndex() (*dataSet, int, bool) {
+	names := m.CursorDataSets()
+	if len(names) == 0 {
+		return nil, 0, false
+	}
+	if !slices.Contains(names, m.cursorSet) {
+		m.cursorSet = names[0]
+	}
+	ds := m.dSets[m.cursorSet]
+	l := ds.sBuf.Length()
+	i := l - 1 - min(m.cursorAge, l-1)
+	// search outwards for the nearest finite data value
+	for lo, hi := i, i+1; (lo >= 0) || (hi < l); {
+		if lo >= 0 {
+			if isFinite(ds.sBuf.AtRaw(lo)) {
+				m.cursorAge = l - 1 - lo
+				return ds, lo, true
+			}
+			lo--
+		}
+		if hi < l {
+			if isFinite(ds.sBuf.AtRaw(hi)) {
+				m.cursorAge = l - 1 - hi
+				return ds, hi, true
+			}
+			hi++
+		}
+	}
+	return ds, 0, false
+}
+
+// cursorStep moves the data cursor to the next data value of its data set
+// in given direction ignoring NaN and infinite values.
+func (m *Model) cursorStep(d int) {
+	ds, i, ok := m.cursorIndex()
+	if !ok {
+		return
+	}
+	if j, ok := ds.validStep(i, d); ok {
+		m.cursorAge = ds.sBuf.Length() - 1 - j
+	}
+}
+
+// validStep returns the index of the next data value from given index
+// in given direction with a finite value, and whether there is one.
+func (ds *dataSet) validStep(i, d int) (int, bool) {
+	for i += d; (i >= 0) && (i < ds.sBuf.Length()); i += d {
+		if isFinite(ds.sBuf.AtRaw(i)) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// isFinite returns whether given value is neither NaN nor infinite.
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
@@ -0,0 +1,66 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package streamlinechart
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestCursor(t *testing.T) {
+	m := New(20, 10)
+	for i, v := range []float64{1, 2, math.NaN(), 5, 3} {
+		m.Push(v)
+		m.PushDataSet("b", float64(10*i))
+	}
+	m.Focus()
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	if !m.Cursor() {
+		t.Fatal("data cursor not shown")
+	}
+
+	// data cursor starts at the newest data value of the first data set
+	if v, age, ok := m.CursorValue(); !ok || (v != 10*4) || (age != 0) {
+		t.Errorf("wrong initial data cursor:%f %d %s", v, age, m.CursorDataSet())
+	}
+
+	// tab and shift+tab cycle through the data sets keeping the age
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if v, _, _ := m.CursorValue(); (m.CursorDataSet() != DefaultDataSetName) || (v != 3) {
+		t.Errorf("wrong data cursor after tab:%s %f", m.CursorDataSet(), v)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if m.CursorDataSet() != "b" {
+		t.Errorf("tab did not wrap around:%s", m.CursorDataSet())
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	if m.CursorDataSet() != DefaultDataSetName {
+		t.Errorf("wrong data set after shift+tab:%s", m.CursorDataSet())
+	}
+
+	// stepping skips NaN values
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	if v, age, _ := m.CursorValue(); (v != 5) || (age != 1) {
+		t.Errorf("wrong data cursor after left:%f %d", v, age)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	if v, age, _ := m.CursorValue(); (v != 2) || (age != 3) {
+		t.Errorf("data cursor did not skip NaN value:%f %d", v, age)
+	}
+
+	// difference is from the previous finite value
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	r := m.CursorReadout()
+	if (len(r) != 3) || (r[0] != DefaultDataSetName) || !strings.HasPrefix(r[2], "Δ: +3") {
+		t.Errorf("wrong readout:%q", r)
+	}
+	m.CursorPrev()
+	m.CursorPrev()
+	m.CursorPrev()
+	if r := m.CursorReadout(); len(r) != 2 {
+		t.Errorf("expected no difference for oldest data value:%q", r)
+	}
+}
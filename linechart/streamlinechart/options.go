@@ -117,3 +117,107 @@ func WithDataSetStream(n string, f []float64) Option {
 		}
 	}
 }
+
+// WithYTransform sets the Y axis AxisTransform.
+func WithYTransform(t linechart.AxisTransform) Option {
+	return func(m *Model) {
+		m.SetYTransform(t)
+	}
+}
+
+// WithXYTickLocators sets the TickLocators used to place
+// X and Y axes values instead of every number of steps.
+// If a TickLocator is nil, then axis values are placed using steps.
+func WithXYTickLocators(x, y linechart.TickLocator) Option {
+	return func(m *Model) {
+		m.SetXTickLocator(x)
+		m.SetYTickLocator(y)
+	}
+}
+
+// WithXYMinorTicks sets the number of minor tick intervals between
+// X and Y axes ticks placed by TickLocators.
+func WithXYMinorTicks(x, y int) Option {
+	return func(m *Model) {
+		m.SetXMinorTicks(x)
+		m.SetYMinorTicks(y)
+	}
+}
+
+// WithDataSetZOrder sets the z-order of the data set given by name string,
+// where data sets with greater z-order are drawn over others.
+func WithDataSetZOrder(n string, z int) Option {
+	return func(m *Model) {
+		m.SetDataSetZOrder(n, z)
+	}
+}
+
+// WithExcludeHiddenRange excludes data values pushed to hidden
+// data sets from automatic adjustment of the value ranges.
+func WithExcludeHiddenRange() Option {
+	return func(m *Model) {
+		m.SetExcludeHiddenRange(true)
+	}
+}
+
+// WithHover enables tracking the mouse over the graphing area for
+// drawing a crosshair and tooltip of the nearest data values with DrawHover.
+func WithHover() Option {
+	return func(m *Model) {
+		m.SetHover(true)
+	}
+}
+
+// WithHoverStyles sets the styles of the hover crosshair and tooltips.
+func WithHoverStyles(crosshair, tooltip lipgloss.Style) Option {
+	return func(m *Model) {
+		m.CrosshairStyle = crosshair
+		m.TooltipStyle = tooltip
+	}
+}
+
+// WithCursor shows the keyboard data cursor drawn with DrawCursor.
+func WithCursor() Option {
+	return func(m *Model) {
+		m.SetCursor(true)
+	}
+}
+
+// WithCursorKeyMap sets the key bindings of the keyboard data cursor.
+func WithCursorKeyMap(k linechart.CursorKeyMap) Option {
+	return func(m *Model) {
+		m.CursorKeyMap = k
+	}
+}
+
+// WithCursorStyle sets the style applied to the data point of the data cursor.
+func WithCursorStyle(s lipgloss.Style) Option {
+	return func(m *Model) {
+		m.CursorStyle = s
+	}
+}
+
+// WithZoomKeyMap sets the key bindings of the zoom history.
+func WithZoomKeyMap(k linechart.ZoomKeyMap) Option {
+	return func(m *Model) {
+		m.ZoomKeyMap = k
+	}
+}
+
+// WithSelectionStyle sets the style of the box zoom selection.
+func WithSelectionStyle(s lipgloss.Style) Option {
+	return func(m *Model) {
+		m.SelectionStyle = s
+	}
+}
+
+// WithAutoFitY enables fitting the displayed Y values to the data values
+// of visible data sets with given fraction of padding above and below,
+// and whether the fitted Y values include zero.
+func WithAutoFitY(padding float64, zero bool) Option {
+	return func(m *Model) {
+		m.SetAutoFitY(true)
+		m.SetAutoFitYPadding(padding)
+		m.SetAutoFitYZero(zero)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/NimbleMarkets/ntcharts/canvas/buffer"
 	"github.com/NimbleMarkets/ntcharts/canvas/graph"
 	"github.com/NimbleMarkets/ntcharts/canvas/runes"
+	"github.com/NimbleMarkets/ntcharts/legend"
 	"github.com/NimbleMarkets/ntcharts/linechart"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -38,6 +39,13 @@ type Model struct {
 	dLineStyle runes.LineStyle     // default data set LineStyletype
 	dStyle     lipgloss.Style      // default data set Style
 	dSets      map[string]*dataSet // maps names to data sets
+
+	linechart.DataSetVisibility // visibility and z-order of data sets
+
+	fitPending bool // whether pushed data values are not yet fitted by auto fit
+
+	cursorSet string // name of data set of the data cursor
+	cursorAge int    // number of data values pushed after the data value of the data cursor
 }
 
 // New returns a streamlinechart Model initialized from
@@ -68,34 +76,92 @@ func New(w, h int, opts ...Option) Model {
 func (m *Model) newDataSet() *dataSet {
 	// use GraphHeight() for y scale factor to align with y axis ticks
 	// note that graph width is not used since lines are able to overlap onto Y axis
-	ys := float64(m.GraphHeight()) / (m.ViewMaxY() - m.ViewMinY()) // y scale factor
+	offset, ys := m.dataScale()
 	return &dataSet{
 		LineStyle: m.dLineStyle,
 		Style:     m.dStyle,
-		sBuf:      buffer.NewFloat64ScaleRingBuffer(m.Width()-m.Origin().X, m.ViewMinY(), ys),
+		sBuf:      buffer.NewScaleRingBuffer(m.Width()-m.Origin().X, offset, ys, m.YScaleFunc()),
 	}
 }
 
+// getDataSet returns the data set given by name string,
+// creating a new data set if it does not exist.
+func (m *Model) getDataSet(n string) *dataSet {
+	if _, ok := m.dSets[n]; !ok {
+		m.dSets[n] = m.newDataSet()
+	}
+	return m.dSets[n]
+}
+
+// dataScale returns the Y offset and Y scale factor used
+// to scale Y data values in the transformed space of the Y axis
+// to the graphing area.
+func (m *Model) dataScale() (offset, scale float64) {
+	// use GraphHeight() for y scale factor to align with y axis ticks
+	offset = m.TransformY(m.ViewMinY())
+	scale = float64(m.GraphHeight()) / (m.TransformY(m.ViewMaxY()) - offset) // y scale factor
+	return
+}
+
 // rescaleData will scale all internally stored data with new scale factor.
 func (m *Model) rescaleData() {
 	// rescale stream buffer
-	ys := float64(m.GraphHeight()) / (m.ViewMaxY() - m.ViewMinY()) // y scale factor
+	offset, ys := m.dataScale()
 	for _, ds := range m.dSets {
 		width := m.Width() - m.Origin().X // width of graphing area includes Y axis
 		// create new buffer with new size if the graphing area size has changed
 		if ds.sBuf.Size() != width {
-			buf := buffer.NewFloat64ScaleRingBuffer(width, m.ViewMinY(), ys)
+			buf := buffer.NewScaleRingBuffer(width, offset, ys, m.YScaleFunc())
 			for _, f := range ds.sBuf.ReadAllRaw() {
 				buf.Push(f)
 			}
 			ds.sBuf = buf
 		} else {
-			ds.sBuf.SetScale(ys)
-			ds.sBuf.SetOffset(m.ViewMinY())
+			ds.sBuf.SetOffsetAndScale(offset, ys)
 		}
 	}
 }
 
+// fitYRange fits the displayed Y values to the data values
+// of visible data sets if auto fit is enabled.
+func (m *Model) fitYRange() {
+	m.fitPending = false
+	if !m.AutoFitY() {
+		return
+	}
+	if m.FitViewYRange(m.visibleYRange()) {
+		m.rescaleData()
+	}
+}
+
+// fitPushed fits the displayed Y values if data values have been pushed
+// since the last fit.  Pushing data values only marks the fit as pending,
+// such that pushing many data values fits once when drawing or in Update.
+func (m *Model) fitPushed() {
+	if m.fitPending {
+		m.fitYRange()
+	}
+}
+
+// visibleYRange returns the minimum and maximum data values of visible
+// data sets, which are infinite if there are no such data values.
+func (m *Model) visibleYRange() (lo, hi float64) {
+	yt := m.YTransform()
+	lo, hi = math.Inf(1), math.Inf(-1)
+	for n, ds := range m.dSets {
+		if !m.DataSetVisible(n) {
+			continue
+		}
+		for _, f := range ds.sBuf.ReadAllRaw() {
+			if isFinite(f) && yt.Valid(f) {
+				lo = math.Min(lo, f)
+				hi = math.Max(hi, f)
+			}
+		}
+	}
+	return
+}
+
 // ClearAllData will reset stored data values in all data sets.
 func (m *Model) ClearAllData() {
 	for _, ds := range m.dSets {
@@ -111,6 +177,84 @@ func (m *Model) ClearDataSet(n string) {
 	}
 }
 
+// DataSetNames returns the names of all data sets sorted by name.
+func (m *Model) DataSetNames() []string {
+	names := make([]string, 0, len(m.dSets))
+	for n := range m.dSets {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// HasDataSet returns whether the data set given by name string exists.
+func (m *Model) HasDataSet(n string) bool {
+	_, ok := m.dSets[n]
+	return ok
+}
+
+// DataSetLen returns the number of data values stored
+// by the data set given by name string.
+func (m *Model) DataSetLen(n string) int {
+	if ds, ok := m.dSets[n]; ok {
+		return ds.sBuf.Length()
+	}
+	return 0
+}
+
+// DataSetStyles returns the LineStyle and Style of the data set
+// given by name string, or the default styles if it does not exist.
+func (m *Model) DataSetStyles(n string) (runes.LineStyle, lipgloss.Style) {
+	if ds, ok := m.dSets[n]; ok {
+		return ds.LineStyle, ds.Style
+	}
+	return m.dLineStyle, m.dStyle
+}
+
+// Values returns the data values of the default data set.
+func (m *Model) Values() []float64 {
+	return m.DataSetValues(DefaultDataSetName)
+}
+
+// DataSetValues returns the data values of the data set given by name
+// string from oldest to newest, which are limited by the graph width.
+func (m *Model) DataSetValues(n string) []float64 {
+	if ds, ok := m.dSets[n]; ok {
+		return ds.sBuf.ReadAllRaw()
+	}
+	return []float64{}
+}
+
+// RemoveDataSet will delete the data set given by name string
+// including its styles.  The default data set is replaced
+// by a new empty data set with the default styles.
+func (m *Model) RemoveDataSet(n string) {
+	delete(m.dSets, n)
+	m.DataSetVisibility.RemoveDataSet(n)
+	if n == DefaultDataSetName {
+		m.dSets[DefaultDataSetName] = m.newDataSet()
+	}
+}
+
+// RenameDataSet will rename the data set given by old name string
+// to the new name string, keeping its data and styles.
+// Renaming the default data set leaves a new empty default data set.
+// Returns false without renaming if the old data set does not exist
+// or a data set with the new name exists.
+func (m *Model) RenameDataSet(old, new string) bool {
+	ds, ok := m.dSets[old]
+	if _, exists := m.dSets[new]; !ok || exists {
+		return false
+	}
+	delete(m.dSets, old)
+	m.dSets[new] = ds
+	m.DataSetVisibility.RenameDataSet(old, new)
+	if old == DefaultDataSetName {
+		m.dSets[DefaultDataSetName] = m.newDataSet()
+	}
+	return true
+}
+
 // SetXRange updates the minimum and maximum expected X values.
 // Existing data will be rescaled.
 func (m *Model) SetXRange(min, max float64) {
@@ -126,10 +270,11 @@ func (m *Model) SetYRange(min, max float64) {
 }
 
 // SetViewXRange updates the displayed minimum and maximum X values.
-// Existing data will be rescaled.
+// Existing data will be rescaled and Y values fitted if auto fit is enabled.
 func (m *Model) SetViewXRange(min, max float64) {
 	m.Model.SetViewXRange(min, max)
 	m.rescaleData()
+	m.fitYRange()
 }
 
 // SetViewYRange updates the displayed minimum and maximum Y values.
@@ -141,17 +286,31 @@ func (m *Model) SetViewYRange(min, max float64) {
 
 // SetViewXYRange updates the displayed minimum and maximum X and Y values.
 // Existing data will be rescaled.
+// Y values are replaced by fitted Y values if auto fit is enabled.
 func (m *Model) SetViewXYRange(minX, maxX, minY, maxY float64) {
 	m.Model.SetViewXRange(minX, maxX)
 	m.Model.SetViewYRange(minY, maxY)
 	m.rescaleData()
+	m.fitYRange()
 }
 
 // Resize will change streamlinechart display width and height.
-// Existing data will be rescaled.
+// Existing data will be rescaled and Y values fitted if auto fit is enabled.
 func (m *Model) Resize(w, h int) {
 	m.Model.Resize(w, h)
 	m.rescaleData()
+	m.fitYRange()
+}
+
+// SetYTransform sets the Y axis AxisTransform.
+// Existing data will be rescaled.
+func (m *Model) SetYTransform(t linechart.AxisTransform) {
+	m.Model.SetYTransform(t)
+	fn := m.YScaleFunc()
+	for _, ds := range m.dSets {
+		ds.sBuf.SetScaleFunc(fn)
+	}
+	m.rescaleData()
 }
 
 // SetStyles will set the default styles of data sets.
@@ -171,6 +330,41 @@ func (m *Model) SetDataSetStyles(n string, ls runes.LineStyle, s lipgloss.Style)
 	ds.Style = s
 }
 
+// SetDataSetVisible will set whether the data set given by name string
+// is drawn.  While hidden data sets are excluded from automatic range
+// adjustment, showing a data set adjusts the ranges to its data values
+// and hiding a data set resets the automatically adjusted Y values to
+// the data values of the visible data sets.
+// Y values are fitted to the visible data sets if auto fit is enabled.
+func (m *Model) SetDataSetVisible(n string, b bool) {
+	ds := m.getDataSet(n)
+	if !m.DataSetVisibility.SetDataSetVisible(n, b) {
+		return
+	}
+	defer m.fitYRange()
+	if !m.ExcludeHiddenRange() {
+		return
+	}
+	adjusted := false
+	if b {
+		for _, f := range ds.sBuf.ReadAllRaw() {
+			adjusted = m.AutoAdjustRange(canvas.Float64Point{X: m.MinX(), Y: f}) || adjusted
+		}
+	} else {
+		adjusted = m.ResetAutoYRange(m.visibleYRange())
+	}
+	if adjusted {
+		m.UpdateGraphSizes()
+		m.rescaleData()
+	}
+}
+
+// ToggleDataSetVisible will hide the data set given by
+// name string if it is drawn, and show it otherwise.
+func (m *Model) ToggleDataSetVisible(n string) {
+	m.SetDataSetVisible(n, !m.DataSetVisible(n))
+}
+
 // Push will push a float64 Y data value to the default data set
 // to be displayed with Draw.
 func (m *Model) Push(f float64) {
@@ -179,16 +373,16 @@ func (m *Model) Push(f float64) {
 
 // Push will push a float64 Y data value to a data set
 // to be displayed with Draw. Using given data set by name string.
+// NaN and infinite data values are displayed as gaps in the line.
 func (m *Model) PushDataSet(n string, f float64) {
+	ds := m.getDataSet(n)
 	// auto adjust x and y ranges if enabled
-	if m.AutoAdjustRange(canvas.Float64Point{X: m.MinX(), Y: f}) {
+	if m.DataSetAdjustsRange(n) && m.AutoAdjustRange(canvas.Float64Point{X: m.MinX(), Y: f}) {
 		m.UpdateGraphSizes()
 		m.rescaleData()
 	}
-	if _, ok := m.dSets[n]; !ok {
-		m.dSets[n] = m.newDataSet()
-	}
-	m.dSets[n].sBuf.Push(f)
+	ds.sBuf.Push(f)
+	m.fitPending = true
 }
 
 // Draw will draw lines runes displayed from right to left
@@ -197,36 +391,45 @@ func (m *Model) Draw() {
 	m.DrawDataSets([]string{DefaultDataSetName})
 }
 
-// DrawAll will draw lines runes for all data sets from right
-// to left of the graphing area of the canvas.
+// DrawAll will draw lines runes for all visible data sets from right
+// to left of the graphing area of the canvas in z-order.
 func (m *Model) DrawAll() {
 	names := make([]string, 0, len(m.dSets))
 	for n, ds := range m.dSets {
-		if ds.sBuf.Length() > 0 {
+		if (ds.sBuf.Length() > 0) && m.DataSetVisible(n) {
 			names = append(names, n)
 		}
 	}
-	sort.Strings(names)
+	m.SortDrawOrder(names)
 	m.DrawDataSets(names)
 }
 
 // DrawDataSets will draw lines runes from right to left
-// of the graphing area of the canvas for each data set given
-// by name strings.
+// of the graphing area of the canvas for each visible data set
+// given by name strings in order.
 func (m *Model) DrawDataSets(names []string) {
 	if len(names) == 0 {
 		return
 	}
+	m.fitPushed()
 	m.Clear()
 	m.DrawXYAxisAndLabel()
 	for _, n := range names {
-		if ds, ok := m.dSets[n]; ok {
+		if ds, ok := m.dSets[n]; ok && m.DataSetVisible(n) {
 			s := ds.sBuf.ReadAll()
 			startX := m.Canvas.Width() - len(s)
 			// round float64 data value to nearest integer to fit onto the canvas
+			// and break lines at NaN and infinite data values
 			l := make([]int, 0, len(s))
+			gaps := make([]bool, 0, len(s))
 			for _, v := range s {
-				l = append(l, int(math.Round(v)))
+				if math.IsNaN(v) || math.IsInf(v, 0) {
+					l = append(l, 0)
+					gaps = append(gaps, true)
+				} else {
+					l = append(l, int(math.Round(v)))
+					gaps = append(gaps, false)
+				}
 			}
 			// convert to canvas coordinates and avoid drawing below X axis
 			yCoords := canvas.CanvasYCoordinates(m.Origin().Y, l)
@@ -237,23 +440,75 @@ func (m *Model) DrawDataSets(names []string) {
 					}
 				}
 			}
-			graph.DrawLineSequence(&m.Canvas,
+			graph.DrawLineSequenceWithGaps(&m.Canvas,
 				(startX == m.Origin().X),
 				startX,
 				yCoords,
+				gaps,
 				ds.LineStyle,
 				ds.Style)
 		}
 	}
 }
 
+// LegendEntries returns a legend entry with a line marker for each
+// data set containing data, sorted by name.
+func (m *Model) LegendEntries() []legend.Entry {
+	names := make([]string, 0, len(m.dSets))
+	for n, ds := range m.dSets {
+		if ds.sBuf.Length() > 0 {
+			names = append(names, n)
+		}
+	}
+	sort.Strings(names)
+	r := make([]legend.Entry, 0, len(names))
+	for _, n := range names {
+		ds := m.dSets[n]
+		r = append(r, legend.Entry{
+			Name:    n,
+			Marker:  legend.LineMarker(ds.LineStyle),
+			Style:   ds.Style,
+			DataSet: n,
+			Hidden:  !m.DataSetVisible(n),
+		})
+	}
+	return r
+}
+
+// DrawHover draws the crosshair and a tooltip of the data value of each
+// visible data set in the column under the mouse if the mouse is over
+// the graphing area.  Should be called after drawing data to overlay
+// the graphing area.
+func (m *Model) DrawHover() {
+	p, ok := m.HoverPosition()
+	if !ok {
+		return
+	}
+	m.DrawCrosshair()
+	var lines []string
+	for _, n := range m.DataSetNames() {
+		ds := m.dSets[n]
+		i := p.X - (m.Canvas.Width() - ds.sBuf.Length()) // values end at the right of the canvas
+		if !m.DataSetVisible(n) || (i < 0) || (i >= ds.sBuf.Length()) {
+			continue
+		}
+		lines = append(lines, n+": "+m.HoverYFormatter(0, ds.sBuf.AtRaw(i)))
+	}
+	m.DrawTooltip(lines)
+}
+
 // Update processes bubbletea Msg to by invoking
 // UpdateHandlerFunc callback if linechart is focused.
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	if !m.Focused() {
 		return m, nil
 	}
-	m.UpdateHandler(&m.Model, msg)
+	m.UpdateHover(msg)
+	if !m.UpdateCursor(msg) { // data cursor keys take precedence
+		m.UpdateHandler(&m.Model, msg)
+	}
 	m.rescaleData()
+	m.fitYRange()
+	m.SyncLinkGroup()
 	return m, nil
 }
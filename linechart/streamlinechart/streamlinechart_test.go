@@ -0,0 +1,93 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package streamlinechart
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/NimbleMarkets/ntcharts/canvas/runes"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestDataSets(t *testing.T) {
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color("4"))
+	m := New(20, 10, WithDataSetStyles("b", runes.ThinLineStyle, style))
+	m.Push(1)
+	m.Push(2)
+	m.PushDataSet("b", 3)
+	if s := strings.Join(m.DataSetNames(), ","); s != "b,default" {
+		t.Errorf("wrong data set names:%s", s)
+	}
+	if !m.HasDataSet("b") || m.HasDataSet("c") {
+		t.Error("wrong existing data sets")
+	}
+	if (m.DataSetLen(DefaultDataSetName) != 2) || (m.DataSetLen("b") != 1) || (m.DataSetLen("c") != 0) {
+		t.Errorf("wrong data set lengths:%d %d", m.DataSetLen(DefaultDataSetName), m.DataSetLen("b"))
+	}
+	if vs := m.Values(); (len(vs) != 2) || (vs[0] != 1) || (vs[1] != 2) {
+		t.Errorf("wrong values:%v", vs)
+	}
+	if ls, s := m.DataSetStyles("b"); (ls != runes.ThinLineStyle) || (s.GetForeground() != style.GetForeground()) {
+		t.Error("wrong data set styles")
+	}
+	if ls, _ := m.DataSetStyles("c"); ls != runes.ArcLineStyle {
+		t.Error("expected default styles of missing data set")
+	}
+
+	// renaming keeps data, styles and visibility
+	m.SetDataSetVisible("b", false)
+	m.SetDataSetZOrder("b", 2)
+	if m.RenameDataSet("b", DefaultDataSetName) || m.RenameDataSet("c", "d") {
+		t.Error("expected renaming to existing or from missing data sets to fail")
+	}
+	if !m.RenameDataSet("b", "c") {
+		t.Fatal("expected renaming to succeed")
+	}
+	if m.HasDataSet("b") || (m.DataSetLen("c") != 1) {
+		t.Error("data set not renamed")
+	}
+	if ls, _ := m.DataSetStyles("c"); (ls != runes.ThinLineStyle) || m.DataSetVisible("c") || (m.DataSetZOrder("c") != 2) {
+		t.Error("renamed data set settings not kept")
+	}
+	if !m.DataSetVisible("b") || (m.DataSetZOrder("b") != 0) {
+		t.Error("old name settings not reset")
+	}
+	if !m.RenameDataSet(DefaultDataSetName, "a") || (m.DataSetLen(DefaultDataSetName) != 0) || (m.DataSetLen("a") != 2) {
+		t.Error("expected renaming default data set to leave empty default data set")
+	}
+
+	// removing deletes data, styles and visibility
+	m.RemoveDataSet("c")
+	if m.HasDataSet("c") || !m.DataSetVisible("c") {
+		t.Error("data set not removed")
+	}
+	m.PushDataSet("c", 5)
+	if ls, _ := m.DataSetStyles("c"); ls != runes.ArcLineStyle {
+		t.Error("expected recreated data set with default styles")
+	}
+	m.RemoveDataSet(DefaultDataSetName)
+	if !m.HasDataSet(DefaultDataSetName) {
+		t.Error("expected default data set to be replaced")
+	}
+}
+
+func TestExcludeHiddenRange(t *testing.T) {
+	m := New(20, 10, WithExcludeHiddenRange())
+	for i := 0; i < 5; i++ {
+		m.Push(float64(2 + 2*i))
+		m.PushDataSet("b", float64(-50+50*i))
+	}
+	if (m.MinY() != -50) || (m.MaxY() != 150) {
+		t.Fatalf("wrong Y range:%f %f", m.MinY(), m.MaxY())
+	}
+	m.SetDataSetVisible("b", false)
+	if (m.MinY() != 2) || (m.MaxY() != 10) {
+		t.Errorf("Y range not reset to visible data sets:%f %f", m.MinY(), m.MaxY())
+	}
+	m.ToggleDataSetVisible("b")
+	if (m.MinY() != -50) || (m.MaxY() != 150) {
+		t.Errorf("Y range not adjusted to shown data set:%f %f", m.MinY(), m.MaxY())
+	}
+}
@@ -0,0 +1,216 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package linechart
+
+// File contains tick locators used to place axis labels at chosen data values
+// instead of every number of steps along the axis.
+// Ticks are located in the transformed space of the axis.
+
+// https://en.wikipedia.org/wiki/Nice_number
+
+import (
+	"math"
+
+	"github.com/NimbleMarkets/ntcharts/canvas"
+	"github.com/NimbleMarkets/ntcharts/canvas/runes"
+)
+
+// TickLocator returns an increasing sequence of at most n
+// tick values between given minimum and maximum values inclusive.
+type TickLocator func(min, max float64, n int) []float64
+
+// NiceTicks is a TickLocator returning multiples of a "nice" step
+// of 1, 2 or 5 times a power of 10 between given minimum and maximum values.
+// The smallest step resulting in at most n ticks is used.
+func NiceTicks(min, max float64, n int) []float64 {
+	step := niceStep(min, max, n)
+	if step <= 0 {
+		return []float64{}
+	}
+	r := []float64{}
+	start := math.Ceil(min / step)
+	for i := start; i*step <= max+step*1e-9; i++ {
+		v := i * step
+		if v == 0 { // avoid negative zero
+			v = 0
+		}
+		r = append(r, v)
+	}
+	return r
+}
+
+// niceStep returns the smallest 1, 2 or 5 times a power of 10
+// step between ticks resulting in at most n ticks between
+// given minimum and maximum values.  Returns 0 if there is no step.
+func niceStep(min, max float64, n int) float64 {
+	if (n < 1) || !(max > min) || math.IsInf(max-min, 0) {
+		return 0
+	}
+	if n == 1 {
+		n = 2 // single tick still requires step size of the whole range
+	}
+	raw := (max - min) / float64(n-1)
+	exp := math.Pow(10, math.Floor(math.Log10(raw)))
+	for _, f := range []float64{1, 2, 5, 10} {
+		step := f * exp
+		if math.Floor(max/step)-math.Ceil(min/step)+1 <= float64(n) {
+			return step
+		}
+	}
+	return 20 * exp
+}
+
+// minorTicks returns n-1 evenly spaced minor tick values between
+// each pair of major tick values, including values before the first
+// and after the last major tick within given minimum and maximum values.
+func minorTicks(major []float64, min, max float64, n int) []float64 {
+	r := []float64{}
+	if (n < 2) || (len(major) < 2) {
+		return r
+	}
+	step := (major[1] - major[0]) / float64(n)
+	start := major[0] - step*float64(n-1)
+	end := major[len(major)-1] + step*float64(n-1)
+	for i := 0; start+step*float64(i) <= end+step*1e-9; i++ {
+		if i%n == n-1 { // skip major ticks
+			continue
+		}
+		v := start + step*float64(i)
+		if (v >= min) && (v <= max) {
+			r = append(r, v)
+		}
+	}
+	return r
+}
+
+// axisTick contains an axis tick value and offset from the origin of the axis.
+type axisTick struct {
+	value float64
+	pos   int
+}
+
+// locateTicks returns axis ticks of values between given minimum and maximum data values
+// and their offsets on an axis of given size using given AxisTransform and TickLocator.
+// Ticks are located using at most n ticks, and n minor ticks between major ticks.
+func locateTicks(min, max float64, size, n int, at AxisTransform, loc TickLocator, minor int) (major []axisTick, minors []axisTick) {
+	tMin := at.Forward(min)
+	tMax := at.Forward(max)
+	if !(tMax > tMin) || (size <= 0) {
+		return
+	}
+	scale := float64(size) / (tMax - tMin)
+	toTicks := func(values []float64) (r []axisTick) {
+		for _, t := range values {
+			r = append(r, axisTick{
+				value: at.Inverse(t),
+				pos:   int(math.Round((t - tMin) * scale)),
+			})
+		}
+		return
+	}
+	values := loc(tMin, tMax, n)
+	major = toTicks(values)
+	minors = toTicks(minorTicks(values, tMin, tMax, minor))
+	return
+}
+
+// yTicks returns the major and minor Y axis ticks of the displayed Y values
+// for a graph of given height.  One tick is located every other row.
+func yTicks(minY, maxY float64, gHeight int, yt AxisTransform, loc TickLocator, minor int) ([]axisTick, []axisTick) {
+	return locateTicks(minY, maxY, gHeight, gHeight/2+1, yt, loc, minor)
+}
+
+// xTicks returns the major and minor X axis ticks of the displayed X values
+// such that formatted X labels do not overlap.
+func (m *Model) xTicks() (major []axisTick, minors []axisTick) {
+	xt := m.XTransform()
+	// reduce number of ticks until labels fit with a space between them
+	for n := m.graphWidth/2 + 1; n > 1; n-- {
+		major, minors = locateTicks(m.viewMinX, m.viewMaxX, m.graphWidth, n, xt, m.xTickLocator, m.xMinorTicks)
+		fit := true
+		end := -1
+		for i, t := range major {
+			if t.pos <= end {
+				fit = false
+				break
+			}
+			end = t.pos + len(m.XLabelFormatter(i, t.value))
+		}
+		if fit {
+			return
+		}
+	}
+	return
+}
+
+// drawYTicks draws Y axis tick values left of the Y axis and
+// tick marks on the Y axis using the Y TickLocator.
+func (m *Model) drawYTicks() {
+	major, minors := yTicks(m.viewMinY, m.viewMaxY, m.graphHeight, m.YTransform(), m.yTickLocator, m.yMinorTicks)
+	for _, t := range minors {
+		m.drawYTickMark(t.pos)
+	}
+	for i, t := range major {
+		m.drawYTickMark(t.pos)
+		s := m.YLabelFormatter(i, t.value)
+		m.Canvas.SetStringWithStyle(canvas.Point{X: m.origin.X - len(s), Y: m.origin.Y - t.pos}, s, m.LabelStyle)
+	}
+}
+
+// drawYTickMark draws a tick mark on the Y axis at given offset from the origin.
+func (m *Model) drawYTickMark(pos int) {
+	p := canvas.Point{X: m.origin.X, Y: m.origin.Y - pos}
+	if m.Canvas.Cell(p).Rune == runes.LineVertical {
+		m.Canvas.SetCell(p, canvas.NewCellWithStyle(runes.LineVerticalLeft, m.AxisStyle))
+	}
+}
+
+// drawXTicks draws X axis tick values below the X axis and
+// tick marks on the X axis using the X TickLocator.
+// Labels that would be cut off are not displayed.
+func (m *Model) drawXTicks() {
+	major, minors := m.xTicks()
+	for _, t := range minors {
+		m.drawXTickMark(t.pos)
+	}
+	for i, t := range major {
+		m.drawXTickMark(t.pos)
+		s := m.XLabelFormatter(i, t.value)
+		if len(s)+m.origin.X+t.pos <= m.Canvas.Width() {
+			m.Canvas.SetStringWithStyle(canvas.Point{X: m.origin.X + t.pos, Y: m.origin.Y + 1}, s, m.LabelStyle)
+		}
+	}
+}
+
+// drawXTickMark draws a tick mark on the X axis at given offset from the origin.
+func (m *Model) drawXTickMark(pos int) {
+	p := canvas.Point{X: m.origin.X + pos, Y: m.origin.Y}
+	if m.Canvas.Cell(p).Rune == runes.LineHorizontal {
+		m.Canvas.SetCell(p, canvas.NewCellWithStyle(runes.LineHorizontalDown, m.AxisStyle))
+	}
+}
+
+// SetXTickLocator sets the TickLocator used to place X axis values.
+// If nil, then X axis values are placed every number of X steps.
+func (m *Model) SetXTickLocator(l TickLocator) {
+	m.xTickLocator = l
+}
+
+// SetYTickLocator sets the TickLocator used to place Y axis values.
+// If nil, then Y axis values are placed every number of Y steps.
+func (m *Model) SetYTickLocator(l TickLocator) {
+	m.yTickLocator = l
+	m.UpdateGraphSizes()
+}
+
+// SetXMinorTicks sets the number of minor tick intervals between
+// X axis ticks placed by the X TickLocator. Disabled if less than 2.
+func (m *Model) SetXMinorTicks(n int) {
+	m.xMinorTicks = n
+}
+
+// SetYMinorTicks sets the number of minor tick intervals between
+// Y axis ticks placed by the Y TickLocator. Disabled if less than 2.
+func (m *Model) SetYMinorTicks(n int) {
+	m.yMinorTicks = n
+}
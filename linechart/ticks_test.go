@@ -0,0 +1,72 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package linechart
+
+import (
+	"math"
+	"testing"
+
+	"github.com/NimbleMarkets/ntcharts/canvas"
+)
+
+func TestNiceTicks(t *testing.T) {
+	tests := []struct {
+		min, max float64
+		n        int
+		expected []float64
+	}{
+		{0, 10, 6, []float64{0, 2, 4, 6, 8, 10}},
+		{0, 10, 11, []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}},
+		{-3.7, 47.3, 6, []float64{0, 10, 20, 30, 40}},
+		{0.13, 0.91, 5, []float64{0.2, 0.4, 0.6, 0.8}},
+		{-1, 1, 3, []float64{-1, 0, 1}},
+		{5, 5, 3, []float64{}},
+	}
+	for _, tc := range tests {
+		r := NiceTicks(tc.min, tc.max, tc.n)
+		if len(r) != len(tc.expected) {
+			t.Errorf("NiceTicks(%f,%f,%d) returned %v, expected %v", tc.min, tc.max, tc.n, r, tc.expected)
+			continue
+		}
+		for i, v := range r {
+			if math.Abs(v-tc.expected[i]) > 1e-9 {
+				t.Errorf("NiceTicks(%f,%f,%d) returned %v, expected %v", tc.min, tc.max, tc.n, r, tc.expected)
+				break
+			}
+		}
+	}
+}
+
+func TestMinorTicks(t *testing.T) {
+	r := minorTicks([]float64{2, 4, 6}, 1, 7, 2)
+	expected := []float64{1, 3, 5, 7}
+	if len(r) != len(expected) {
+		t.Fatalf("minorTicks returned %v, expected %v", r, expected)
+	}
+	for i, v := range r {
+		if math.Abs(v-expected[i]) > 1e-9 {
+			t.Errorf("minorTicks returned %v, expected %v", r, expected)
+		}
+	}
+}
+
+func TestTickLabels(t *testing.T) {
+	lc := New(30, 12, 0, 100, 0, 100,
+		WithXYSteps(1, 1),
+		WithXYTickLocators(NiceTicks, NiceTicks))
+	lc.DrawXYAxisAndLabel()
+	// Y labels are at exact rows of tick values
+	gh := lc.GraphHeight()
+	for _, v := range []float64{0, 20, 40, 60, 80, 100} {
+		row := lc.Origin().Y - int(math.Round(v*float64(gh)/100))
+		found := false
+		for x := 0; x < lc.Origin().X; x++ {
+			if lc.Canvas.Cell(canvas.Point{X: x, Y: row}).Rune != 0 {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("missing Y label for %f at row %d", v, row)
+		}
+	}
+}
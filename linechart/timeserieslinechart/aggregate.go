@@ -0,0 +1,140 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package timeserieslinechart
+
+// File contains methods of combining TimePoints mapped to the same graph column.
+
+import (
+	"math"
+
+	"github.com/NimbleMarkets/ntcharts/canvas"
+)
+
+// Aggregation is the method used to combine the values
+// of multiple TimePoints mapped to the same graph column.
+type Aggregation int
+
+const (
+	// AggregateDefault draws line runes using the average of all values
+	// of lines drawn through each column, and draws braille runes
+	// using lines drawn through every TimePoint.
+	AggregateDefault Aggregation = iota
+	// AggregateAverage uses the average value of TimePoints in each column.
+	AggregateAverage
+	// AggregateLast uses the value of the last TimePoint in each column.
+	AggregateLast
+	// AggregateMin uses the minimum value of TimePoints in each column.
+	AggregateMin
+	// AggregateMax uses the maximum value of TimePoints in each column.
+	AggregateMax
+	// AggregateSum uses the sum of values of TimePoints in each column.
+	// Sums are not used when automatically adjusting the Y value range.
+	AggregateSum
+	// AggregateSpan draws the full range from the minimum to
+	// the maximum value of TimePoints in each column.
+	AggregateSpan
+)
+
+// columnAggregate tracks the scaled data points mapped to a graph column.
+type columnAggregate struct {
+	col      int     // graph column
+	x        float64 // X value of last data point
+	count    int
+	sum      float64
+	min      float64
+	max      float64
+	last     float64
+	minFirst bool // whether minimum value occurs before maximum value
+}
+
+// add includes given scaled data point in the column aggregate.
+func (a *columnAggregate) add(f canvas.Float64Point) {
+	if a.count == 0 {
+		a.min = f.Y
+		a.max = f.Y
+		a.minFirst = true
+	}
+	if f.Y < a.min {
+		a.min = f.Y
+		a.minFirst = false
+	}
+	if f.Y > a.max {
+		a.max = f.Y
+		a.minFirst = true
+	}
+	a.count++
+	a.sum += f.Y
+	a.last = f.Y
+	a.x = f.X
+}
+
+// value returns the scaled aggregated value of the column.
+// Scaled values are offset by the scaled Y offset of the data set,
+// which is needed to add scaled values together.
+// AggregateSpan and AggregateDefault return the last value.
+func (a *columnAggregate) value(agg Aggregation, offset float64) float64 {
+	switch agg {
+	case AggregateAverage:
+		return a.sum / float64(a.count)
+	case AggregateMin:
+		return a.min
+	case AggregateMax:
+		return a.max
+	case AggregateSum:
+		return a.sum + float64(a.count-1)*offset
+	default:
+		return a.last
+	}
+}
+
+// points returns the scaled data points representing the column
+// for drawing lines through each column.
+func (a *columnAggregate) points(agg Aggregation, offset float64) []canvas.Float64Point {
+	if agg != AggregateSpan {
+		return []canvas.Float64Point{{X: a.x, Y: a.value(agg, offset)}}
+	}
+	if a.minFirst {
+		return []canvas.Float64Point{{X: a.x, Y: a.min}, {X: a.x, Y: a.max}}
+	}
+	return []canvas.Float64Point{{X: a.x, Y: a.max}, {X: a.x, Y: a.min}}
+}
+
+// aggregateColumns returns the aggregates of scaled data points
+// in chronological order grouped by graph column given by colFn.
+func aggregateColumns(points []canvas.Float64Point, colFn func(canvas.Float64Point) int) []columnAggregate {
+	r := []columnAggregate{}
+	for _, f := range points {
+		c := colFn(f)
+		if (len(r) == 0) || (r[len(r)-1].col != c) {
+			r = append(r, columnAggregate{col: c})
+		}
+		r[len(r)-1].add(f)
+	}
+	return r
+}
+
+// aggregateLineSequence returns a sequence of Y values of given width
+// to draw line runes from the aggregates of each graph column.
+// Columns without data points are interpolated from neighboring columns.
+func aggregateLineSequence(cols []columnAggregate, width int, agg Aggregation, offset float64) []int {
+	r := make([]int, width)
+	for i, c := range cols {
+		y := c.value(agg, offset)
+		if (c.col >= 0) && (c.col < width) {
+			r[c.col] = int(math.Round(y))
+		}
+		if i == 0 {
+			continue
+		}
+		// interpolate columns between previous and current column
+		p := cols[i-1]
+		py := p.value(agg, offset)
+		for x := p.col + 1; x < c.col; x++ {
+			if (x >= 0) && (x < width) {
+				t := float64(x-p.col) / float64(c.col-p.col)
+				r[x] = int(math.Round(py + (y-py)*t))
+			}
+		}
+	}
+	return r
+}
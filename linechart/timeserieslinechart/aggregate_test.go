@@ -0,0 +1,107 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package timeserieslinechart
+
+import (
+	"math"
+	"testing"
+
+	"github.com/NimbleMarkets/ntcharts/canvas"
+	"github.com/NimbleMarkets/ntcharts/canvas/runes"
+)
+
+func TestColumnAggregate(t *testing.T) {
+	rising := []float64{2, 8, 4}  // minimum before maximum
+	falling := []float64{8, 2, 4} // maximum before minimum
+	tests := []struct {
+		name   string
+		ys     []float64
+		agg    Aggregation
+		offset float64
+		want   []float64 // Y values of points
+	}{
+		{"average", rising, AggregateAverage, 0, []float64{14.0 / 3}},
+		{"last", rising, AggregateLast, 0, []float64{4}},
+		{"min", rising, AggregateMin, 0, []float64{2}},
+		{"max", rising, AggregateMax, 0, []float64{8}},
+		{"sum", rising, AggregateSum, 0, []float64{14}},
+		{"sum offset", rising, AggregateSum, 1.5, []float64{17}},
+		{"default", rising, AggregateDefault, 0, []float64{4}},
+		{"span rising", rising, AggregateSpan, 0, []float64{2, 8}},
+		{"span falling", falling, AggregateSpan, 0, []float64{8, 2}},
+	}
+	for _, tc := range tests {
+		var a columnAggregate
+		for i, y := range tc.ys {
+			a.add(canvas.Float64Point{X: float64(i) / 10, Y: y})
+		}
+		ps := a.points(tc.agg, tc.offset)
+		if len(ps) != len(tc.want) {
+			t.Errorf("%s: wrong number of points:%v", tc.name, ps)
+			continue
+		}
+		for i, p := range ps {
+			if (math.Abs(p.Y-tc.want[i]) > 1e-9) || (p.X != 0.2) {
+				t.Errorf("%s: wrong point %d:%v expected Y %f", tc.name, i, p, tc.want[i])
+			}
+		}
+	}
+}
+
+func TestAggregateLineSequence(t *testing.T) {
+	points := []canvas.Float64Point{
+		{X: 0, Y: 1}, {X: 0.2, Y: 3}, // column 0
+		{X: 3, Y: 7},                   // column 3, columns 1 and 2 interpolated
+		{X: 4.4, Y: 9}, {X: 3.6, Y: 5}, // column 4
+	}
+	cols := aggregateColumns(points, func(f canvas.Float64Point) int {
+		return int(math.Round(f.X))
+	})
+	if len(cols) != 3 {
+		t.Fatalf("wrong number of columns:%d", len(cols))
+	}
+	got := aggregateLineSequence(cols, 6, AggregateMax, 0)
+	want := []int{3, 4, 6, 7, 9, 0}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("wrong line sequence:%v expected %v", got, want)
+			break
+		}
+	}
+}
+
+func TestAggregateSumOffset(t *testing.T) {
+	// a Y range not starting at 0 offsets scaled values
+	m := New(22, 12, WithYRange(2, 12), WithTimeRange(testTime, testTime.Add(100e9)),
+		WithAggregation(AggregateSum))
+	m.Push(testTimePoint(50, 3))
+	m.Push(TimePoint{Time: testTime.Add(50100e6), Value: 4})
+	ds := m.dSets[DefaultDataSetName]
+	if ds.scaledYOffset() == 0 {
+		t.Fatal("expected scaled Y offset")
+	}
+	seq := m.getLineSequence(ds.tBuf.ReadAll(), AggregateSum, ds.scaledYOffset())
+	col := int(math.Round(ds.tBuf.At(0).X))
+	want := int(math.Round(ds.tBuf.ScaleDatum(canvas.Float64Point{X: 0, Y: 7}).Y))
+	if seq[col] != want {
+		t.Errorf("wrong sum in column %d:%d expected %d", col, seq[col], want)
+	}
+}
+
+func TestDrawSpans(t *testing.T) {
+	m := New(12, 11, WithXYSteps(0, 0), WithYRange(0, 10),
+		WithTimeRange(testTime, testTime.Add(10e9)),
+		WithAggregation(AggregateSpan), WithLineStyle(runes.ThinLineStyle))
+	m.Push(TimePoint{Time: testTime.Add(5e9), Value: 2})
+	m.Push(TimePoint{Time: testTime.Add(5100e6), Value: 8})
+	m.Push(TimePoint{Time: testTime.Add(5200e6), Value: 5})
+	m.DrawAll()
+	ds := m.dSets[DefaultDataSetName]
+	x := m.Canvas.Width() - (m.Width() - m.Origin().X) + int(math.Round(ds.tBuf.At(0).X))
+	for v := 2; v <= 8; v++ {
+		y := canvas.CanvasYCoordinate(m.Origin().Y, int(math.Round(float64(v)*ds.tBuf.Scale().Y)))
+		if r := m.Canvas.Cell(canvas.Point{X: x, Y: y}).Rune; !runes.IsLine(r) {
+			t.Errorf("span missing value %d at %d,%d:%q\n%s", v, x, y, r, m.View())
+		}
+	}
+}
@@ -0,0 +1,177 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package timeserieslinechart
+
+// File contains the keyboard data cursor of the timeserieslinechart
+// moving between the TimePoints of the visible data sets.
+
+import (
+	"slices"
+
+	"github.com/NimbleMarkets/ntcharts/canvas"
+	"github.com/NimbleMarkets/ntcharts/linechart"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// UpdateCursor processes bubbletea key Msg matching the CursorKeyMap to
+// show, hide and move the data cursor, and returns whether the Msg was
+// used by the data cursor.  Called by Update before the UpdateHandler, and
+// should be called for charts receiving messages without Update.
+func (m *Model) UpdateCursor(tm tea.Msg) bool {
+	return m.UpdateDataCursor(m, tm)
+}
+
+// CursorDataSet returns the name of the data set of the data cursor.
+func (m *Model) CursorDataSet() string {
+	m.cursorPoint()
+	return m.cursorSet
+}
+
+// SetCursorDataSet moves the data cursor to the nearest
+// TimePoint of the data set given by name string.
+func (m *Model) SetCursorDataSet(n string) {
+	m.cursorSet = n
+	m.cursorFollow()
+}
+
+// CursorNextDataSet moves the data cursor to the nearest TimePoint
+// of the visible data set given number of data sets after the
+// data set of the data cursor by name, or before if negative.
+func (m *Model) CursorNextDataSet(d int) {
+	linechart.NextCursorDataSet(m, d)
+}
+
+// CursorNext moves the data cursor to the next TimePoint of its data set.
+func (m *Model) CursorNext() {
+	m.cursorStep(1)
+}
+
+// CursorPrev moves the data cursor to the previous TimePoint of its data set.
+func (m *Model) CursorPrev() {
+	m.cursorStep(-1)
+}
+
+// CursorTimePoint returns the TimePoint of the data cursor
+// and whether there is a TimePoint to display.
+func (m *Model) CursorTimePoint() (TimePoint, bool) {
+	ds, i, ok := m.cursorPoint()
+	if !ok {
+		return TimePoint{}, false
+	}
+	f := ds.tBuf.AtRaw(i)
+	return TimePoint{Time: m.XTime(f.X), Value: f.Y}, true
+}
+
+// CursorReadout returns lines of text displaying the data set name,
+// time, value and difference from the previous value of the data cursor.
+func (m *Model) CursorReadout() []string {
+	ds, i, ok := m.cursorPoint()
+	if !ok {
+		return nil
+	}
+	f := ds.tBuf.AtRaw(i)
+	var prev float64
+	j, hasPrev := ds.validStep(i, -1)
+	if hasPrev {
+		prev = ds.tBuf.AtRaw(j).Y
+	}
+	lines := []string{
+		m.XTime(f.X).In(m.Location()).Format(m.hoverLayout),
+		"V: " + m.HoverYFormatter(0, f.Y),
+	}
+	return m.FormatCursorReadout(m.cursorSet, lines, f.Y, prev, hasPrev)
+}
+
+// DrawCursor draws the data cursor and its readout if the data cursor
+// is shown.  Should be called after drawing data to overlay the graphing area.
+func (m *Model) DrawCursor() {
+	if !m.Cursor() {
+		return
+	}
+	ds, i, ok := m.cursorPoint()
+	if !ok {
+		return
+	}
+	sf := m.ScaleFloat64PointForLine(ds.tBuf.AtRaw(i))
+	m.DrawCursorAt(canvas.CanvasPointFromFloat64Point(m.Origin(), sf), m.CursorReadout())
+}
+
+// CursorDataSets returns the names of visible data sets containing
+// TimePoints, which the data cursor can be placed on, sorted by name.
+func (m *Model) CursorDataSets() []string {
+	names := m.DataSetNames()
+	r := names[:0]
+	for _, n := range names {
+		if m.DataSetVisible(n) && (m.dSets[n].tBuf.Length() > 0) {
+			r = append(r, n)
+		}
+	}
+	return r
+}
+
+// cursorPoint returns the data set and index of the TimePoint of the data
+// cursor, and whether there is such a TimePoint.  The data cursor is placed
+// on the TimePoint nearest to its time, or to the maximum displayed time if
+// not placed, of its data set or of the first visible data set.
+func (m *Model) cursorPoint() (*dataSet, int, bool) {
+	names := m.CursorDataSets()
+	if len(names) == 0 {
+		return nil, 0, false
+	}
+	if !slices.Contains(names, m.cursorSet) {
+		m.cursorSet = names[0]
+	}
+	if !m.cursorPlaced {
+		m.cursorX = m.ViewMaxX()
+	}
+	ds := m.dSets[m.cursorSet]
+	i, ok := ds.nearest(m.cursorX)
+	if ok {
+		m.cursorX = ds.tBuf.AtRaw(i).X
+		m.cursorPlaced = true
+	}
+	return ds, i, ok
+}
+
+// cursorStep moves the data cursor to the next TimePoint of its data set
+// in given direction ignoring NaN and infinite values.
+func (m *Model) cursorStep(d int) {
+	ds, i, ok := m.cursorPoint()
+	if !ok {
+		return
+	}
+	if j, ok := ds.validStep(i, d); ok {
+		m.cursorX = ds.tBuf.AtRaw(j).X
+	}
+	m.cursorFollow()
+}
+
+// cursorFollow moves the displayed time range
+// to display the TimePoint of the data cursor.
+func (m *Model) cursorFollow() {
+	if _, _, ok := m.cursorPoint(); !ok {
+		return
+	}
+	switch {
+	case m.cursorX < m.ViewMinX():
+		m.MoveLeft(m.TransformX(m.ViewMinX()) - m.TransformX(m.cursorX))
+	case m.cursorX > m.ViewMaxX():
+		m.MoveRight(m.TransformX(m.cursorX) - m.TransformX(m.ViewMaxX()))
+	default:
+		return
+	}
+	m.rescaleData()
+	m.fitYRange()
+}
+
+// validStep returns the index of the next TimePoint from given index
+// in given direction with a finite value, and whether there is one.
+func (ds *dataSet) validStep(i, d int) (int, bool) {
+	for i += d; (i >= 0) && (i < ds.tBuf.Length()); i += d {
+		if isFinite(ds.tBuf.AtRaw(i).Y) {
+			return i, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,93 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package timeserieslinechart
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestCursor(t *testing.T) {
+	m := New(20, 10, WithEpoch(testTime))
+	for i, v := range []float64{1, 2, math.NaN(), 5, 3} {
+		m.Push(testTimePoint(i, v))
+		m.PushDataSet("b", testTimePoint(i, float64(10*i)))
+	}
+	m.Focus()
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	if !m.Cursor() {
+		t.Fatal("data cursor not shown")
+	}
+
+	// data cursor starts at the newest TimePoint of the first data set
+	if tp, ok := m.CursorTimePoint(); !ok || !tp.Time.Equal(testTime.Add(4e9)) || (tp.Value != 10*4) {
+		t.Errorf("wrong initial data cursor:%v %s", tp, m.CursorDataSet())
+	}
+
+	// tab and shift+tab cycle through the data sets keeping the time
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if tp, _ := m.CursorTimePoint(); (m.CursorDataSet() != DefaultDataSetName) || (tp.Value != 3) {
+		t.Errorf("wrong data cursor after tab:%s %v", m.CursorDataSet(), tp)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if m.CursorDataSet() != "b" {
+		t.Errorf("tab did not wrap around:%s", m.CursorDataSet())
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	if m.CursorDataSet() != DefaultDataSetName {
+		t.Errorf("wrong data set after shift+tab:%s", m.CursorDataSet())
+	}
+
+	// stepping skips NaN values
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	if tp, _ := m.CursorTimePoint(); tp.Value != 5 {
+		t.Errorf("wrong data cursor after left:%v", tp)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	if tp, _ := m.CursorTimePoint(); !tp.Time.Equal(testTime.Add(1e9)) || (tp.Value != 2) {
+		t.Errorf("data cursor did not skip NaN value:%v", tp)
+	}
+
+	// difference is from the previous finite value
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	r := m.CursorReadout()
+	if (len(r) != 4) || (r[0] != DefaultDataSetName) || !strings.HasPrefix(r[3], "Δ: +3") {
+		t.Errorf("wrong readout:%q", r)
+	}
+	m.CursorPrev()
+	m.CursorPrev()
+	m.CursorPrev()
+	if r := m.CursorReadout(); len(r) != 3 {
+		t.Errorf("expected no difference for first TimePoint:%q", r)
+	}
+}
+
+func TestCursorFollow(t *testing.T) {
+	m := New(20, 10, WithEpoch(testTime))
+	for i := 0; i < 10; i++ {
+		m.Push(testTimePoint(i, float64(i)))
+	}
+	m.SetViewXRange(2, 5)
+	m.SetCursor(true)
+	if tp, _ := m.CursorTimePoint(); tp.Value != 5 {
+		t.Fatalf("wrong initial data cursor:%v", tp)
+	}
+
+	// displayed time range follows the data cursor off the view
+	m.CursorNext()
+	if (m.ViewMinX() != 3) || (m.ViewMaxX() != 6) {
+		t.Errorf("view did not follow data cursor right:%f %f", m.ViewMinX(), m.ViewMaxX())
+	}
+	for i := 0; i < 5; i++ {
+		m.CursorPrev()
+	}
+	if (m.ViewMinX() != 1) || (m.ViewMaxX() != 4) {
+		t.Errorf("view did not follow data cursor left:%f %f", m.ViewMinX(), m.ViewMaxX())
+	}
+	if (m.ViewMinY() > 1) || (m.ViewMaxY() < 4) {
+		t.Errorf("Y values not fitted to followed view:%f %f", m.ViewMinY(), m.ViewMaxY())
+	}
+}
@@ -0,0 +1,113 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package timeserieslinechart
+
+// File contains the hover tooltip of the timeserieslinechart displaying
+// the time under the mouse and the nearest values of each data set.
+
+import (
+	"sort"
+	"time"
+)
+
+// DefaultHoverTimeLayout is the default time layout
+// of hover tooltips and data cursor readouts.
+const DefaultHoverTimeLayout = "2006-01-02 15:04:05"
+
+// SetHoverTimeLayout sets the time layout of the times displayed by hover
+// tooltips and data cursor readouts in the Model time.Location.
+func (m *Model) SetHoverTimeLayout(layout string) {
+	m.hoverLayout = layout
+}
+
+// HoverTimeLayout returns the time layout of hover tooltips and data cursor readouts.
+func (m *Model) HoverTimeLayout() string {
+	return m.hoverLayout
+}
+
+// DrawHover draws the crosshair and a tooltip of the time under the mouse,
+// the nearest candle of each candle set and the nearest TimePoint of each
+// visible data set if the mouse is over the graphing area.
+// Should be called after drawing data to overlay the graphing area.
+func (m *Model) DrawHover() {
+	f, ok := m.HoverPoint()
+	if !ok {
+		return
+	}
+	m.DrawCrosshair()
+	lines := []string{m.XTime(f.X).In(m.Location()).Format(m.hoverLayout)}
+
+	names := make([]string, 0, len(m.cSets))
+	for n, cs := range m.cSets {
+		if len(cs.candles) > 0 {
+			names = append(names, n)
+		}
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		c := m.cSets[n].nearest(m.XTime(f.X))
+		l := "O:" + m.HoverYFormatter(0, c.Open) +
+			" H:" + m.HoverYFormatter(0, c.High) +
+			" L:" + m.HoverYFormatter(0, c.Low) +
+			" C:" + m.HoverYFormatter(0, c.Close)
+		if n != DefaultDataSetName {
+			l = n + ": " + l
+		}
+		lines = append(lines, l)
+	}
+
+	for _, n := range m.DataSetNames() {
+		if !m.DataSetVisible(n) {
+			continue
+		}
+		ds := m.dSets[n]
+		if i, ok := ds.nearest(f.X); ok {
+			lines = append(lines, n+": "+m.HoverYFormatter(0, ds.tBuf.AtRaw(i).Y))
+		}
+	}
+	m.DrawTooltip(lines)
+}
+
+// nearest returns the index of the TimePoint nearest to given X value
+// ignoring NaN and infinite values, and whether there is such a TimePoint.
+func (ds *dataSet) nearest(x float64) (int, bool) {
+	b := ds.tBuf
+	l := b.Length()
+	i := sort.Search(l, func(i int) bool {
+		return b.AtRaw(i).X >= x
+	})
+	// search outwards from the TimePoints on each side of the X value
+	for lo, hi := i-1, i; (lo >= 0) || (hi < l); {
+		var j int
+		switch {
+		case lo < 0:
+			j, hi = hi, hi+1
+		case hi >= l:
+			j, lo = lo, lo-1
+		case (x - b.AtRaw(lo).X) <= (b.AtRaw(hi).X - x):
+			j, lo = lo, lo-1
+		default:
+			j, hi = hi, hi+1
+		}
+		if isFinite(b.AtRaw(j).Y) {
+			return j, true
+		}
+	}
+	return 0, false
+}
+
+// nearest returns the candle nearest to given time, which
+// must not be called on a candle set without candles.
+func (cs *candleSet) nearest(t time.Time) Candle {
+	n := len(cs.candles)
+	i := sort.Search(n, func(i int) bool { return !cs.candles[i].Time.Before(t) })
+	switch {
+	case i == n:
+		return cs.candles[n-1]
+	case i == 0:
+		return cs.candles[0]
+	case t.Sub(cs.candles[i-1].Time) <= cs.candles[i].Time.Sub(t):
+		return cs.candles[i-1]
+	}
+	return cs.candles[i]
+}
@@ -0,0 +1,268 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package timeserieslinechart
+
+// File contains indicators linked to source data sets and candle sets,
+// which push derived TimePoints to output data sets, and bands filling
+// the region between two data sets.
+
+import (
+	"math"
+
+	"github.com/NimbleMarkets/ntcharts/canvas"
+	"github.com/NimbleMarkets/ntcharts/canvas/runes"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// DefaultBandRune is the rune commonly used to fill bands.
+const DefaultBandRune = runes.LightShade
+
+// Indicator computes TimePoints of one or more output data sets incrementally
+// from each TimePoint or candle of a source data set or candle set.
+// Both methods return one TimePoint for each output data set, where NaN
+// values are displayed as gaps such as before enough values are pushed.
+// The indicators package contains common technical indicators.
+type Indicator interface {
+	Push(t TimePoint) []TimePoint
+	PushCandle(c Candle) []TimePoint
+}
+
+// linkedIndicator is an Indicator pushing to output data sets.
+type linkedIndicator struct {
+	ind   Indicator
+	names []string // names of output data sets
+}
+
+// push pushes given TimePoints to the output data sets.
+func (li linkedIndicator) push(m *Model, ts []TimePoint) {
+	for i := 0; (i < len(ts)) && (i < len(li.names)); i++ {
+		if li.names[i] != "" { // output data set removed
+			m.PushDataSet(li.names[i], ts[i])
+		}
+	}
+}
+
+// band fills the region between two data sets.
+type band struct {
+	upper string
+	lower string
+	r     rune
+	style lipgloss.Style
+}
+
+// LinkDataSets links an Indicator to the source data set given by name
+// string, pushing TimePoints of the Indicator to output data sets given
+// by name strings in order whenever a TimePoint is pushed to the source
+// data set. Existing TimePoints of the source data set are pushed to the
+// Indicator immediately.  Output data sets can be sources of other Indicators.
+// TimePoints reaching a data set through a cycle of linked Indicators, such
+// as an output data set linked back to the source data set, are pushed to
+// the data set without being pushed again to its linked Indicators.
+func (m *Model) LinkDataSets(source string, ind Indicator, names ...string) {
+	li := linkedIndicator{ind: ind, names: append([]string{}, names...)}
+	m.links[source] = append(m.links[source], li)
+	if ds, ok := m.dSets[source]; ok {
+		defer m.startPush(source)()
+		for _, f := range ds.tBuf.ReadAllRaw() {
+			li.push(m, ind.Push(TimePoint{Time: m.XTime(f.X), Value: f.Y}))
+		}
+	}
+}
+
+// LinkCandleSet links an Indicator to the candle set given by name string,
+// pushing TimePoints of the Indicator to output data sets given by name
+// strings in order whenever a candle of the source candle set is completed.
+// Candles of candle sets without an interval are completed when pushed,
+// and candles of candle sets with an interval are completed when a candle
+// of a later interval is pushed.  Existing completed candles of the source
+// candle set are pushed to the Indicator immediately.
+func (m *Model) LinkCandleSet(source string, ind Indicator, names ...string) {
+	li := linkedIndicator{ind: ind, names: append([]string{}, names...)}
+	m.cLinks[source] = append(m.cLinks[source], li)
+	if cs, ok := m.cSets[source]; ok {
+		candles := cs.candles
+		if (cs.interval > 0) && (len(candles) > 0) {
+			candles = candles[:len(candles)-1]
+		}
+		for _, c := range candles {
+			li.push(m, ind.PushCandle(c))
+		}
+	}
+}
+
+// UnlinkDataSets removes all Indicators linked to the data set
+// or candle set given by name string.  Output data sets are kept.
+func (m *Model) UnlinkDataSets(source string) {
+	delete(m.links, source)
+	delete(m.cLinks, source)
+}
+
+// renameLinks renames the data set given by old name string to the new name
+// string in the sources and outputs of linked Indicators and in bands.
+func (m *Model) renameLinks(old, new string) {
+	if lis, ok := m.links[old]; ok {
+		delete(m.links, old)
+		m.links[new] = lis
+	}
+	for _, links := range []map[string][]linkedIndicator{m.links, m.cLinks} {
+		for _, lis := range links {
+			for _, li := range lis {
+				for i, n := range li.names {
+					if n == old {
+						li.names[i] = new
+					}
+				}
+			}
+		}
+	}
+	for i, b := range m.bands {
+		if b.upper == old {
+			m.bands[i].upper = new
+		}
+		if b.lower == old {
+			m.bands[i].lower = new
+		}
+	}
+}
+
+// removeLinks removes the data set given by name string from the sources
+// and outputs of linked Indicators and removes bands of the data set.
+// Indicators without remaining output data sets are unlinked.
+func (m *Model) removeLinks(n string) {
+	delete(m.links, n)
+	for _, links := range []map[string][]linkedIndicator{m.links, m.cLinks} {
+		for source, lis := range links {
+			kept := lis[:0]
+			for _, li := range lis {
+				outputs := false
+				for i, o := range li.names {
+					if o == n {
+						li.names[i] = ""
+					} else if o != "" {
+						outputs = true
+					}
+				}
+				if outputs {
+					kept = append(kept, li)
+				}
+			}
+			if len(kept) == 0 {
+				delete(links, source)
+			} else {
+				links[source] = kept
+			}
+		}
+	}
+	bands := m.bands[:0]
+	for _, b := range m.bands {
+		if (b.upper != n) && (b.lower != n) {
+			bands = append(bands, b)
+		}
+	}
+	m.bands = bands
+}
+
+// pushLinks pushes given TimePoint to Indicators linked to the data set
+// given by name string, unless the data set is already being pushed to
+// its linked Indicators through a cycle of linked Indicators.
+func (m *Model) pushLinks(n string, t TimePoint) {
+	if m.inPush[n] || (len(m.links[n]) == 0) {
+		return
+	}
+	defer m.startPush(n)()
+	for _, li := range m.links[n] {
+		li.push(m, li.ind.Push(t))
+	}
+}
+
+// startPush marks the data set given by name string as being pushed
+// to its linked Indicators, and returns a function removing the mark.
+func (m *Model) startPush(n string) func() {
+	if m.inPush == nil {
+		m.inPush = make(map[string]bool)
+	}
+	m.inPush[n] = true
+	return func() { delete(m.inPush, n) }
+}
+
+// pushCandleLinks pushes given completed candle to Indicators
+// linked to the candle set given by name string.
+func (m *Model) pushCandleLinks(n string, c Candle) {
+	for _, li := range m.cLinks[n] {
+		li.push(m, li.ind.PushCandle(c))
+	}
+}
+
+// SetBand sets a band filling the region between the upper and lower
+// data sets given by name strings with given rune and style, such as
+// Bollinger Bands.  Bands are drawn below lines and candles.
+// Replaces an existing band of the same data sets.
+func (m *Model) SetBand(upper, lower string, r rune, s lipgloss.Style) {
+	b := band{upper: upper, lower: lower, r: r, style: s}
+	for i, e := range m.bands {
+		if (e.upper == upper) && (e.lower == lower) {
+			m.bands[i] = b
+			return
+		}
+	}
+	m.bands = append(m.bands, b)
+}
+
+// RemoveBand removes the band between the upper and lower
+// data sets given by name strings.
+func (m *Model) RemoveBand(upper, lower string) {
+	for i, e := range m.bands {
+		if (e.upper == upper) && (e.lower == lower) {
+			m.bands = append(m.bands[:i], m.bands[i+1:]...)
+			return
+		}
+	}
+}
+
+// drawBands fills the region between the visible data sets
+// of each band in columns displaying both data sets.
+func (m *Model) drawBands() {
+	for _, b := range m.bands {
+		upper, uok := m.dSets[b.upper]
+		lower, lok := m.dSets[b.lower]
+		if !uok || !lok || !m.DataSetVisible(b.upper) || !m.DataSetVisible(b.lower) {
+			continue
+		}
+		uSeq, uValid := m.columnSequence(upper)
+		lSeq, lValid := m.columnSequence(lower)
+		startX := m.Canvas.Width() - len(uSeq)
+		for i := range uSeq {
+			if !uValid[i] || !lValid[i] {
+				continue
+			}
+			top := canvas.CanvasYCoordinate(m.Origin().Y, max(uSeq[i], lSeq[i]))
+			bottom := canvas.CanvasYCoordinate(m.Origin().Y, min(uSeq[i], lSeq[i]))
+			if m.XStep() > 0 { // avoid drawing on or below X axis
+				bottom = min(bottom, m.Origin().Y)
+			}
+			top = max(top, 0)
+			for y := top + 1; y < bottom; y++ {
+				m.Canvas.SetCell(canvas.Point{X: startX + i, Y: y}, canvas.NewCellWithStyle(b.r, b.style))
+			}
+		}
+	}
+}
+
+// columnSequence returns the sequence of Y values of the lines of a
+// data set in each column, and whether each column displays the data set.
+func (m *Model) columnSequence(ds *dataSet) ([]int, []bool) {
+	width := max(m.Width()-m.Origin().X, 0)
+	seqY := make([]int, width)
+	valid := make([]bool, width)
+	for _, points := range m.drawPoints(ds, 2*m.GraphWidth()) {
+		seq := m.getLineSequence(points, ds.aggregation, ds.scaledYOffset())
+		first := max(int(math.Round(points[0].X)), 0)
+		last := min(int(math.Round(points[len(points)-1].X)), len(seq)-1)
+		for i := first; i <= last; i++ {
+			seqY[i] = seq[i]
+			valid[i] = true
+		}
+	}
+	return seqY, valid
+}
@@ -0,0 +1,367 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+// Package indicators implements technical indicators computing derived
+// time series incrementally, with constant time updates for each value.
+// Each indicator implements timeserieslinechart.Indicator to be linked
+// to data sets and candle sets of a timeserieslinechart.Model, such as:
+//
+//	m.LinkDataSets("price", indicators.NewSMA(20), "sma20")
+//	m.LinkCandleSet("candles", indicators.NewBollinger(20, 2), "mid", "upper", "lower")
+//	m.SetBand("upper", "lower", timeserieslinechart.DefaultBandRune, bandStyle)
+//
+// Indicators of candles use the close value, except VWAP which uses
+// the typical price and volume.  Values are NaN until enough values
+// are pushed, and NaN or infinite values are ignored.
+package indicators
+
+// https://en.wikipedia.org/wiki/Moving_average
+// https://en.wikipedia.org/wiki/Bollinger_Bands
+// https://en.wikipedia.org/wiki/Volume-weighted_average_price
+// https://en.wikipedia.org/wiki/Relative_strength_index
+// https://en.wikipedia.org/wiki/MACD
+
+import (
+	"math"
+	"time"
+
+	tslc "github.com/NimbleMarkets/ntcharts/linechart/timeserieslinechart"
+)
+
+var (
+	_ tslc.Indicator = (*SMA)(nil)
+	_ tslc.Indicator = (*EMA)(nil)
+	_ tslc.Indicator = (*Bollinger)(nil)
+	_ tslc.Indicator = (*VWAP)(nil)
+	_ tslc.Indicator = (*RSI)(nil)
+	_ tslc.Indicator = (*MACD)(nil)
+)
+
+// isValid returns whether given value is neither NaN nor infinite.
+func isValid(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+// points returns TimePoints at given time of given values.
+func points(t time.Time, vs ...float64) []tslc.TimePoint {
+	r := make([]tslc.TimePoint, len(vs))
+	for i, v := range vs {
+		r[i] = tslc.TimePoint{Time: t, Value: v}
+	}
+	return r
+}
+
+// window contains the last n values and their sums.
+type window struct {
+	values []float64
+	next   int // index of next value
+	count  int // number of values, at most len(values)
+	sum    float64
+	sumSq  float64
+}
+
+// newWindow returns a new *window of given size of at least 1.
+func newWindow(n int) *window {
+	return &window{values: make([]float64, max(n, 1))}
+}
+
+// add adds a value to the window replacing the oldest value if full.
+func (w *window) add(v float64) {
+	if w.count == len(w.values) {
+		old := w.values[w.next]
+		w.sum -= old
+		w.sumSq -= old * old
+	} else {
+		w.count++
+	}
+	w.values[w.next] = v
+	w.next = (w.next + 1) % len(w.values)
+	w.sum += v
+	w.sumSq += v * v
+}
+
+// full returns whether the window contains n values.
+func (w *window) full() bool {
+	return w.count == len(w.values)
+}
+
+// mean returns the average of the values of the window.
+func (w *window) mean() float64 {
+	return w.sum / float64(w.count)
+}
+
+// stdDev returns the population standard deviation of the values of the window.
+func (w *window) stdDev() float64 {
+	m := w.mean()
+	return math.Sqrt(math.Max(w.sumSq/float64(w.count)-m*m, 0))
+}
+
+// SMA is the simple moving average of the last n values.
+// Has a single output.
+type SMA struct {
+	w *window
+}
+
+// NewSMA returns a new *SMA of the last n values.
+func NewSMA(n int) *SMA {
+	return &SMA{w: newWindow(n)}
+}
+
+// Update adds a value and returns the current average,
+// or NaN if there are less than n values.
+func (s *SMA) Update(v float64) float64 {
+	if isValid(v) {
+		s.w.add(v)
+	}
+	if !s.w.full() {
+		return math.NaN()
+	}
+	return s.w.mean()
+}
+
+// Push is a timeserieslinechart.Indicator adding a TimePoint value.
+func (s *SMA) Push(t tslc.TimePoint) []tslc.TimePoint {
+	return points(t.Time, s.Update(t.Value))
+}
+
+// PushCandle is a timeserieslinechart.Indicator adding a candle close value.
+func (s *SMA) PushCandle(c tslc.Candle) []tslc.TimePoint {
+	return points(c.Time, s.Update(c.Close))
+}
+
+// EMA is the exponential moving average of values with a smoothing factor
+// of 2/(n+1), starting with the simple moving average of the first n values.
+// Has a single output.
+type EMA struct {
+	n     int
+	alpha float64
+	count int
+	value float64
+}
+
+// NewEMA returns a new *EMA over n values.
+func NewEMA(n int) *EMA {
+	n = max(n, 1)
+	return &EMA{n: n, alpha: 2 / float64(n+1)}
+}
+
+// Update adds a value and returns the current average,
+// or NaN if there are less than n values.
+func (e *EMA) Update(v float64) float64 {
+	if isValid(v) {
+		e.count++
+		switch {
+		case e.count < e.n:
+			e.value += v
+		case e.count == e.n:
+			e.value = (e.value + v) / float64(e.n)
+		default:
+			e.value += e.alpha * (v - e.value)
+		}
+	}
+	return e.Value()
+}
+
+// Value returns the current average, or NaN if there are less than n values.
+func (e *EMA) Value() float64 {
+	if e.count < e.n {
+		return math.NaN()
+	}
+	return e.value
+}
+
+// Push is a timeserieslinechart.Indicator adding a TimePoint value.
+func (e *EMA) Push(t tslc.TimePoint) []tslc.TimePoint {
+	return points(t.Time, e.Update(t.Value))
+}
+
+// PushCandle is a timeserieslinechart.Indicator adding a candle close value.
+func (e *EMA) PushCandle(c tslc.Candle) []tslc.TimePoint {
+	return points(c.Time, e.Update(c.Close))
+}
+
+// Bollinger is the Bollinger Bands of the simple moving average of the last
+// n values and bands k population standard deviations above and below.
+// Has middle, upper and lower outputs.
+type Bollinger struct {
+	w *window
+	k float64
+}
+
+// NewBollinger returns a new *Bollinger of the last n values
+// with bands k standard deviations from the average.
+func NewBollinger(n int, k float64) *Bollinger {
+	return &Bollinger{w: newWindow(n), k: k}
+}
+
+// Update adds a value and returns the current middle, upper and lower
+// values, or NaN if there are less than n values.
+func (b *Bollinger) Update(v float64) (mid, upper, lower float64) {
+	if isValid(v) {
+		b.w.add(v)
+	}
+	if !b.w.full() {
+		return math.NaN(), math.NaN(), math.NaN()
+	}
+	mid = b.w.mean()
+	d := b.k * b.w.stdDev()
+	return mid, mid + d, mid - d
+}
+
+// Push is a timeserieslinechart.Indicator adding a TimePoint value.
+func (b *Bollinger) Push(t tslc.TimePoint) []tslc.TimePoint {
+	mid, upper, lower := b.Update(t.Value)
+	return points(t.Time, mid, upper, lower)
+}
+
+// PushCandle is a timeserieslinechart.Indicator adding a candle close value.
+func (b *Bollinger) PushCandle(c tslc.Candle) []tslc.TimePoint {
+	mid, upper, lower := b.Update(c.Close)
+	return points(c.Time, mid, upper, lower)
+}
+
+// VWAP is the volume weighted average price since the start of the day
+// in a time.Location, or since the first value if there is no time.Location.
+// Has a single output.
+type VWAP struct {
+	loc    *time.Location
+	day    time.Time // start of current day
+	pv     float64   // cumulative price times volume
+	volume float64   // cumulative volume
+}
+
+// NewVWAP returns a new *VWAP restarting at midnight in given
+// time.Location, or never restarting if nil.
+func NewVWAP(loc *time.Location) *VWAP {
+	return &VWAP{loc: loc}
+}
+
+// Update adds a price and volume at given time and returns the current
+// average price, or NaN if there is no volume since the start of the day.
+func (w *VWAP) Update(t time.Time, price, volume float64) float64 {
+	if w.loc != nil {
+		y, m, d := t.In(w.loc).Date()
+		if day := time.Date(y, m, d, 0, 0, 0, 0, w.loc); !day.Equal(w.day) {
+			w.day = day
+			w.pv = 0
+			w.volume = 0
+		}
+	}
+	if isValid(price) && isValid(volume) {
+		w.pv += price * volume
+		w.volume += volume
+	}
+	if w.volume == 0 {
+		return math.NaN()
+	}
+	return w.pv / w.volume
+}
+
+// Push is a timeserieslinechart.Indicator adding a TimePoint
+// value as a price with a volume of 1.
+func (w *VWAP) Push(t tslc.TimePoint) []tslc.TimePoint {
+	return points(t.Time, w.Update(t.Time, t.Value, 1))
+}
+
+// PushCandle is a timeserieslinechart.Indicator adding the typical price,
+// which is the average of the high, low and close values, and volume of a candle.
+func (w *VWAP) PushCandle(c tslc.Candle) []tslc.TimePoint {
+	return points(c.Time, w.Update(c.Time, (c.High+c.Low+c.Close)/3, c.Volume))
+}
+
+// RSI is the relative strength index from 0 to 100 of the changes between
+// values, using Wilder's smoothing of average gains and losses over n changes.
+// Has a single output.
+type RSI struct {
+	n       int
+	count   int // number of changes
+	prev    float64
+	hasPrev bool
+	gain    float64 // average gain
+	loss    float64 // average loss
+}
+
+// NewRSI returns a new *RSI over n changes.
+func NewRSI(n int) *RSI {
+	return &RSI{n: max(n, 1)}
+}
+
+// Update adds a value and returns the current relative strength index,
+// or NaN if there are less than n changes.
+func (r *RSI) Update(v float64) float64 {
+	if isValid(v) {
+		if r.hasPrev {
+			ch := v - r.prev
+			g, l := math.Max(ch, 0), math.Max(-ch, 0)
+			r.count++
+			if r.count <= r.n { // average of first n changes
+				r.gain += (g - r.gain) / float64(r.count)
+				r.loss += (l - r.loss) / float64(r.count)
+			} else {
+				r.gain = (r.gain*float64(r.n-1) + g) / float64(r.n)
+				r.loss = (r.loss*float64(r.n-1) + l) / float64(r.n)
+			}
+		}
+		r.prev = v
+		r.hasPrev = true
+	}
+	switch {
+	case r.count < r.n:
+		return math.NaN()
+	case r.loss == 0 && r.gain == 0:
+		return 50
+	case r.loss == 0:
+		return 100
+	}
+	return 100 - 100/(1+r.gain/r.loss)
+}
+
+// Push is a timeserieslinechart.Indicator adding a TimePoint value.
+func (r *RSI) Push(t tslc.TimePoint) []tslc.TimePoint {
+	return points(t.Time, r.Update(t.Value))
+}
+
+// PushCandle is a timeserieslinechart.Indicator adding a candle close value.
+func (r *RSI) PushCandle(c tslc.Candle) []tslc.TimePoint {
+	return points(c.Time, r.Update(c.Close))
+}
+
+// MACD is the moving average convergence divergence, which is the difference
+// between fast and slow exponential moving averages of values, the signal
+// line exponential moving average of the difference and their histogram
+// difference.  Has MACD, signal and histogram outputs.
+type MACD struct {
+	fast   *EMA
+	slow   *EMA
+	signal *EMA
+}
+
+// NewMACD returns a new *MACD with given numbers of values of
+// fast, slow and signal exponential moving averages, such as 12, 26 and 9.
+func NewMACD(fast, slow, signal int) *MACD {
+	return &MACD{fast: NewEMA(fast), slow: NewEMA(slow), signal: NewEMA(signal)}
+}
+
+// Update adds a value and returns the current MACD, signal and histogram
+// values, or NaN if there are not enough values.
+func (m *MACD) Update(v float64) (macd, signal, hist float64) {
+	if !isValid(v) {
+		macd = m.fast.Value() - m.slow.Value()
+		signal = m.signal.Value()
+		return macd, signal, macd - signal
+	}
+	macd = m.fast.Update(v) - m.slow.Update(v)
+	signal = m.signal.Update(macd) // NaN differences are ignored
+	return macd, signal, macd - signal
+}
+
+// Push is a timeserieslinechart.Indicator adding a TimePoint value.
+func (m *MACD) Push(t tslc.TimePoint) []tslc.TimePoint {
+	macd, signal, hist := m.Update(t.Value)
+	return points(t.Time, macd, signal, hist)
+}
+
+// PushCandle is a timeserieslinechart.Indicator adding a candle close value.
+func (m *MACD) PushCandle(c tslc.Candle) []tslc.TimePoint {
+	macd, signal, hist := m.Update(c.Close)
+	return points(c.Time, macd, signal, hist)
+}
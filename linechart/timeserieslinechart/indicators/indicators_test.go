@@ -0,0 +1,132 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package indicators
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	tslc "github.com/NimbleMarkets/ntcharts/linechart/timeserieslinechart"
+)
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestSMA(t *testing.T) {
+	s := NewSMA(3)
+	expected := []float64{math.NaN(), math.NaN(), 2, 3, 4}
+	for i, v := range []float64{1, 2, 3, 4, 5} {
+		r := s.Update(v)
+		if math.IsNaN(expected[i]) != math.IsNaN(r) || (!math.IsNaN(r) && !almostEqual(r, expected[i])) {
+			t.Errorf("expected %v at index %d:%v", expected[i], i, r)
+		}
+	}
+	if r := s.Update(math.NaN()); !almostEqual(r, 4) {
+		t.Errorf("expected NaN value to be ignored:%v", r)
+	}
+}
+
+func TestEMA(t *testing.T) {
+	e := NewEMA(3)
+	e.Update(1)
+	if r := e.Update(2); !math.IsNaN(r) {
+		t.Errorf("expected NaN before 3 values:%v", r)
+	}
+	if r := e.Update(3); !almostEqual(r, 2) {
+		t.Errorf("expected seed of simple average:%v", r)
+	}
+	if r := e.Update(6); !almostEqual(r, 4) {
+		t.Errorf("expected smoothing factor of 1/2:%v", r)
+	}
+}
+
+func TestBollinger(t *testing.T) {
+	b := NewBollinger(4, 2)
+	var mid, upper, lower float64
+	for _, v := range []float64{2, 4, 4, 6} {
+		mid, upper, lower = b.Update(v)
+	}
+	// mean 4, population standard deviation sqrt(2)
+	if !almostEqual(mid, 4) || !almostEqual(upper, 4+2*math.Sqrt2) || !almostEqual(lower, 4-2*math.Sqrt2) {
+		t.Errorf("unexpected bands:%v %v %v", mid, upper, lower)
+	}
+}
+
+func TestVWAP(t *testing.T) {
+	w := NewVWAP(time.UTC)
+	t0 := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+	w.Update(t0, 10, 1)
+	if r := w.Update(t0.Add(time.Hour), 20, 3); !almostEqual(r, 17.5) {
+		t.Errorf("expected volume weighted average:%v", r)
+	}
+	if r := w.Update(t0.Add(24*time.Hour), 30, 1); !almostEqual(r, 30) {
+		t.Errorf("expected restart on new day:%v", r)
+	}
+	ts := w.PushCandle(tslc.Candle{Time: t0.Add(25 * time.Hour), High: 12, Low: 6, Close: 9, Volume: 1})
+	if (len(ts) != 1) || !almostEqual(ts[0].Value, 19.5) {
+		t.Errorf("expected typical price of candle:%v", ts)
+	}
+}
+
+func TestRSI(t *testing.T) {
+	r := NewRSI(2)
+	r.Update(10)
+	if v := r.Update(12); !math.IsNaN(v) {
+		t.Errorf("expected NaN before 2 changes:%v", v)
+	}
+	if v := r.Update(11); !almostEqual(v, 100-100/(1+2.0)) {
+		t.Errorf("unexpected RSI of first changes:%v", v)
+	}
+	// Wilder smoothing: gain (1+0)/2, loss (0.5+3)/2
+	if v := r.Update(8); !almostEqual(v, 100-100/(1+0.5/1.75)) {
+		t.Errorf("unexpected smoothed RSI:%v", v)
+	}
+}
+
+func TestMACD(t *testing.T) {
+	m := NewMACD(2, 3, 2)
+	var macd, signal, hist float64
+	for _, v := range []float64{1, 2, 3, 4} {
+		macd, signal, hist = m.Update(v)
+	}
+	// fast EMA: 1.5, 2.5, 3.5 and slow EMA: 2, 3 gives differences 0.5, 0.5
+	if !almostEqual(macd, 0.5) || !almostEqual(signal, 0.5) || !almostEqual(hist, 0) {
+		t.Errorf("unexpected MACD:%v %v %v", macd, signal, hist)
+	}
+}
+
+func TestLinkDataSets(t *testing.T) {
+	t0 := time.Unix(0, 0)
+	m := tslc.New(40, 10)
+	m.PushDataSet("price", tslc.TimePoint{Time: t0, Value: 1})
+	m.LinkDataSets("price", NewSMA(2), "sma")
+	m.PushDataSet("price", tslc.TimePoint{Time: t0.Add(time.Second), Value: 3})
+	tps := m.DataSetTimePoints("sma")
+	if (len(tps) != 2) || !math.IsNaN(tps[0].Value) || !almostEqual(tps[1].Value, 2) || !tps[1].Time.Equal(t0.Add(time.Second)) {
+		t.Errorf("wrong linked TimePoints:%v", tps)
+	}
+
+	// renamed sources and outputs stay linked
+	if !m.RenameDataSet("sma", "avg") || !m.RenameDataSet("price", "close") {
+		t.Fatalf("data sets not renamed:%v", m.DataSetNames())
+	}
+	m.PushDataSet("close", tslc.TimePoint{Time: t0.Add(2 * time.Second), Value: 5})
+	if n := m.DataSetLen("avg"); n != 3 {
+		t.Errorf("wrong number of renamed linked TimePoints:%d", n)
+	}
+	if m.HasDataSet("sma") || m.RenameDataSet("close", "avg") {
+		t.Errorf("renamed to existing data set:%v", m.DataSetNames())
+	}
+
+	// removed sources are unlinked
+	m.RemoveDataSet("close")
+	m.PushDataSet("close", tslc.TimePoint{Time: t0.Add(3 * time.Second), Value: 7})
+	if n := m.DataSetLen("avg"); n != 3 {
+		t.Errorf("removed data set still linked:%d", n)
+	}
+	if names := m.DataSetNames(); len(names) != 3 || names[0] != "avg" || names[1] != "close" || names[2] != tslc.DefaultDataSetName {
+		t.Errorf("wrong data set names:%v", names)
+	}
+}
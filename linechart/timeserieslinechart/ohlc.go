@@ -0,0 +1,402 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package timeserieslinechart
+
+// File contains candle sets storing open, high, low, close and volume
+// values of time intervals, which can be aggregated from ticks
+// and drawn as candlesticks or OHLC bars.
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/NimbleMarkets/ntcharts/canvas"
+	"github.com/NimbleMarkets/ntcharts/canvas/graph"
+	"github.com/NimbleMarkets/ntcharts/canvas/runes"
+	"github.com/NimbleMarkets/ntcharts/legend"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Candle contains the open, high, low, close and volume values
+// of a time interval starting at Time.
+type Candle struct {
+	Time   time.Time
+	Open   float64
+	High   float64
+	Low    float64
+	Close  float64
+	Volume float64
+}
+
+// bullish returns whether the close value is at or above the open value.
+func (c Candle) bullish() bool {
+	return c.Close >= c.Open
+}
+
+// merge returns the candle combining given candle
+// of a later time in the same time interval.
+func (c Candle) merge(o Candle) Candle {
+	c.High = math.Max(c.High, o.High)
+	c.Low = math.Min(c.Low, o.Low)
+	c.Close = o.Close
+	c.Volume += o.Volume
+	return c
+}
+
+// CandleType determines how candles of a candle set are drawn.
+type CandleType int
+
+const (
+	// CandleFilled draws candlesticks with heavy line bodies.
+	CandleFilled CandleType = iota
+	// CandleHollow draws candlesticks with hollow double line bodies
+	// for bullish candles and heavy line bodies for bearish candles.
+	CandleHollow
+	// CandleOHLC draws OHLC bars with the open value as a tick
+	// to the left and the close value as a tick to the right.
+	CandleOHLC
+	// CandleHeikinAshi draws candlesticks with heavy line bodies
+	// of Heikin-Ashi candles computed from the candles.
+	CandleHeikinAshi
+)
+
+// candleSet contains the candles of a candle set in chronological order.
+type candleSet struct {
+	candleType CandleType
+	bullStyle  lipgloss.Style // style of candles closing at or above open
+	bearStyle  lipgloss.Style // style of candles closing below open
+
+	interval time.Duration // time interval of each candle, 0 if not aggregated
+
+	candles []Candle
+	ha      []Candle // Heikin-Ashi candles of each candle
+}
+
+// insert adds given candle to the candle set, combining it with an
+// existing candle of the same time, and returns the stored candle.
+func (cs *candleSet) insert(c Candle) Candle {
+	n := len(cs.candles)
+	i := n
+	if (n > 0) && c.Time.Before(cs.candles[n-1].Time) { // out of order candle
+		i = sort.Search(n, func(i int) bool { return !cs.candles[i].Time.Before(c.Time) })
+	} else if (n > 0) && c.Time.Equal(cs.candles[n-1].Time) {
+		i = n - 1
+	}
+	switch {
+	case (i < n) && cs.candles[i].Time.Equal(c.Time):
+		if cs.interval > 0 {
+			c = cs.candles[i].merge(c)
+		}
+		cs.candles[i] = c
+	case i == n:
+		cs.candles = append(cs.candles, c)
+	default:
+		cs.candles = append(cs.candles, Candle{})
+		copy(cs.candles[i+1:], cs.candles[i:])
+		cs.candles[i] = c
+	}
+	cs.updateHeikinAshi(i)
+	return cs.candles[i]
+}
+
+// updateHeikinAshi recomputes the Heikin-Ashi candles
+// starting from the candle at given index.
+func (cs *candleSet) updateHeikinAshi(i int) {
+	cs.ha = append(cs.ha[:i], make([]Candle, len(cs.candles)-i)...)
+	for j := i; j < len(cs.candles); j++ {
+		c := cs.candles[j]
+		hc := (c.Open + c.High + c.Low + c.Close) / 4
+		ho := (c.Open + c.Close) / 2
+		if j > 0 {
+			ho = (cs.ha[j-1].Open + cs.ha[j-1].Close) / 2
+		}
+		cs.ha[j] = Candle{
+			Time:   c.Time,
+			Open:   ho,
+			High:   math.Max(c.High, math.Max(ho, hc)),
+			Low:    math.Min(c.Low, math.Min(ho, hc)),
+			Close:  hc,
+			Volume: c.Volume,
+		}
+	}
+}
+
+// newCandleSet returns a new initialized *candleSet.
+func (m *Model) newCandleSet() *candleSet {
+	return &candleSet{
+		candleType: m.dCandleType,
+		bullStyle:  m.dBullStyle,
+		bearStyle:  m.dBearStyle,
+		interval:   m.dCandleInterval,
+	}
+}
+
+// getCandleSet returns the candle set given by name string,
+// creating a new candle set if it does not exist.
+func (m *Model) getCandleSet(n string) *candleSet {
+	if _, ok := m.cSets[n]; !ok {
+		m.cSets[n] = m.newCandleSet()
+	}
+	return m.cSets[n]
+}
+
+// candleTime returns the start of the time interval of given duration
+// containing given time.  Intervals are aligned to the wall clock
+// of the Model time.Location, with intervals of whole days
+// starting at midnight.
+func (m *Model) candleTime(t time.Time, d time.Duration) time.Time {
+	if d <= 0 {
+		return t
+	}
+	const day = 24 * time.Hour
+	if d%day != 0 {
+		_, off := t.In(m.Location()).Zone()
+		o := time.Duration(off) * time.Second
+		return t.Add(o).Truncate(d).Add(-o)
+	}
+	t = midnight(t.In(m.Location()))
+	y, mo, dd := t.Date()
+	days := floorDiv(time.Date(y, mo, dd, 0, 0, 0, 0, time.UTC).Unix(), 86400)
+	n := int64(d / day)
+	return t.AddDate(0, 0, -int(days-floorDiv(days, n)*n))
+}
+
+// SetCandleType sets the CandleType of the default candle set.
+func (m *Model) SetCandleType(t CandleType) {
+	m.dCandleType = t
+	m.SetCandleSetType(DefaultDataSetName, t)
+}
+
+// SetCandleSetType sets the CandleType of the candle set given by name string.
+func (m *Model) SetCandleSetType(n string, t CandleType) {
+	cs := m.getCandleSet(n)
+	cs.candleType = t
+	if t == CandleHeikinAshi { // Heikin-Ashi candles may exceed the candle ranges
+		for _, c := range cs.ha {
+			m.adjustCandleRange(c)
+		}
+	}
+}
+
+// SetCandleStyles sets the styles of bullish and bearish
+// candles of the default candle set.
+func (m *Model) SetCandleStyles(bull, bear lipgloss.Style) {
+	m.dBullStyle = bull
+	m.dBearStyle = bear
+	m.SetCandleSetStyles(DefaultDataSetName, bull, bear)
+}
+
+// SetCandleSetStyles sets the styles of bullish and bearish
+// candles of the candle set given by name string.
+// Bullish candles close at or above their open value.
+func (m *Model) SetCandleSetStyles(n string, bull, bear lipgloss.Style) {
+	cs := m.getCandleSet(n)
+	cs.bullStyle = bull
+	cs.bearStyle = bear
+}
+
+// SetCandleInterval sets the time interval of each candle of the default candle set.
+func (m *Model) SetCandleInterval(d time.Duration) {
+	m.dCandleInterval = d
+	m.SetCandleSetInterval(DefaultDataSetName, d)
+}
+
+// SetCandleSetInterval sets the time interval of each candle of the
+// candle set given by name string. Candles and ticks pushed to the
+// candle set are aggregated into candles starting at multiples of the
+// interval of the wall clock in the Model time.Location, where intervals
+// of whole days start at midnight.  If 0, then candles are not aggregated and
+// pushing a candle with the same time replaces the existing candle.
+// Only applies to candles pushed afterwards.
+func (m *Model) SetCandleSetInterval(n string, d time.Duration) {
+	m.getCandleSet(n).interval = d
+}
+
+// PushCandle will push a candle of open, high, low, close and volume values
+// at given time to the default candle set to be displayed with DrawCandles.
+func (m *Model) PushCandle(t time.Time, o, h, l, c, v float64) {
+	m.PushCandleSet(DefaultDataSetName, t, o, h, l, c, v)
+}
+
+// PushCandleSet will push a candle of open, high, low, close and volume values
+// at given time to the candle set given by name string.
+// If the candle set has an interval, then the candle is combined
+// with the candle of the interval containing the given time.
+// Completed candles are pushed to Indicators linked to the candle set.
+func (m *Model) PushCandleSet(n string, t time.Time, o, h, l, c, v float64) {
+	cs := m.getCandleSet(n)
+	t = m.candleTime(t, cs.interval)
+	l0 := len(cs.candles)
+	r := cs.insert(Candle{Time: t, Open: o, High: h, Low: l, Close: c, Volume: v})
+	m.adjustCandleRange(r)
+	if cs.candleType == CandleHeikinAshi {
+		for _, ha := range cs.ha[sort.Search(len(cs.ha), func(i int) bool { return !cs.ha[i].Time.Before(t) }):] {
+			m.adjustCandleRange(ha)
+		}
+	}
+	// push completed candles to linked Indicators
+	if (len(cs.candles) > l0) && cs.candles[l0].Time.Equal(t) {
+		if cs.interval <= 0 {
+			m.pushCandleLinks(n, r)
+		} else if l0 > 0 {
+			m.pushCandleLinks(n, cs.candles[l0-1])
+		}
+	}
+	m.fitPending = true
+}
+
+// PushTick will push a traded price and volume at given time to the
+// default candle set, aggregated into the candle of the interval
+// containing the given time.
+func (m *Model) PushTick(t time.Time, price, volume float64) {
+	m.PushCandleSetTick(DefaultDataSetName, t, price, volume)
+}
+
+// PushCandleSetTick will push a traded price and volume at given time to the
+// candle set given by name string, aggregated into the candle of the interval
+// containing the given time.  Ticks are expected in chronological order
+// and candle sets without an interval create a candle for each tick.
+func (m *Model) PushCandleSetTick(n string, t time.Time, price, volume float64) {
+	m.PushCandleSet(n, t, price, price, price, price, volume)
+}
+
+// adjustCandleRange auto adjusts the X and Y ranges
+// from the time, high and low values of given candle.
+func (m *Model) adjustCandleRange(c Candle) {
+	x := m.TimeX(c.Time)
+	bh := m.AutoAdjustRange(canvas.Float64Point{X: x, Y: c.High})
+	bl := m.AutoAdjustRange(canvas.Float64Point{X: x, Y: c.Low})
+	if bh || bl {
+		m.UpdateGraphSizes()
+		m.rescaleData()
+	}
+}
+
+// Candles returns the candles of the default candle set.
+func (m *Model) Candles() []Candle {
+	return m.CandleSet(DefaultDataSetName)
+}
+
+// CandleSet returns the candles of the candle set
+// given by name string in chronological order.
+func (m *Model) CandleSet(n string) []Candle {
+	if cs, ok := m.cSets[n]; ok {
+		return append([]Candle{}, cs.candles...)
+	}
+	return []Candle{}
+}
+
+// ClearCandleSet will erase stored candles of the candle set given by name string.
+func (m *Model) ClearCandleSet(n string) {
+	if cs, ok := m.cSets[n]; ok {
+		cs.candles = nil
+		cs.ha = nil
+	}
+}
+
+// legendEntries returns legend entries of the bullish and bearish
+// candles of the candle set given by name string.
+func (cs *candleSet) legendEntries(n string) []legend.Entry {
+	var marker rune
+	switch cs.candleType {
+	case CandleHollow:
+		marker = runes.LineVerticalDouble
+	case CandleOHLC:
+		marker = runes.LineHorizontalVertical
+	default:
+		marker = runes.LineVerticalHeavy
+	}
+	bull, bear := "bull", "bear"
+	if n != DefaultDataSetName {
+		bull, bear = n+" "+bull, n+" "+bear
+	}
+	return []legend.Entry{
+		{Name: bull, Marker: string(marker), Style: cs.bullStyle},
+		{Name: bear, Marker: string(marker), Style: cs.bearStyle},
+	}
+}
+
+// DrawCandles will draw the candles of the default candle set
+// displayed from left to right of the graphing area of the canvas.
+func (m *Model) DrawCandles() {
+	m.DrawCandleSets([]string{DefaultDataSetName})
+}
+
+// DrawCandleSets will draw the candles of each candle set given by name
+// strings from left to right of the graphing area of the canvas, with one
+// column for each candle.  Candles in the same column are drawn over each other.
+func (m *Model) DrawCandleSets(names []string) {
+	if len(names) == 0 {
+		return
+	}
+	m.fitPushed()
+	m.Clear()
+	m.DrawXYAxisAndLabel()
+	m.drawBands()
+	for _, n := range names {
+		if cs, ok := m.cSets[n]; ok {
+			m.drawCandleSet(cs)
+		}
+	}
+}
+
+// drawCandleSet draws the candles of given candle set within the view range.
+func (m *Model) drawCandleSet(cs *candleSet) {
+	candles := cs.candles
+	if cs.candleType == CandleHeikinAshi {
+		candles = cs.ha
+	}
+	offset, scale := m.dataScale()
+	xt := m.XTransform()
+	yt := m.YTransform()
+	scaleY := func(v float64) float64 {
+		return (m.TransformY(v) - offset.Y) * scale.Y
+	}
+	minX := m.ViewMinX()
+	maxX := m.ViewMaxX()
+	i := sort.Search(len(candles), func(i int) bool { return m.TimeX(candles[i].Time) >= minX })
+	for _, c := range candles[i:] {
+		x := m.TimeX(c.Time)
+		if x > maxX {
+			break
+		}
+		if !xt.Valid(x) || !yt.Valid(c.Low) || !isFinite(c.Open, c.High, c.Low, c.Close) {
+			continue
+		}
+		sx := int((m.TransformX(x) - offset.X) * scale.X)
+		if (sx < 0) || (sx >= m.GraphWidth()) {
+			continue
+		}
+		drawX := sx + m.Origin().X
+		if m.YStep() > 0 {
+			drawX += 1
+		}
+		p := canvas.Point{X: drawX, Y: m.Origin().Y - 1}
+		s := cs.bearStyle
+		if c.bullish() {
+			s = cs.bullStyle
+		}
+		l, o, cl, h := scaleY(c.Low), scaleY(c.Open), scaleY(c.Close), scaleY(c.High)
+		bl, bh := math.Min(o, cl), math.Max(o, cl)
+		switch {
+		case cs.candleType == CandleOHLC:
+			graph.DrawOHLCBarBottomToTop(&m.Canvas, p, l, o, cl, h, s)
+		case (cs.candleType == CandleHollow) && c.bullish():
+			graph.DrawHollowCandlestickBottomToTop(&m.Canvas, p, l, bl, bh, h, s)
+		default:
+			graph.DrawCandlestickBottomToTop(&m.Canvas, p, l, bl, bh, h, s)
+		}
+	}
+}
+
+// isFinite returns whether all given values are neither NaN nor infinite.
+func isFinite(vs ...float64) bool {
+	for _, v := range vs {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return false
+		}
+	}
+	return true
+}
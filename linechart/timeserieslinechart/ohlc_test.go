@@ -0,0 +1,161 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package timeserieslinechart
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestCandleTime(t *testing.T) {
+	ist := time.FixedZone("IST", 5*3600+1800)
+	tm := time.Date(2024, 1, 3, 10, 47, 12, 0, ist)
+	tests := []struct {
+		name string
+		loc  *time.Location
+		d    time.Duration
+		want time.Time
+	}{
+		{"none", ist, 0, tm},
+		{"minutes", ist, 5 * time.Minute, time.Date(2024, 1, 3, 10, 45, 0, 0, ist)},
+		{"hour", ist, time.Hour, time.Date(2024, 1, 3, 10, 0, 0, 0, ist)},
+		{"hour UTC", time.UTC, time.Hour, time.Date(2024, 1, 3, 5, 0, 0, 0, time.UTC)},
+		{"4 hours", ist, 4 * time.Hour, time.Date(2024, 1, 3, 8, 0, 0, 0, ist)},
+		{"day", ist, 24 * time.Hour, time.Date(2024, 1, 3, 0, 0, 0, 0, ist)},
+		{"day UTC", time.UTC, 24 * time.Hour, time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{"2 days", ist, 48 * time.Hour, time.Date(2024, 1, 2, 0, 0, 0, 0, ist)},
+	}
+	for _, tc := range tests {
+		m := New(20, 10, WithLocation(tc.loc))
+		if got := m.candleTime(tm, tc.d); !got.Equal(tc.want) {
+			t.Errorf("%s: wrong candle time:%v expected %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestCandleSetInsert(t *testing.T) {
+	candle := func(s int, o, h, l, c, v float64) Candle {
+		return Candle{Time: testTime.Add(time.Duration(s) * time.Minute), Open: o, High: h, Low: l, Close: c, Volume: v}
+	}
+	tests := []struct {
+		name     string
+		interval time.Duration
+		in       []Candle
+		want     []Candle
+	}{
+		{
+			name: "ordered", interval: time.Minute,
+			in:   []Candle{candle(0, 10, 12, 9, 11, 1), candle(1, 11, 13, 10, 12, 2)},
+			want: []Candle{candle(0, 10, 12, 9, 11, 1), candle(1, 11, 13, 10, 12, 2)},
+		},
+		{
+			name: "same bucket merged", interval: time.Minute,
+			in:   []Candle{candle(0, 10, 12, 9, 11, 1), candle(0, 11, 14, 10, 13, 2), candle(0, 13, 13, 8, 9, 3)},
+			want: []Candle{candle(0, 10, 14, 8, 9, 6)},
+		},
+		{
+			name: "same time replaced", interval: 0,
+			in:   []Candle{candle(0, 10, 12, 9, 11, 1), candle(0, 11, 14, 10, 13, 2)},
+			want: []Candle{candle(0, 11, 14, 10, 13, 2)},
+		},
+		{
+			name: "out of order", interval: time.Minute,
+			in: []Candle{candle(0, 10, 12, 9, 11, 1), candle(3, 13, 14, 12, 13, 1),
+				candle(1, 11, 12, 10, 12, 1), candle(0, 11, 15, 10, 10, 2)},
+			want: []Candle{candle(0, 10, 15, 9, 10, 3), candle(1, 11, 12, 10, 12, 1), candle(3, 13, 14, 12, 13, 1)},
+		},
+	}
+	for _, tc := range tests {
+		cs := &candleSet{interval: tc.interval}
+		for _, c := range tc.in {
+			cs.insert(c)
+		}
+		if len(cs.candles) != len(tc.want) {
+			t.Errorf("%s: wrong candles:%v", tc.name, cs.candles)
+			continue
+		}
+		for i, c := range cs.candles {
+			if c != tc.want[i] {
+				t.Errorf("%s: wrong candle %d:%v expected %v", tc.name, i, c, tc.want[i])
+			}
+		}
+		// Heikin-Ashi candles are recomputed for out of order candles
+		if len(cs.ha) != len(cs.candles) {
+			t.Errorf("%s: wrong number of Heikin-Ashi candles:%d", tc.name, len(cs.ha))
+			continue
+		}
+		for i, c := range cs.candles {
+			ho := (c.Open + c.Close) / 2
+			if i > 0 {
+				ho = (cs.ha[i-1].Open + cs.ha[i-1].Close) / 2
+			}
+			hc := (c.Open + c.High + c.Low + c.Close) / 4
+			want := Candle{Time: c.Time, Open: ho, High: math.Max(c.High, math.Max(ho, hc)),
+				Low: math.Min(c.Low, math.Min(ho, hc)), Close: hc, Volume: c.Volume}
+			if cs.ha[i] != want {
+				t.Errorf("%s: wrong Heikin-Ashi candle %d:%v expected %v", tc.name, i, cs.ha[i], want)
+			}
+		}
+	}
+}
+
+func TestHeikinAshi(t *testing.T) {
+	cs := &candleSet{}
+	cs.insert(Candle{Time: testTime, Open: 10, High: 14, Low: 8, Close: 12})
+	cs.insert(Candle{Time: testTime.Add(time.Minute), Open: 12, High: 13, Low: 4, Close: 5})
+	want := []Candle{
+		{Time: testTime, Open: 11, High: 14, Low: 8, Close: 11},
+		{Time: testTime.Add(time.Minute), Open: 11, High: 13, Low: 4, Close: 8.5},
+	}
+	for i, c := range cs.ha {
+		if c != want[i] {
+			t.Errorf("wrong Heikin-Ashi candle %d:%v expected %v", i, c, want[i])
+		}
+	}
+}
+
+func TestPushTick(t *testing.T) {
+	m := New(20, 10, WithCandleInterval(time.Minute))
+	at := func(s int) time.Time { return testTime.Add(time.Duration(s) * time.Second) }
+	m.PushTick(at(5), 10, 1)
+	m.PushTick(at(30), 12, 2)
+	m.PushTick(at(50), 9, 3)
+	m.PushTick(at(70), 11, 4)
+	want := []Candle{
+		{Time: testTime, Open: 10, High: 12, Low: 9, Close: 9, Volume: 6},
+		{Time: at(60), Open: 11, High: 11, Low: 11, Close: 11, Volume: 4},
+	}
+	cs := m.Candles()
+	if len(cs) != len(want) {
+		t.Fatalf("wrong candles:%v", cs)
+	}
+	for i, c := range cs {
+		if c != want[i] {
+			t.Errorf("wrong candle %d:%v expected %v", i, c, want[i])
+		}
+	}
+}
+
+func TestClearAllDataCandles(t *testing.T) {
+	bull := lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+	m := New(20, 10,
+		WithCandleType(CandleHeikinAshi),
+		WithCandleInterval(time.Minute),
+		WithDataSetCandleStyles("other", bull, bull))
+	m.PushTick(testTime, 10, 1)
+	m.PushCandleSetTick("other", testTime, 10, 1)
+	m.ClearAllData()
+	if (len(m.Candles()) != 0) || (len(m.CandleSet("other")) != 0) {
+		t.Error("candles not cleared")
+	}
+	cs := m.cSets[DefaultDataSetName]
+	if (cs.candleType != CandleHeikinAshi) || (cs.interval != time.Minute) || (len(cs.ha) != 0) {
+		t.Errorf("default candle set settings not kept:%v %v", cs.candleType, cs.interval)
+	}
+	if s := m.cSets["other"].bullStyle; s.GetForeground() != bull.GetForeground() {
+		t.Error("candle set styles not kept")
+	}
+}
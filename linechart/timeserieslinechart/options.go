@@ -7,6 +7,7 @@ package timeserieslinechart
 import (
 	"time"
 
+	"github.com/NimbleMarkets/ntcharts/canvas/graph"
 	"github.com/NimbleMarkets/ntcharts/canvas/runes"
 	"github.com/NimbleMarkets/ntcharts/linechart"
 
@@ -43,9 +44,10 @@ func WithZoneManager(zm *zone.Manager) Option {
 }
 
 // WithXLabelFormatter sets the default X label formatter for displaying X values as strings.
+// The formatter is kept when time ticks, the time.Location or epoch of the Model change.
 func WithXLabelFormatter(fmter linechart.LabelFormatter) Option {
 	return func(m *Model) {
-		m.XLabelFormatter = fmter
+		m.SetXLabelFormatter(fmter)
 	}
 }
 
@@ -137,3 +139,280 @@ func WithDataSetTimeSeries(n string, p []TimePoint) Option {
 		}
 	}
 }
+
+// WithMaxPoints sets the default maximum number of TimePoints stored by data sets.
+func WithMaxPoints(n int) Option {
+	return func(m *Model) {
+		m.SetMaxPoints(n)
+	}
+}
+
+// WithDataSetMaxPoints sets the maximum number of TimePoints
+// stored by the data set given by name.
+func WithDataSetMaxPoints(n string, max int) Option {
+	return func(m *Model) {
+		m.SetDataSetMaxPoints(n, max)
+	}
+}
+
+// WithMaxAge sets the default maximum age of TimePoints stored by data sets
+// relative to the newest TimePoint of each data set.
+func WithMaxAge(d time.Duration) Option {
+	return func(m *Model) {
+		m.SetMaxAge(d)
+	}
+}
+
+// WithDataSetMaxAge sets the maximum age of TimePoints stored by the data set
+// given by name relative to its newest TimePoint.
+func WithDataSetMaxAge(n string, d time.Duration) Option {
+	return func(m *Model) {
+		m.SetDataSetMaxAge(n, d)
+	}
+}
+
+// WithMaxGap sets the default maximum time between consecutive
+// TimePoints of data sets before breaking the line between them.
+func WithMaxGap(d time.Duration) Option {
+	return func(m *Model) {
+		m.SetMaxGap(d)
+	}
+}
+
+// WithDataSetMaxGap sets the maximum time between consecutive TimePoints
+// of the data set given by name before breaking the line between them.
+func WithDataSetMaxGap(n string, d time.Duration) Option {
+	return func(m *Model) {
+		m.SetDataSetMaxGap(n, d)
+	}
+}
+
+// WithEvictHandler sets the callback invoked for each TimePoint
+// removed from data sets due to data set retention limits.
+func WithEvictHandler(h EvictHandler) Option {
+	return func(m *Model) {
+		m.SetEvictHandler(h)
+	}
+}
+
+// WithDownsampleFunc sets the function used to reduce the number
+// of displayed data points of each data set before drawing,
+// such as graph.DownsampleLTTB or graph.DownsampleMinMax.
+func WithDownsampleFunc(f graph.DownsampleFunc) Option {
+	return func(m *Model) {
+		m.SetDownsampleFunc(f)
+	}
+}
+
+// WithAggregation sets the default Aggregation of data sets.
+func WithAggregation(a Aggregation) Option {
+	return func(m *Model) {
+		m.SetAggregation(a)
+	}
+}
+
+// WithDataSetAggregation sets the Aggregation of the data set given by name.
+func WithDataSetAggregation(n string, a Aggregation) Option {
+	return func(m *Model) {
+		m.SetDataSetAggregation(n, a)
+	}
+}
+
+// WithXTransform sets the X axis AxisTransform.
+func WithXTransform(t linechart.AxisTransform) Option {
+	return func(m *Model) {
+		m.SetXTransform(t)
+	}
+}
+
+// WithYTransform sets the Y axis AxisTransform.
+func WithYTransform(t linechart.AxisTransform) Option {
+	return func(m *Model) {
+		m.SetYTransform(t)
+	}
+}
+
+// WithXYTickLocators sets the TickLocators used to place
+// X and Y axes values instead of every number of steps.
+// If a TickLocator is nil, then axis values are placed using steps.
+func WithXYTickLocators(x, y linechart.TickLocator) Option {
+	return func(m *Model) {
+		m.SetXTickLocator(x)
+		m.SetYTickLocator(y)
+	}
+}
+
+// WithXYMinorTicks sets the number of minor tick intervals between
+// X and Y axes ticks placed by TickLocators.
+func WithXYMinorTicks(x, y int) Option {
+	return func(m *Model) {
+		m.SetXMinorTicks(x)
+		m.SetYMinorTicks(y)
+	}
+}
+
+// WithLocation sets the time.Location used to determine
+// calendar boundaries of time ticks and display their labels.
+func WithLocation(loc *time.Location) Option {
+	return func(m *Model) {
+		m.SetLocation(loc)
+	}
+}
+
+// WithTimeTicks places X axis values at calendar boundaries
+// such as midnight and month starts chosen from the visible
+// time range, with labels displaying dates where they change.
+func WithTimeTicks() Option {
+	return func(m *Model) {
+		m.SetTimeTicks(true)
+	}
+}
+
+// WithEpoch sets the time.Time of X value 0, which defaults to the Unix epoch.
+// Using an epoch near the displayed times retains nanosecond precision.
+// Formatters given by WithXLabelFormatter must display X values
+// as seconds since the epoch, such as by TimeLabelFormatter.
+func WithEpoch(t time.Time) Option {
+	return func(m *Model) {
+		m.SetEpoch(t)
+	}
+}
+
+// WithSessionCalendar sets the SessionCalendar used to compress
+// the X axis to only display times during trading sessions.
+func WithSessionCalendar(c *SessionCalendar) Option {
+	return func(m *Model) {
+		m.SetSessionCalendar(c)
+	}
+}
+
+// WithCandleType sets the CandleType of the default candle set.
+func WithCandleType(t CandleType) Option {
+	return func(m *Model) {
+		m.SetCandleType(t)
+	}
+}
+
+// WithCandleStyles sets the styles of bullish and bearish
+// candles of the default candle set.
+func WithCandleStyles(bull, bear lipgloss.Style) Option {
+	return func(m *Model) {
+		m.SetCandleStyles(bull, bear)
+	}
+}
+
+// WithCandleInterval sets the time interval of each
+// candle of the default candle set.
+func WithCandleInterval(d time.Duration) Option {
+	return func(m *Model) {
+		m.SetCandleInterval(d)
+	}
+}
+
+// WithDataSetCandleType sets the CandleType of the candle set given by name string.
+func WithDataSetCandleType(n string, t CandleType) Option {
+	return func(m *Model) {
+		m.SetCandleSetType(n, t)
+	}
+}
+
+// WithDataSetCandleStyles sets the styles of bullish and bearish
+// candles of the candle set given by name string.
+func WithDataSetCandleStyles(n string, bull, bear lipgloss.Style) Option {
+	return func(m *Model) {
+		m.SetCandleSetStyles(n, bull, bear)
+	}
+}
+
+// WithDataSetCandleInterval sets the time interval of each
+// candle of the candle set given by name string.
+func WithDataSetCandleInterval(n string, d time.Duration) Option {
+	return func(m *Model) {
+		m.SetCandleSetInterval(n, d)
+	}
+}
+
+// WithDataSetZOrder sets the z-order of the data set given by name string,
+// where data sets with greater z-order are drawn over others.
+func WithDataSetZOrder(n string, z int) Option {
+	return func(m *Model) {
+		m.SetDataSetZOrder(n, z)
+	}
+}
+
+// WithExcludeHiddenRange excludes data values pushed to hidden
+// data sets from automatic adjustment of the value ranges.
+func WithExcludeHiddenRange() Option {
+	return func(m *Model) {
+		m.SetExcludeHiddenRange(true)
+	}
+}
+
+// WithHover enables tracking the mouse over the graphing area for drawing
+// a crosshair and tooltip of the time and nearest values with DrawHover.
+func WithHover() Option {
+	return func(m *Model) {
+		m.SetHover(true)
+	}
+}
+
+// WithHoverStyles sets the styles of the hover crosshair and tooltips.
+func WithHoverStyles(crosshair, tooltip lipgloss.Style) Option {
+	return func(m *Model) {
+		m.CrosshairStyle = crosshair
+		m.TooltipStyle = tooltip
+	}
+}
+
+// WithHoverTimeLayout sets the time layout of hover tooltips.
+func WithHoverTimeLayout(layout string) Option {
+	return func(m *Model) {
+		m.SetHoverTimeLayout(layout)
+	}
+}
+
+// WithCursor shows the keyboard data cursor drawn with DrawCursor.
+func WithCursor() Option {
+	return func(m *Model) {
+		m.SetCursor(true)
+	}
+}
+
+// WithCursorKeyMap sets the key bindings of the keyboard data cursor.
+func WithCursorKeyMap(k linechart.CursorKeyMap) Option {
+	return func(m *Model) {
+		m.CursorKeyMap = k
+	}
+}
+
+// WithCursorStyle sets the style applied to the data point of the data cursor.
+func WithCursorStyle(s lipgloss.Style) Option {
+	return func(m *Model) {
+		m.CursorStyle = s
+	}
+}
+
+// WithZoomKeyMap sets the key bindings of the zoom history.
+func WithZoomKeyMap(k linechart.ZoomKeyMap) Option {
+	return func(m *Model) {
+		m.ZoomKeyMap = k
+	}
+}
+
+// WithSelectionStyle sets the style of the box zoom selection.
+func WithSelectionStyle(s lipgloss.Style) Option {
+	return func(m *Model) {
+		m.SelectionStyle = s
+	}
+}
+
+// WithAutoFitY enables fitting the displayed Y values to the data values
+// and candles within the displayed time range with given fraction of
+// padding above and below, and whether the fitted Y values include zero.
+func WithAutoFitY(padding float64, zero bool) Option {
+	return func(m *Model) {
+		m.SetAutoFitY(true)
+		m.SetAutoFitYPadding(padding)
+		m.SetAutoFitYZero(zero)
+	}
+}
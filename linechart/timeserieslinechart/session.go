@@ -0,0 +1,309 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package timeserieslinechart
+
+// File contains a trading session calendar used to compress the X axis
+// to only display times during trading sessions, skipping
+// overnight hours, weekends and holidays.
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Session contains the opening and closing times of a trading session
+// as the wall clock time since midnight.
+type Session struct {
+	Open  time.Duration
+	Close time.Duration
+}
+
+// length returns the duration of the session.
+func (s Session) length() time.Duration {
+	if s.Close < s.Open {
+		return 0
+	}
+	return s.Close - s.Open
+}
+
+// SessionCalendar maps wall clock times to session times, which is the
+// total duration of trading sessions since a reference time.  Trading sessions
+// are given by a weekly template of sessions for each weekday, and a list of
+// holidays without sessions.  Times outside of sessions are mapped
+// to the session time of the next session open.
+type SessionCalendar struct {
+	loc      *time.Location
+	week     [7][]Session // sessions of each time.Weekday sorted by opening time
+	dayTotal [7]float64   // total seconds of sessions of each time.Weekday
+	total    float64      // total seconds of sessions of the weekly template
+
+	holidays    []int64   // sorted days of holidays since Unix epoch
+	holidaySums []float64 // cumulative session seconds of holidays before each index
+}
+
+// NewSessionCalendar returns a new *SessionCalendar without any
+// sessions using given time.Location for wall clock times.
+// If nil, then UTC is used.
+func NewSessionCalendar(loc *time.Location) *SessionCalendar {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return &SessionCalendar{loc: loc}
+}
+
+// NewWeekdaySessionCalendar returns a new *SessionCalendar with a session
+// from given open and close wall clock times since midnight every Monday
+// to Friday using given time.Location.  For example, regular trading hours of
+// US equities use 9h30m and 16h in the America/New_York time.Location,
+// and daily data can use 0 and 24h to skip weekends.
+func NewWeekdaySessionCalendar(loc *time.Location, open, close time.Duration) *SessionCalendar {
+	c := NewSessionCalendar(loc)
+	for d := time.Monday; d <= time.Friday; d++ {
+		c.SetSessions(d, Session{Open: open, Close: close})
+	}
+	return c
+}
+
+// Location returns the time.Location of wall clock times.
+func (c *SessionCalendar) Location() *time.Location {
+	return c.loc
+}
+
+// dailySession returns the average total duration of sessions
+// of each weekday with sessions in the weekly template.
+func (c *SessionCalendar) dailySession() time.Duration {
+	n := 0
+	for _, t := range c.dayTotal {
+		if t > 0 {
+			n++
+		}
+	}
+	if n == 0 {
+		return 24 * time.Hour
+	}
+	return time.Duration(c.total / float64(n) * 1e9)
+}
+
+// SetSessions replaces the sessions of given time.Weekday.
+// Sessions are expected to not overlap and to be within a single day.
+// Models using the calendar must set it again to rescale their data.
+func (c *SessionCalendar) SetSessions(d time.Weekday, s ...Session) {
+	ss := append([]Session{}, s...)
+	sort.Slice(ss, func(i, j int) bool { return ss[i].Open < ss[j].Open })
+	c.week[d] = ss
+	c.dayTotal[d] = 0
+	for _, s := range ss {
+		c.dayTotal[d] += s.length().Seconds()
+	}
+	c.total = 0
+	for _, t := range c.dayTotal {
+		c.total += t
+	}
+	c.updateHolidays()
+}
+
+// Sessions returns the sessions of given time.Weekday.
+func (c *SessionCalendar) Sessions(d time.Weekday) []Session {
+	return append([]Session{}, c.week[d]...)
+}
+
+// AddHolidays adds the dates of given times in the calendar
+// time.Location as holidays without any sessions.
+// Models using the calendar must set it again to rescale their data.
+func (c *SessionCalendar) AddHolidays(ts ...time.Time) {
+	for _, t := range ts {
+		d := c.day(t)
+		i := sort.Search(len(c.holidays), func(i int) bool { return c.holidays[i] >= d })
+		if (i < len(c.holidays)) && (c.holidays[i] == d) {
+			continue
+		}
+		c.holidays = append(c.holidays, 0)
+		copy(c.holidays[i+1:], c.holidays[i:])
+		c.holidays[i] = d
+	}
+	c.updateHolidays()
+}
+
+// ClearHolidays removes all holidays.
+// Models using the calendar must set it again to rescale their data.
+func (c *SessionCalendar) ClearHolidays() {
+	c.holidays = nil
+	c.updateHolidays()
+}
+
+// updateHolidays recomputes the cumulative session seconds of holidays.
+func (c *SessionCalendar) updateHolidays() {
+	c.holidaySums = make([]float64, len(c.holidays)+1)
+	for i, d := range c.holidays {
+		c.holidaySums[i+1] = c.holidaySums[i] + c.dayTotal[weekday(d)]
+	}
+}
+
+// isHoliday returns whether given day since Unix epoch is a holiday.
+func (c *SessionCalendar) isHoliday(d int64) bool {
+	i := sort.Search(len(c.holidays), func(i int) bool { return c.holidays[i] >= d })
+	return (i < len(c.holidays)) && (c.holidays[i] == d)
+}
+
+// InSession returns whether given time is during a session.
+func (c *SessionCalendar) InSession(t time.Time) bool {
+	t = t.In(c.loc)
+	d := c.day(t)
+	if c.isHoliday(d) {
+		return false
+	}
+	tod := wallClock(t)
+	for _, s := range c.week[weekday(d)] {
+		if (tod >= s.Open) && (tod < s.Close) {
+			return true
+		}
+	}
+	return false
+}
+
+// SessionTime returns the total seconds of sessions between
+// a reference time and given time, which is negative if the given
+// time is before the reference time.  Times outside of sessions
+// return the session time of the next session open.
+// If there are no sessions, then returns seconds since the Unix epoch.
+func (c *SessionCalendar) SessionTime(t time.Time) float64 {
+	if c.total <= 0 {
+		return timeToX(unixEpoch, t)
+	}
+	t = t.In(c.loc)
+	d := c.day(t)
+	r := c.dayStart(d)
+	if c.isHoliday(d) {
+		return r
+	}
+	tod := wallClock(t)
+	for _, s := range c.week[weekday(d)] {
+		if tod <= s.Open {
+			break
+		}
+		r += (min(tod, s.Close) - s.Open).Seconds()
+	}
+	return r
+}
+
+// WallTime returns the wall clock time of given session time.
+// Session times at the close of a session return the next session open.
+// If there are no sessions, then given session time is seconds since the Unix epoch.
+func (c *SessionCalendar) WallTime(s float64) time.Time {
+	if c.total <= 0 {
+		return xToTime(unixEpoch, s).In(c.loc)
+	}
+	// estimate day from number of weeks then find
+	// the last day starting at or before session time
+	d := int64(math.Floor(s/c.total)) * 7
+	for c.dayStart(d) > s {
+		d -= 7
+	}
+	for c.dayStart(d+7) <= s {
+		d += 7
+	}
+	for c.dayStart(d+1) <= s {
+		d++
+	}
+	rem := time.Duration(math.Round((s - c.dayStart(d)) * 1e9))
+	for _, ss := range c.week[weekday(d)] {
+		if rem < ss.length() {
+			return c.date(d, ss.Open+rem)
+		}
+		rem -= ss.length()
+	}
+	return c.date(d+1, 0) // not expected, as session time is before next day
+}
+
+// dayStart returns the session time at the start of given day since Unix epoch.
+// The reference time is the start of the week containing the Unix epoch.
+func (c *SessionCalendar) dayStart(d int64) float64 {
+	// Unix epoch is a Thursday, and weeks start on Sundays
+	days := d + int64(time.Thursday)
+	weeks := floorDiv(days, 7)
+	r := float64(weeks) * c.total
+	for wd := int64(0); wd < days-weeks*7; wd++ {
+		r += c.dayTotal[wd]
+	}
+	i := sort.Search(len(c.holidays), func(i int) bool { return c.holidays[i] >= d })
+	return r - c.holidaySums[i]
+}
+
+// day returns the number of days since the Unix epoch
+// of the date of given time in the calendar time.Location.
+func (c *SessionCalendar) day(t time.Time) int64 {
+	y, m, d := t.In(c.loc).Date()
+	return floorDiv(time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix(), 86400)
+}
+
+// date returns the time of given wall clock time since
+// midnight of given day since the Unix epoch.
+func (c *SessionCalendar) date(d int64, tod time.Duration) time.Time {
+	y, m, dd := time.Unix(d*86400, 0).UTC().Date()
+	return time.Date(y, m, dd, 0, 0, 0, int(tod), c.loc)
+}
+
+// weekday returns the time.Weekday of given day since the Unix epoch.
+func weekday(d int64) time.Weekday {
+	return time.Weekday((d%7 + 7 + int64(time.Thursday)) % 7)
+}
+
+// wallClock returns the wall clock time since midnight of given time.
+func wallClock(t time.Time) time.Duration {
+	h, m, s := t.Clock()
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute +
+		time.Duration(s)*time.Second + time.Duration(t.Nanosecond())
+}
+
+// floorDiv returns the floor of a divided by positive b.
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if (a%b != 0) && (a < 0) {
+		q--
+	}
+	return q
+}
+
+// sessionTransform is a linechart.AxisTransform mapping X values
+// as seconds since an epoch to session times of a SessionCalendar.
+type sessionTransform struct {
+	cal   *SessionCalendar
+	epoch time.Time
+}
+
+// Forward returns the session time of given X value.
+func (t sessionTransform) Forward(v float64) float64 {
+	return t.cal.SessionTime(xToTime(t.epoch, v))
+}
+
+// Inverse returns the X value of given session time.
+func (t sessionTransform) Inverse(v float64) float64 {
+	return timeToX(t.epoch, t.cal.WallTime(v))
+}
+
+// Valid returns whether given X value is not NaN.
+func (t sessionTransform) Valid(v float64) bool {
+	return !math.IsNaN(v)
+}
+
+// SetSessionCalendar sets the SessionCalendar used to compress the X axis
+// to only display times during trading sessions. Scaling, labels and
+// moving the viewport of the X axis use session times.
+// If nil, then the X axis displays all times.
+// Replaces the X axis AxisTransform.  Changing the sessions or holidays
+// of the SessionCalendar does not rescale existing data, so the
+// SessionCalendar should be set again after such changes.
+func (m *Model) SetSessionCalendar(c *SessionCalendar) {
+	m.sessions = c
+	if c == nil {
+		m.SetXTransform(nil)
+	} else {
+		m.SetXTransform(sessionTransform{cal: c, epoch: m.epoch})
+	}
+}
+
+// SessionCalendar returns the SessionCalendar compressing the X axis, or nil.
+func (m *Model) SessionCalendar() *SessionCalendar {
+	return m.sessions
+}
@@ -0,0 +1,137 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package timeserieslinechart
+
+import (
+	"testing"
+	"time"
+)
+
+// testSessionCalendar returns a SessionCalendar of weekday sessions from 9:30 to 16:00 in EST.
+func testSessionCalendar() (*SessionCalendar, *time.Location) {
+	loc := time.FixedZone("EST", -5*3600)
+	return NewWeekdaySessionCalendar(loc, 9*time.Hour+30*time.Minute, 16*time.Hour), loc
+}
+
+// nearTime returns whether given times are within a microsecond,
+// as session times of present-day times are float64 seconds.
+func nearTime(a, b time.Time) bool {
+	d := a.Sub(b)
+	return (d > -time.Microsecond) && (d < time.Microsecond)
+}
+
+func TestSessionTimeRoundTrip(t *testing.T) {
+	c, loc := testSessionCalendar()
+	starts := []time.Time{
+		time.Date(2024, 1, 1, 0, 0, 0, 0, loc),
+		time.Date(1969, 12, 22, 0, 0, 0, 0, loc), // weeks before and after the Unix epoch
+		time.Date(1965, 3, 1, 0, 0, 0, 0, loc),
+	}
+	for _, start := range starts {
+		last := c.SessionTime(start)
+		for tm := start; tm.Before(start.AddDate(0, 0, 21)); tm = tm.Add(17*time.Minute + 3) {
+			s := c.SessionTime(tm)
+			if s < last {
+				t.Fatalf("session time decreased at %v:%f < %f", tm, s, last)
+			}
+			if c.InSession(tm) {
+				if s == last {
+					t.Fatalf("session time not increasing in session at %v", tm)
+				}
+				if wt := c.WallTime(s); !nearTime(wt, tm) {
+					t.Fatalf("time did not round trip:%v expected %v", wt, tm)
+				}
+			} else if wt := c.WallTime(s); !c.InSession(wt) || wt.Before(tm) {
+				t.Fatalf("time %v outside session not mapped to next open:%v", tm, wt)
+			}
+			last = s
+		}
+	}
+	if s := c.SessionTime(time.Date(1969, 12, 26, 12, 0, 0, 0, loc)); s >= 0 {
+		t.Errorf("session time before reference time not negative:%f", s)
+	}
+}
+
+func TestSessionWeekend(t *testing.T) {
+	c, loc := testSessionCalendar()
+	friClose := time.Date(2024, 1, 12, 16, 0, 0, 0, loc)
+	monOpen := time.Date(2024, 1, 15, 9, 30, 0, 0, loc)
+	open := c.SessionTime(monOpen)
+	for _, tm := range []time.Time{friClose, friClose.Add(time.Hour), time.Date(2024, 1, 13, 12, 0, 0, 0, loc), monOpen} {
+		if s := c.SessionTime(tm); s != open {
+			t.Errorf("wrong session time of %v:%f expected %f", tm, s, open)
+		}
+	}
+	if d := open - c.SessionTime(friClose.Add(-time.Hour)); d != 3600 {
+		t.Errorf("weekend not skipped:%f", d)
+	}
+	if wt := c.WallTime(open); !wt.Equal(monOpen) {
+		t.Errorf("wrong wall time of session close:%v", wt)
+	}
+	if c.InSession(time.Date(2024, 1, 13, 12, 0, 0, 0, loc)) {
+		t.Error("saturday in session")
+	}
+}
+
+func TestSessionHolidays(t *testing.T) {
+	c, loc := testSessionCalendar()
+	friLast := time.Date(2024, 1, 12, 15, 0, 0, 0, loc)
+	monNoon := time.Date(2024, 1, 15, 12, 0, 0, 0, loc)
+	tueOpen := time.Date(2024, 1, 16, 9, 30, 0, 0, loc)
+	later := time.Date(2024, 3, 1, 12, 0, 0, 0, loc)
+	before := c.SessionTime(later)
+
+	c.AddHolidays(monNoon, monNoon.Add(time.Hour)) // same date added once
+	if len(c.holidays) != 1 {
+		t.Fatalf("wrong holidays:%v", c.holidays)
+	}
+	if c.InSession(monNoon) {
+		t.Error("holiday in session")
+	}
+	if s := c.SessionTime(monNoon); s != c.SessionTime(tueOpen) {
+		t.Errorf("holiday not mapped to next open:%f", s)
+	}
+	if d := c.SessionTime(tueOpen) - c.SessionTime(friLast); d != 3600 {
+		t.Errorf("holiday not skipped:%f", d)
+	}
+	if wt := c.WallTime(c.SessionTime(friLast.Add(time.Hour))); !wt.Equal(tueOpen) {
+		t.Errorf("wrong wall time after holiday:%v", wt)
+	}
+	if d := before - c.SessionTime(later); d != 6.5*3600 {
+		t.Errorf("later session times not shifted by holiday:%f", d)
+	}
+	if wt := c.WallTime(c.SessionTime(later)); !wt.Equal(later) {
+		t.Errorf("time after holiday did not round trip:%v", wt)
+	}
+
+	c.ClearHolidays()
+	if !c.InSession(monNoon) || (c.SessionTime(later) != before) {
+		t.Error("holidays not cleared")
+	}
+}
+
+func TestSessionTransform(t *testing.T) {
+	c, loc := testSessionCalendar()
+	m := New(20, 10, WithEpoch(testTime), WithSessionCalendar(c))
+	at := m.XTransform()
+	if _, ok := at.(sessionTransform); !ok {
+		t.Fatalf("wrong X transform:%T", at)
+	}
+	tm := time.Date(2024, 1, 2, 10, 15, 0, 500, loc)
+	x := timeToX(testTime, tm)
+	v := at.Forward(x)
+	if v != c.SessionTime(tm) {
+		t.Errorf("wrong forward value:%f expected %f", v, c.SessionTime(tm))
+	}
+	if rx := at.Inverse(v); !nearTime(xToTime(testTime, rx), tm) {
+		t.Errorf("X value did not round trip:%v", xToTime(testTime, rx))
+	}
+
+	// changes to the calendar rescale data once the calendar is set again
+	m.Push(TimePoint{Time: tm, Value: 1})
+	c.AddHolidays(time.Date(2024, 1, 1, 0, 0, 0, 0, loc))
+	m.SetSessionCalendar(c)
+	if at := m.XTransform(); at.Forward(x) != v-6.5*3600 {
+		t.Errorf("holiday not applied:%f", at.Forward(x))
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/NimbleMarkets/ntcharts/canvas/buffer"
 	"github.com/NimbleMarkets/ntcharts/canvas/graph"
 	"github.com/NimbleMarkets/ntcharts/canvas/runes"
+	"github.com/NimbleMarkets/ntcharts/legend"
 	"github.com/NimbleMarkets/ntcharts/linechart"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -24,13 +25,30 @@ import (
 
 const DefaultDataSetName = "default"
 
+// DateTimeLabelFormatter returns a LabelFormatter displaying
+// month and day of X values in UTC, prefixed with the year
+// the first time the year is displayed.
 func DateTimeLabelFormatter() linechart.LabelFormatter {
+	return DateTimeLabelFormatterIn(time.UTC)
+}
+
+// DateTimeLabelFormatterIn returns a LabelFormatter displaying
+// month and day of X values in given time.Location, prefixed
+// with the year the first time the year is displayed.
+func DateTimeLabelFormatterIn(loc *time.Location) linechart.LabelFormatter {
+	return dateTimeLabelFormatter(loc, unixEpoch)
+}
+
+// dateTimeLabelFormatter returns a LabelFormatter displaying
+// month and day of X values as seconds since given epoch in given time.Location,
+// prefixed with the year the first time the year is displayed.
+func dateTimeLabelFormatter(loc *time.Location, epoch time.Time) linechart.LabelFormatter {
 	var yearLabel string
 	return func(i int, v float64) string {
 		if i == 0 { // reset year labeling if redisplaying values
 			yearLabel = ""
 		}
-		t := time.Unix(int64(v), 0).UTC()
+		t := xToTime(epoch, math.Floor(v)).In(loc)
 		monthDay := t.Format("01/02")
 		year := t.Format("'06")
 		if yearLabel != year { // apply year label if first time seeing year
@@ -42,13 +60,44 @@ func DateTimeLabelFormatter() linechart.LabelFormatter {
 	}
 }
 
+// HourTimeLabelFormatter returns a LabelFormatter
+// displaying the time of day of X values in UTC.
 func HourTimeLabelFormatter() linechart.LabelFormatter {
+	return HourTimeLabelFormatterIn(time.UTC)
+}
+
+// HourTimeLabelFormatterIn returns a LabelFormatter displaying
+// the time of day of X values in given time.Location.
+func HourTimeLabelFormatterIn(loc *time.Location) linechart.LabelFormatter {
+	return TimeLabelFormatter("15:04:05", loc, unixEpoch)
+}
+
+// TimeLabelFormatter returns a LabelFormatter displaying X values
+// as seconds since given epoch in given time.Location using
+// given time layout, such as "15:04:05.000" for milliseconds.
+func TimeLabelFormatter(layout string, loc *time.Location, epoch time.Time) linechart.LabelFormatter {
 	return func(i int, v float64) string {
-		t := time.Unix(int64(v), 0).UTC()
-		return t.Format("15:04:05")
+		return xToTime(epoch, v).In(loc).Format(layout)
 	}
 }
 
+// unixEpoch is the default epoch of X values.
+var unixEpoch = time.Unix(0, 0)
+
+// timeToX returns the X value of given time.Time
+// as the number of seconds since given epoch.
+func timeToX(epoch, t time.Time) float64 {
+	return float64(t.Unix()-epoch.Unix()) + float64(t.Nanosecond()-epoch.Nanosecond())/1e9
+}
+
+// xToTime returns the time.Time of given X value as the number of
+// seconds since given epoch, rounded to the nearest nanosecond.
+func xToTime(epoch time.Time, v float64) time.Time {
+	sec := math.Floor(v)
+	nsec := math.Round((v - sec) * 1e9)
+	return time.Unix(epoch.Unix()+int64(sec), int64(epoch.Nanosecond())+int64(nsec))
+}
+
 type TimePoint struct {
 	Time  time.Time
 	Value float64
@@ -67,14 +116,30 @@ func (a *cAverage) Add(f float64) float64 {
 	return a.Avg
 }
 
+// EvictHandler is a callback invoked with the data set name
+// and the TimePoint being removed from a data set due to
+// the data set retention limits.
+type EvictHandler func(n string, t TimePoint)
+
 type dataSet struct {
 	LineStyle runes.LineStyle // type of line runes to draw
 	Style     lipgloss.Style
 
+	aggregation Aggregation // method combining TimePoints in the same column
+
+	maxGap time.Duration // maximum time between TimePoints without breaking the line, 0 if unlimited
+
 	// stores TimePoints as FloatPoint64{X:time.Time, Y: value}
 	// time.Time will be converted to seconds since epoch.
-	// both time and value will be scaled to fit the graphing area
-	tBuf *buffer.Float64PointScaleBuffer
+	// both time and value will be scaled to fit the graphing area.
+	// buffer grows as needed and removes TimePoints exceeding retention limits
+	tBuf *buffer.RetentionRingBuffer
+}
+
+// scaledYOffset returns the Y offset of the data set scaled
+// by the Y scale factor of the data set.
+func (ds *dataSet) scaledYOffset() float64 {
+	return ds.tBuf.Offset().Y * ds.tBuf.Scale().Y
 }
 
 // Model contains state of a timeserieslinechart with an embedded linechart.Model
@@ -88,7 +153,44 @@ type Model struct {
 	linechart.Model
 	dLineStyle runes.LineStyle     // default data set LineStyletype
 	dStyle     lipgloss.Style      // default data set Style
+	dAgg       Aggregation         // default data set Aggregation
+	dMaxPoints int                 // default data set maximum number of TimePoints
+	dMaxAge    time.Duration       // default data set maximum age of TimePoints
+	dMaxGap    time.Duration       // default data set maximum time between TimePoints
 	dSets      map[string]*dataSet // maps names to data sets
+
+	linechart.DataSetVisibility // visibility and z-order of data sets
+
+	dCandleType     CandleType            // default candle set CandleType
+	dBullStyle      lipgloss.Style        // default candle set style of bullish candles
+	dBearStyle      lipgloss.Style        // default candle set style of bearish candles
+	dCandleInterval time.Duration         // default candle set time interval of each candle
+	cSets           map[string]*candleSet // maps names to candle sets
+
+	links  map[string][]linkedIndicator // maps source data set names to linked Indicators
+	inPush map[string]bool              // names of data sets being pushed to linked Indicators
+	cLinks map[string][]linkedIndicator // maps source candle set names to linked Indicators
+	bands  []band                       // regions filled between data sets
+
+	evictHandler EvictHandler // callback for TimePoints removed by retention limits
+
+	downsample graph.DownsampleFunc // reduces data points to draw, nil to draw all
+
+	fitPending bool // whether pushed data values are not yet fitted by auto fit
+
+	epoch     time.Time      // time of X value 0
+	location  *time.Location // location of time ticks, nil for UTC
+	timeTicks bool           // whether X axis values are placed by TimeTicks
+
+	customXLabels bool // whether X label formatter was set by SetXLabelFormatter
+
+	sessions *SessionCalendar // compresses X axis to trading sessions, nil for all times
+
+	hoverLayout string // time layout of hover tooltips and cursor readouts
+
+	cursorSet    string  // name of data set of the data cursor
+	cursorX      float64 // X value of TimePoint of the data cursor
+	cursorPlaced bool    // whether the data cursor is placed on a TimePoint
 }
 
 // New returns a timeserieslinechart Model initialized from
@@ -108,6 +210,14 @@ func New(w, h int, opts ...Option) Model {
 		dLineStyle: runes.ArcLineStyle,
 		dStyle:     lipgloss.NewStyle(),
 		dSets:      make(map[string]*dataSet),
+		dBullStyle: lipgloss.NewStyle(),
+		dBearStyle: lipgloss.NewStyle(),
+		cSets:      make(map[string]*candleSet),
+		links:      make(map[string][]linkedIndicator),
+		cLinks:     make(map[string][]linkedIndicator),
+		epoch:      unixEpoch,
+
+		hoverLayout: DefaultHoverTimeLayout,
 	}
 	for _, opt := range opts {
 		opt(&m)
@@ -122,41 +232,134 @@ func New(w, h int, opts ...Option) Model {
 
 // newDataSet returns a new initialize *dataSet.
 func (m *Model) newDataSet() *dataSet {
-	xs := float64(m.GraphWidth()) / (m.ViewMaxX() - m.ViewMinX()) // x scale factor
-	ys := float64(m.Origin().Y) / (m.ViewMaxY() - m.ViewMinY())   // y scale factor
-	offset := canvas.Float64Point{X: m.ViewMinX(), Y: m.ViewMinY()}
-	scale := canvas.Float64Point{X: xs, Y: ys}
-	return &dataSet{
-		LineStyle: m.dLineStyle,
-		Style:     m.dStyle,
-		tBuf:      buffer.NewFloat64PointScaleBuffer(offset, scale),
+	offset, scale := m.dataScale()
+	ds := &dataSet{
+		LineStyle:   m.dLineStyle,
+		Style:       m.dStyle,
+		aggregation: m.dAgg,
+		maxGap:      m.dMaxGap,
+		tBuf:        buffer.NewRetentionRingBuffer(offset, scale, m.PointScaleFunc()),
 	}
+	ds.tBuf.SetMaxPoints(m.dMaxPoints, nil)
+	ds.tBuf.SetMaxXAge(m.dMaxAge.Seconds(), nil)
+	return ds
+}
+
+// getDataSet returns the data set given by name string,
+// creating a new data set if it does not exist.
+func (m *Model) getDataSet(n string) *dataSet {
+	if _, ok := m.dSets[n]; !ok {
+		m.dSets[n] = m.newDataSet()
+	}
+	return m.dSets[n]
+}
+
+// evictFunc returns a function reporting data points removed
+// from the data set given by name string to the EvictHandler.
+// Returns nil if there is no EvictHandler.
+func (m *Model) evictFunc(n string) buffer.EvictFunc {
+	if m.evictHandler == nil {
+		return nil
+	}
+	return func(f canvas.Float64Point) {
+		m.evictHandler(n, TimePoint{Time: m.XTime(f.X), Value: f.Y})
+	}
+}
+
+// dataScale returns the offset and scale factor used
+// to scale data points in the transformed space of the axes
+// to the graphing area.
+func (m *Model) dataScale() (offset, scale canvas.Float64Point) {
+	offset = canvas.Float64Point{X: m.TransformX(m.ViewMinX()), Y: m.TransformY(m.ViewMinY())}
+	scale.X = float64(m.GraphWidth()) / (m.TransformX(m.ViewMaxX()) - offset.X) // x scale factor
+	scale.Y = float64(m.Origin().Y) / (m.TransformY(m.ViewMaxY()) - offset.Y)   // y scale factor
+	return
 }
 
 // rescaleData will reinitialize time chunks and
 // map time points into graph columns for display
 func (m *Model) rescaleData() {
 	// rescale time points buffer
-	xs := float64(m.GraphWidth()) / (m.ViewMaxX() - m.ViewMinX()) // x scale factor
-	ys := float64(m.Origin().Y) / (m.ViewMaxY() - m.ViewMinY())   // y scale factor
-	offset := canvas.Float64Point{X: m.ViewMinX(), Y: m.ViewMinY()}
-	scale := canvas.Float64Point{X: xs, Y: ys}
+	offset, scale := m.dataScale()
 	for _, ds := range m.dSets {
-		if ds.tBuf.Offset() != offset {
-			ds.tBuf.SetOffset(offset)
+		if (ds.tBuf.Offset() != offset) || (ds.tBuf.Scale() != scale) {
+			ds.tBuf.SetOffsetAndScale(offset, scale) // buffer rescales all raw data points once
 		}
-		if ds.tBuf.Scale() != scale {
-			ds.tBuf.SetScale(scale)
+	}
+}
+
+// fitYRange fits the displayed Y values to the values of visible data sets
+// and the high and low values of candle sets within the displayed X values
+// if auto fit is enabled.
+func (m *Model) fitYRange() {
+	m.fitPending = false
+	if !m.AutoFitY() {
+		return
+	}
+	if m.FitViewYRange(m.yRange(m.ViewMinX(), m.ViewMaxX())) {
+		m.rescaleData()
+	}
+}
+
+// fitPushed fits the displayed Y values if data values have been pushed
+// since the last fit.  Pushing data values only marks the fit as pending,
+// such that pushing many data values fits once when drawing or in Update.
+func (m *Model) fitPushed() {
+	if m.fitPending {
+		m.fitYRange()
+	}
+}
+
+// yRange returns the minimum and maximum values of visible data sets
+// and the high and low values of candle sets between given X values,
+// which are infinite if there are no such values.
+func (m *Model) yRange(minX, maxX float64) (lo, hi float64) {
+	yt := m.YTransform()
+	lo, hi = math.Inf(1), math.Inf(-1)
+	add := func(v float64) {
+		if isFinite(v) && yt.Valid(v) {
+			lo = math.Min(lo, v)
+			hi = math.Max(hi, v)
+		}
+	}
+	for n, ds := range m.dSets {
+		if !m.DataSetVisible(n) {
+			continue
+		}
+		b := ds.tBuf
+		i := sort.Search(b.Length(), func(i int) bool { return b.AtRaw(i).X >= minX })
+		for ; (i < b.Length()) && (b.AtRaw(i).X <= maxX); i++ {
+			add(b.AtRaw(i).Y)
+		}
+	}
+	for _, cs := range m.cSets {
+		candles := cs.candles
+		if cs.candleType == CandleHeikinAshi {
+			candles = cs.ha
+		}
+		i := sort.Search(len(candles), func(i int) bool { return m.TimeX(candles[i].Time) >= minX })
+		for _, c := range candles[i:] {
+			if m.TimeX(c.Time) > maxX {
+				break
+			}
+			if isFinite(c.Open, c.High, c.Low, c.Close) {
+				add(c.High)
+				add(c.Low)
+			}
 		}
 	}
+	return
 }
 
-// ClearAllData will reset stored data values in all data sets.
+// ClearAllData will reset stored data values in all data sets and candle sets.
 func (m *Model) ClearAllData() {
 	for _, ds := range m.dSets {
 		ds.tBuf.Clear()
 	}
 	m.dSets[DefaultDataSetName] = m.newDataSet()
+	for n := range m.cSets {
+		m.ClearCandleSet(n)
+	}
 }
 
 // ClearDataSet will erase stored data set given by name string.
@@ -166,10 +369,141 @@ func (m *Model) ClearDataSet(n string) {
 	}
 }
 
+// DataSetNames returns the names of all data sets sorted by name.
+func (m *Model) DataSetNames() []string {
+	names := make([]string, 0, len(m.dSets))
+	for n := range m.dSets {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// HasDataSet returns whether the data set given by name string exists.
+func (m *Model) HasDataSet(n string) bool {
+	_, ok := m.dSets[n]
+	return ok
+}
+
+// DataSetLen returns the number of data values stored
+// by the data set given by name string.
+func (m *Model) DataSetLen(n string) int {
+	if ds, ok := m.dSets[n]; ok {
+		return ds.tBuf.Length()
+	}
+	return 0
+}
+
+// DataSetStyles returns the LineStyle and Style of the data set
+// given by name string, or the default styles if it does not exist.
+func (m *Model) DataSetStyles(n string) (runes.LineStyle, lipgloss.Style) {
+	if ds, ok := m.dSets[n]; ok {
+		return ds.LineStyle, ds.Style
+	}
+	return m.dLineStyle, m.dStyle
+}
+
+// TimePoints returns the TimePoints of the default data set.
+func (m *Model) TimePoints() []TimePoint {
+	return m.DataSetTimePoints(DefaultDataSetName)
+}
+
+// DataSetTimePoints returns the TimePoints of the data set
+// given by name string in chronological order.
+func (m *Model) DataSetTimePoints(n string) []TimePoint {
+	ds, ok := m.dSets[n]
+	if !ok {
+		return []TimePoint{}
+	}
+	raw := ds.tBuf.ReadAllRaw()
+	r := make([]TimePoint, len(raw))
+	for i, f := range raw {
+		r[i] = TimePoint{Time: m.XTime(f.X), Value: f.Y}
+	}
+	return r
+}
+
+// RemoveDataSet will delete the data set given by name string
+// including its styles, Indicators linked to it and its bands.
+// Linked Indicators no longer push to the removed data set.
+// The default data set is replaced by a new empty data set
+// with the default styles.
+func (m *Model) RemoveDataSet(n string) {
+	delete(m.dSets, n)
+	m.DataSetVisibility.RemoveDataSet(n)
+	if n == DefaultDataSetName {
+		m.dSets[DefaultDataSetName] = m.newDataSet()
+	}
+	m.removeLinks(n)
+}
+
+// RenameDataSet will rename the data set given by old name string
+// to the new name string, keeping its data, styles, linked Indicators
+// and bands.  Renaming the default data set leaves a new empty default data set.
+// Returns false without renaming if the old data set does not exist
+// or a data set with the new name exists.
+func (m *Model) RenameDataSet(old, new string) bool {
+	ds, ok := m.dSets[old]
+	if _, exists := m.dSets[new]; !ok || exists {
+		return false
+	}
+	delete(m.dSets, old)
+	m.dSets[new] = ds
+	m.DataSetVisibility.RenameDataSet(old, new)
+	if old == DefaultDataSetName {
+		m.dSets[DefaultDataSetName] = m.newDataSet()
+	}
+	m.renameLinks(old, new)
+	return true
+}
+
 // SetTimeRange updates the minimum and maximum expected time values.
 // Existing data will be rescaled.
 func (m *Model) SetTimeRange(min, max time.Time) {
-	m.Model.SetXRange(float64(min.Unix()), float64(max.Unix()))
+	m.Model.SetXRange(m.TimeX(min), m.TimeX(max))
+	m.rescaleData()
+}
+
+// TimeX returns the X value of given time.Time as the
+// number of seconds since the epoch of the Model.
+func (m *Model) TimeX(t time.Time) float64 {
+	return timeToX(m.epoch, t)
+}
+
+// XTime returns the time.Time of given X value as the
+// number of seconds since the epoch of the Model.
+func (m *Model) XTime(v float64) time.Time {
+	return xToTime(m.epoch, v)
+}
+
+// Epoch returns the time.Time of X value 0.
+func (m *Model) Epoch() time.Time {
+	return m.epoch
+}
+
+// SetEpoch updates the time.Time of X value 0, which defaults to the Unix epoch.
+// Since X values are float64 seconds since the epoch, using an epoch near the
+// displayed times retains nanosecond precision of present-day times.
+// Existing data and value ranges will be shifted to the new epoch,
+// and the X label formatter is replaced with the default time labels
+// of the Model using the new epoch unless set by WithXLabelFormatter.
+func (m *Model) SetEpoch(t time.Time) {
+	d := timeToX(t, m.epoch)
+	m.epoch = t
+	for _, ds := range m.dSets {
+		raw := ds.tBuf.ReadAllRaw()
+		ds.tBuf.Clear()
+		for _, f := range raw {
+			ds.tBuf.Push(canvas.Float64Point{X: f.X + d, Y: f.Y}, nil)
+		}
+	}
+	viewMin, viewMax := m.ViewMinX()+d, m.ViewMaxX()+d
+	m.Model.SetXRange(m.MinX()+d, m.MaxX()+d)
+	m.Model.SetViewXRange(viewMin, viewMax)
+	if m.sessions != nil { // session transform uses epoch
+		m.SetSessionCalendar(m.sessions)
+	}
+	m.updateTimeTicks()
 	m.rescaleData()
 }
 
@@ -181,10 +515,20 @@ func (m *Model) SetYRange(min, max float64) {
 }
 
 // SetViewTimeRange updates the displayed minimum and maximum time values.
-// Existing data will be rescaled.
+// Existing data will be rescaled and Y values fitted if auto fit is enabled.
 func (m *Model) SetViewTimeRange(min, max time.Time) {
-	m.Model.SetViewXRange(float64(min.Unix()), float64(max.Unix()))
+	m.Model.SetViewXRange(m.TimeX(min), m.TimeX(max))
+	m.rescaleData()
+	m.fitYRange()
+}
+
+// SetViewXRange updates the displayed minimum and maximum X values,
+// which are seconds since the epoch. Existing data will be rescaled
+// and Y values fitted if auto fit is enabled.
+func (m *Model) SetViewXRange(min, max float64) {
+	m.Model.SetViewXRange(min, max)
 	m.rescaleData()
+	m.fitYRange()
 }
 
 // SetViewYRange updates the displayed minimum and maximum Y values.
@@ -194,12 +538,24 @@ func (m *Model) SetViewYRange(min, max float64) {
 	m.rescaleData()
 }
 
+// SetViewXYRange updates the displayed minimum and maximum X and Y values,
+// where X values are seconds since the epoch. Existing data will be rescaled.
+// Y values are replaced by fitted Y values if auto fit is enabled.
+func (m *Model) SetViewXYRange(minX, maxX, minY, maxY float64) {
+	m.Model.SetViewXRange(minX, maxX)
+	m.Model.SetViewYRange(minY, maxY)
+	m.rescaleData()
+	m.fitYRange()
+}
+
 // SetViewTimeAndYRange updates the displayed minimum and maximum time and Y values.
 // Existing data will be rescaled.
+// Y values are replaced by fitted Y values if auto fit is enabled.
 func (m *Model) SetViewTimeAndYRange(minX, maxX time.Time, minY, maxY float64) {
-	m.Model.SetViewXRange(float64(minX.Unix()), float64(maxX.Unix()))
+	m.Model.SetViewXRange(m.TimeX(minX), m.TimeX(maxX))
 	m.Model.SetViewYRange(minY, maxY)
 	m.rescaleData()
+	m.fitYRange()
 }
 
 // Resize will change timeserieslinechart display width and height.
@@ -209,6 +565,36 @@ func (m *Model) Resize(w, h int) {
 	m.rescaleData()
 }
 
+// SetXTransform sets the X axis AxisTransform,
+// replacing any SessionCalendar. Existing data will be rescaled.
+func (m *Model) SetXTransform(t linechart.AxisTransform) {
+	if _, ok := t.(sessionTransform); !ok {
+		m.sessions = nil
+	}
+	m.Model.SetXTransform(t)
+	m.resetScaleFunc()
+	if m.timeTicks {
+		m.updateTimeTicks()
+	}
+}
+
+// SetYTransform sets the Y axis AxisTransform.
+// Existing data will be rescaled.
+func (m *Model) SetYTransform(t linechart.AxisTransform) {
+	m.Model.SetYTransform(t)
+	m.resetScaleFunc()
+}
+
+// resetScaleFunc updates the ScaleFunc of all data sets
+// to the current axes transforms and rescales all data.
+func (m *Model) resetScaleFunc() {
+	fn := m.PointScaleFunc()
+	for _, ds := range m.dSets {
+		ds.tBuf.SetScaleFunc(fn)
+	}
+	m.rescaleData()
+}
+
 // SetLineStyle will set the default line styles of data sets.
 func (m *Model) SetLineStyle(ls runes.LineStyle) {
 	m.dLineStyle = ls
@@ -239,6 +625,119 @@ func (m *Model) SetDataSetStyle(n string, s lipgloss.Style) {
 	ds.Style = s
 }
 
+// SetAggregation will set the default Aggregation of data sets
+// used when multiple TimePoints are mapped to the same graph column.
+func (m *Model) SetAggregation(a Aggregation) {
+	m.dAgg = a
+	m.SetDataSetAggregation(DefaultDataSetName, a)
+}
+
+// SetDataSetAggregation will set the Aggregation of the given data set by name string
+// used when multiple TimePoints are mapped to the same graph column.
+func (m *Model) SetDataSetAggregation(n string, a Aggregation) {
+	m.getDataSet(n).aggregation = a
+}
+
+// SetMaxPoints will set the default maximum number of TimePoints
+// stored by data sets. The oldest TimePoints will be removed
+// when pushing TimePoints beyond the limit.  If 0, then there is no limit.
+func (m *Model) SetMaxPoints(n int) {
+	m.dMaxPoints = n
+	m.SetDataSetMaxPoints(DefaultDataSetName, n)
+}
+
+// SetDataSetMaxPoints will set the maximum number of TimePoints
+// stored by the given data set by name string.
+// The oldest TimePoints exceeding the limit will be removed.
+// If 0, then there is no limit.
+func (m *Model) SetDataSetMaxPoints(n string, max int) {
+	m.getDataSet(n).tBuf.SetMaxPoints(max, m.evictFunc(n))
+}
+
+// SetMaxAge will set the default maximum age of TimePoints stored
+// by data sets relative to the newest TimePoint of each data set.
+// Older TimePoints will be removed when pushing newer TimePoints.
+// If 0, then there is no limit.
+func (m *Model) SetMaxAge(d time.Duration) {
+	m.dMaxAge = d
+	m.SetDataSetMaxAge(DefaultDataSetName, d)
+}
+
+// SetDataSetMaxAge will set the maximum age of TimePoints stored
+// by the given data set by name string relative to its newest TimePoint.
+// Older TimePoints will be removed. If 0, then there is no limit.
+func (m *Model) SetDataSetMaxAge(n string, d time.Duration) {
+	m.getDataSet(n).tBuf.SetMaxXAge(d.Seconds(), m.evictFunc(n))
+}
+
+// SetMaxGap will set the default maximum time between consecutive
+// TimePoints of data sets before breaking the line between them,
+// such as during feed outages or market closes.
+// If 0, then lines are only broken at NaN values.
+func (m *Model) SetMaxGap(d time.Duration) {
+	m.dMaxGap = d
+	m.SetDataSetMaxGap(DefaultDataSetName, d)
+}
+
+// SetDataSetMaxGap will set the maximum time between consecutive
+// TimePoints of the given data set by name string before breaking
+// the line between them. If 0, then lines are only broken at NaN values.
+func (m *Model) SetDataSetMaxGap(n string, d time.Duration) {
+	if d < 0 {
+		d = 0
+	}
+	m.getDataSet(n).maxGap = d
+}
+
+// SetEvictHandler will set the callback invoked for each TimePoint
+// removed from data sets due to data set retention limits.
+// If nil, then removed TimePoints are not reported.
+func (m *Model) SetEvictHandler(h EvictHandler) {
+	m.evictHandler = h
+}
+
+// SetDownsampleFunc will set the function used to reduce the number
+// of displayed data points of each data set to a number proportional
+// to the graph width before drawing. If nil, then all data points are drawn.
+func (m *Model) SetDownsampleFunc(f graph.DownsampleFunc) {
+	m.downsample = f
+}
+
+// SetDataSetVisible will set whether the data set given by name string
+// is drawn.  While hidden data sets are excluded from automatic range
+// adjustment, showing a data set adjusts the ranges to its data values
+// and hiding a data set resets the automatically adjusted Y values to
+// the data values of the visible data sets and candle sets.
+// Y values are fitted to the visible data sets if auto fit is enabled.
+func (m *Model) SetDataSetVisible(n string, b bool) {
+	ds := m.getDataSet(n)
+	if !m.DataSetVisibility.SetDataSetVisible(n, b) {
+		return
+	}
+	defer m.fitYRange()
+	if !m.ExcludeHiddenRange() {
+		return
+	}
+	adjusted := false
+	if b {
+		for _, f := range ds.tBuf.ReadAllRaw() {
+			adjusted = m.AutoAdjustRange(f) || adjusted
+		}
+	} else {
+		adjusted = m.ResetAutoYRange(m.yRange(math.Inf(-1), math.Inf(1)))
+	}
+	if adjusted {
+		m.UpdateGraphSizes()
+		m.rescaleData()
+	}
+}
+
+// ToggleDataSetVisible will hide the data set given by
+// name string if it is drawn, and show it otherwise.
+func (m *Model) ToggleDataSetVisible(n string) {
+	m.SetDataSetVisible(n, !m.DataSetVisible(n))
+}
+
 // Push will push a TimePoint data value to the default data set
 // to be displayed with Draw.
 func (m *Model) Push(t TimePoint) {
@@ -247,18 +746,19 @@ func (m *Model) Push(t TimePoint) {
 
 // Push will push a TimePoint data value to a data set
 // to be displayed with Draw. Using given data set by name string.
+// TimePoints with NaN or infinite values are displayed as gaps in the line.
+// The TimePoint is also pushed to Indicators linked to the data set.
 func (m *Model) PushDataSet(n string, t TimePoint) {
-	f := canvas.Float64Point{X: float64(t.Time.Unix()), Y: t.Value}
+	f := canvas.Float64Point{X: m.TimeX(t.Time), Y: t.Value}
+	ds := m.getDataSet(n)
 	// auto adjust x and y ranges if enabled
-	if m.AutoAdjustRange(f) {
+	if m.DataSetAdjustsRange(n) && m.AutoAdjustRange(f) {
 		m.UpdateGraphSizes()
 		m.rescaleData()
 	}
-	if _, ok := m.dSets[n]; !ok {
-		m.dSets[n] = m.newDataSet()
-	}
-	ds := m.dSets[n]
-	ds.tBuf.Push(f)
+	ds.tBuf.Push(f, m.evictFunc(n))
+	m.pushLinks(n, t)
+	m.fitPending = true
 }
 
 // Draw will draw lines runes displayed from left to right
@@ -267,53 +767,157 @@ func (m *Model) Draw() {
 	m.DrawDataSets([]string{DefaultDataSetName})
 }
 
-// DrawAll will draw lines runes for all data sets
-// from left to right of the graphing area of the canvas.
+// DrawAll will draw candles for all candle sets and then lines runes
+// for all visible data sets in z-order from left to right of the
+// graphing area of the canvas.
 func (m *Model) DrawAll() {
-	names := make([]string, 0, len(m.dSets))
-	for n, ds := range m.dSets {
-		if ds.tBuf.Length() > 0 {
-			names = append(names, n)
+	names := m.visibleDataSets()
+	cNames := make([]string, 0, len(m.cSets))
+	for n, cs := range m.cSets {
+		if len(cs.candles) > 0 {
+			cNames = append(cNames, n)
 		}
 	}
-	sort.Strings(names)
-	m.DrawDataSets(names)
+	sort.Strings(cNames)
+	if len(names) == 0 && len(cNames) == 0 {
+		return
+	}
+	m.fitPushed()
+	m.Clear()
+	m.DrawXYAxisAndLabel()
+	m.drawBands()
+	for _, n := range cNames {
+		m.drawCandleSet(m.cSets[n])
+	}
+	m.drawDataSets(names)
 }
 
 // DrawDataSets will draw lines runes from left to right
-// of the graphing area of the canvas for each data set given
-// by name strings.
+// of the graphing area of the canvas for each visible data set
+// given by name strings in order.
 func (m *Model) DrawDataSets(names []string) {
 	if len(names) == 0 {
 		return
 	}
+	m.fitPushed()
 	m.Clear()
 	m.DrawXYAxisAndLabel()
+	m.drawBands()
+	m.drawDataSets(names)
+}
+
+// drawDataSets draws lines runes for each visible data set given by name strings.
+func (m *Model) drawDataSets(names []string) {
 	for _, n := range names {
-		if ds, ok := m.dSets[n]; ok {
-			dataPoints := ds.tBuf.ReadAll()
-			dataLen := len(dataPoints)
-			if dataLen == 0 {
-				return
+		if ds, ok := m.dSets[n]; ok && m.DataSetVisible(n) {
+			// two data points per column for line runes
+			for _, points := range m.drawPoints(ds, 2*m.GraphWidth()) {
+				m.drawLineSegment(points, ds)
 			}
-			// get sequence of line values for graphing
-			seqY := m.getLineSequence(dataPoints)
-			// convert to canvas coordinates and avoid drawing below X axis
-			yCoords := canvas.CanvasYCoordinates(m.Origin().Y, seqY)
-			if m.XStep() > 0 {
-				for i, v := range yCoords {
-					if v > m.Origin().Y {
-						yCoords[i] = m.Origin().Y
-					}
+		}
+	}
+}
+
+// drawLineSegment draws line runes through a given segment of scaled
+// data points of a data set from the first to the last data point.
+func (m *Model) drawLineSegment(points []canvas.Float64Point, ds *dataSet) {
+	// get sequence of line values for graphing
+	seqY := m.getLineSequence(points, ds.aggregation, ds.scaledYOffset())
+	first := max(int(math.Round(points[0].X)), 0)
+	last := min(int(math.Round(points[len(points)-1].X)), len(seqY)-1)
+	if first > last { // segment not displayed
+		return
+	}
+	// convert to canvas coordinates and avoid drawing below X axis
+	yCoords := canvas.CanvasYCoordinates(m.Origin().Y, seqY[first:last+1])
+	if m.XStep() > 0 {
+		for i, v := range yCoords {
+			if v > m.Origin().Y {
+				yCoords[i] = m.Origin().Y
+			}
+		}
+	}
+	startX := m.Canvas.Width() - len(seqY)
+	graph.DrawLineSequence(&m.Canvas,
+		(startX+first == m.Origin().X),
+		startX+first,
+		yCoords,
+		ds.LineStyle,
+		ds.Style)
+	if ds.aggregation == AggregateSpan {
+		m.drawSpans(startX, len(seqY), points, ds)
+	}
+}
+
+// drawSpans draws vertical line runes from the minimum to the maximum value
+// of each graph column starting from given X coordinate for given number
+// of columns from a given set of scaled []FloatPoint64.
+func (m *Model) drawSpans(startX, width int, points []canvas.Float64Point, ds *dataSet) {
+	cols := aggregateColumns(points, func(f canvas.Float64Point) int {
+		return int(math.Round(f.X))
+	})
+	for _, c := range cols {
+		if (c.col < 0) || (c.col >= width) {
+			continue
+		}
+		top := canvas.CanvasYCoordinate(m.Origin().Y, int(math.Round(c.max)))
+		bottom := canvas.CanvasYCoordinate(m.Origin().Y, int(math.Round(c.min)))
+		if m.XStep() > 0 { // avoid drawing below X axis
+			top = min(top, m.Origin().Y)
+			bottom = min(bottom, m.Origin().Y)
+		}
+		for y := top; y <= bottom; y++ {
+			graph.DrawLineRune(&m.Canvas,
+				canvas.Point{X: startX + c.col, Y: y},
+				runes.LineVertical, ds.LineStyle, ds.Style)
+		}
+	}
+}
+
+// DrawColumns will draw columns of block runes going up from the bottom
+// of the graphing area of the canvas with the values of the default data
+// set in each column, such as volumes.
+func (m *Model) DrawColumns() {
+	m.DrawColumnsDataSets([]string{DefaultDataSetName})
+}
+
+// DrawColumnsDataSets will draw columns of block runes going up from the
+// bottom of the graphing area of the canvas for each visible data set given
+// by name strings.  TimePoints in the same column are combined using the data set
+// Aggregation, where AggregateSum is commonly used for volumes.
+// AggregateDefault and AggregateSpan use the last value of each column.
+func (m *Model) DrawColumnsDataSets(names []string) {
+	if len(names) == 0 {
+		return
+	}
+	m.fitPushed()
+	m.Clear()
+	m.DrawXYAxisAndLabel()
+	startX := m.Origin().X
+	if m.YStep() > 0 {
+		startX += 1
+	}
+	bottom := m.Origin().Y
+	if m.XStep() > 0 {
+		bottom -= 1
+	}
+	for _, n := range names {
+		ds, ok := m.dSets[n]
+		if !ok || !m.DataSetVisible(n) {
+			continue
+		}
+		for _, points := range m.drawPoints(ds, 0) {
+			cols := aggregateColumns(points, func(f canvas.Float64Point) int {
+				return int(math.Floor(f.X))
+			})
+			for _, c := range cols {
+				if (c.col < 0) || (c.col >= m.GraphWidth()) {
+					continue
 				}
+				v := min(c.value(ds.aggregation, ds.scaledYOffset()), float64(m.GraphHeight()))
+				graph.DrawColumnBottomToTop(&m.Canvas,
+					canvas.Point{X: startX + c.col, Y: bottom}, v, ds.Style)
 			}
-			startX := m.Canvas.Width() - len(yCoords)
-			graph.DrawLineSequence(&m.Canvas,
-				(startX == m.Origin().X),
-				startX,
-				yCoords,
-				ds.LineStyle,
-				ds.Style)
 		}
 	}
 }
@@ -324,17 +928,23 @@ func (m *Model) DrawBraille() {
 	m.DrawBrailleDataSets([]string{DefaultDataSetName})
 }
 
-// DrawBrailleAll will draw braille runes for all data sets
-// from left to right of the graphing area of the canvas.
+// DrawBrailleAll will draw braille runes for all visible data sets
+// in z-order from left to right of the graphing area of the canvas.
 func (m *Model) DrawBrailleAll() {
+	m.DrawBrailleDataSets(m.visibleDataSets())
+}
+
+// visibleDataSets returns the names of visible data sets
+// containing data sorted in z-order.
+func (m *Model) visibleDataSets() []string {
 	names := make([]string, 0, len(m.dSets))
 	for n, ds := range m.dSets {
-		if ds.tBuf.Length() > 0 {
+		if (ds.tBuf.Length() > 0) && m.DataSetVisible(n) {
 			names = append(names, n)
 		}
 	}
-	sort.Strings(names)
-	m.DrawBrailleDataSets(names)
+	m.SortDrawOrder(names)
+	return names
 }
 
 // DrawBraille will draw braille runes displayed from left to right
@@ -342,6 +952,8 @@ func (m *Model) DrawBrailleAll() {
 // Requires four data sets containing candlestick data open, high, low, close values.
 // Assumes that all data sets have the same number of TimePoints and
 // the TimePoint at the same index of each data set has the same Time value.
+// Candle sets pushed with PushCandle and drawn with DrawCandles do not require
+// aligned data sets.
 func (m *Model) DrawCandle(openName, highName, lowName, closeName string, bullStyle, bearStyle lipgloss.Style) {
 	if len(openName) == 0 || len(highName) == 0 || len(lowName) == 0 || len(closeName) == 0 {
 		return
@@ -375,6 +987,7 @@ func (m *Model) DrawCandle(openName, highName, lowName, closeName string, bullSt
 		limit = len(cData)
 	}
 
+	m.fitPushed()
 	m.Clear()
 	m.DrawXYAxisAndLabel()
 	for i := 0; i < limit; i++ {
@@ -405,49 +1018,25 @@ func (m *Model) DrawCandle(openName, highName, lowName, closeName string, bullSt
 }
 
 // DrawBrailleDataSets will draw braille runes from left to right
-// of the graphing area of the canvas for each data set given
-// by name strings.
+// of the graphing area of the canvas for each visible data set
+// given by name strings in order.
 func (m *Model) DrawBrailleDataSets(names []string) {
 	if len(names) == 0 {
 		return
 	}
+	m.fitPushed()
 	m.Clear()
 	m.DrawXYAxisAndLabel()
+	m.drawBands()
 	for _, n := range names {
-		if ds, ok := m.dSets[n]; ok {
-			dataPoints := ds.tBuf.ReadAll()
-			dataLen := len(dataPoints)
-			if dataLen == 0 {
-				return
-			}
-			// draw lines from each point to the next point
+		if ds, ok := m.dSets[n]; ok && m.DataSetVisible(n) {
 			bGrid := graph.NewBrailleGrid(m.GraphWidth(), m.GraphHeight(),
 				0, float64(m.GraphWidth()), // X values already scaled to graph
 				0, float64(m.GraphHeight())) // Y values already scaled to graph
-			for i := 0; i < dataLen; i++ {
-				j := i + 1
-				if j >= dataLen {
-					j = i
-				}
-				p1 := dataPoints[i]
-				p2 := dataPoints[j]
-				// ignore points that will not be displayed
-				bothBeforeMin := (p1.X < 0 && p2.X < 0)
-				bothAfterMax := (p1.X > float64(m.GraphWidth()) && p2.X > float64(m.GraphWidth()))
-				if bothBeforeMin || bothAfterMax {
-					continue
-				}
-				// get braille grid points from two Float64Point data points
-				gp1 := bGrid.GridPoint(p1)
-				gp2 := bGrid.GridPoint(p2)
-				// set all points in the braille grid
-				// between two points that approximates a line
-				points := graph.GetLinePoints(gp1, gp2)
-				for _, p := range points {
-					bGrid.Set(p)
-				}
+			// two data points per braille dot column
+			for _, points := range m.drawPoints(ds, 4*m.GraphWidth()) {
+				m.drawBrailleSegment(bGrid, points, ds)
 			}
-
 			// get all rune patterns for braille grid
 			// and draw them on to the canvas
 			startX := 0
@@ -461,10 +1050,51 @@ func (m *Model) DrawBrailleDataSets(names []string) {
 	}
 }
 
+// drawBrailleSegment sets the braille grid points of lines drawn
+// through a given segment of scaled data points of a data set.
+func (m *Model) drawBrailleSegment(bGrid *graph.BrailleGrid, dataPoints []canvas.Float64Point, ds *dataSet) {
+	if ds.aggregation != AggregateDefault {
+		// replace data points with aggregates of each braille grid column
+		cols := aggregateColumns(dataPoints, func(f canvas.Float64Point) int {
+			return bGrid.GridPoint(f).X
+		})
+		offset := ds.scaledYOffset()
+		dataPoints = make([]canvas.Float64Point, 0, len(cols))
+		for _, c := range cols {
+			dataPoints = append(dataPoints, c.points(ds.aggregation, offset)...)
+		}
+	}
+	// draw lines from each point to the next point
+	dataLen := len(dataPoints)
+	for i := 0; i < dataLen; i++ {
+		j := i + 1
+		if j >= dataLen {
+			j = i
+		}
+		p1 := dataPoints[i]
+		p2 := dataPoints[j]
+		// ignore points that will not be displayed
+		bothBeforeMin := (p1.X < 0 && p2.X < 0)
+		bothAfterMax := (p1.X > float64(m.GraphWidth()) && p2.X > float64(m.GraphWidth()))
+		if bothBeforeMin || bothAfterMax {
+			continue
+		}
+		// get braille grid points from two Float64Point data points
+		gp1 := bGrid.GridPoint(p1)
+		gp2 := bGrid.GridPoint(p2)
+		// set all points in the braille grid
+		// between two points that approximates a line
+		points := graph.GetLinePoints(gp1, gp2)
+		for _, p := range points {
+			bGrid.Set(p)
+		}
+	}
+}
+
 // Set column background style to given lipgloss.Style background
 // corresponding to timestamp at given time.Time.
 func (m *Model) SetColumnBackgroundStyle(ts time.Time, s lipgloss.Style) {
-	f := canvas.Float64Point{X: float64(ts.Unix()), Y: 0}
+	f := canvas.Float64Point{X: m.TimeX(ts), Y: 0}
 	if f.X < m.ViewMinX() || f.X > m.ViewMaxX() {
 		return
 	}
@@ -481,13 +1111,71 @@ func (m *Model) SetColumnBackgroundStyle(ts time.Time, s lipgloss.Style) {
 	}
 }
 
+// drawPoints returns the scaled data points of a data set needed to draw
+// the displayed graph in chronological order, split into segments of data
+// points connected by lines.  Segments are split at NaN and infinite values
+// and between data points further apart than the maximum gap of the data set.
+// Data points outside of the graph are skipped except for the nearest data point
+// on each side of the graph, and the remaining data points are reduced
+// to at most n data points in total if there is a DownsampleFunc.
+func (m *Model) drawPoints(ds *dataSet, n int) [][]canvas.Float64Point {
+	b := ds.tBuf
+	l := b.Length()
+	start := sort.Search(l, func(i int) bool {
+		return b.At(i).X >= 0
+	})
+	end := sort.Search(l, func(i int) bool {
+		return b.At(i).X > float64(m.GraphWidth())
+	})
+	if start > 0 {
+		start--
+	}
+	if end < l {
+		end++
+	}
+	maxGap := ds.maxGap.Seconds()
+	segs := [][]canvas.Float64Point{}
+	points := []canvas.Float64Point{}
+	total := 0
+	for i := start; i < end; i++ {
+		f := b.At(i)
+		valid := !math.IsNaN(f.Y) && !math.IsInf(f.Y, 0)
+		gap := (maxGap > 0) && (i > start) && (b.AtRaw(i).X-b.AtRaw(i-1).X > maxGap)
+		if (gap || !valid) && (len(points) > 0) {
+			segs = append(segs, points)
+			points = []canvas.Float64Point{}
+		}
+		if valid {
+			points = append(points, f)
+			total++
+		}
+	}
+	if len(points) > 0 {
+		segs = append(segs, points)
+	}
+	if (m.downsample != nil) && (n > 0) {
+		// each segment is reduced in proportion to its number of data points
+		for i, seg := range segs {
+			segs[i] = m.downsample(seg, max(n*len(seg)/total, 1))
+		}
+	}
+	return segs
+}
+
 // getLineSequence returns a sequence of Y values
-// to draw line runes from a given set of scaled []FloatPoint64.
-func (m *Model) getLineSequence(points []canvas.Float64Point) []int {
+// to draw line runes from a given set of scaled []FloatPoint64
+// using given Aggregation and scaled Y offset of the data set.
+func (m *Model) getLineSequence(points []canvas.Float64Point, agg Aggregation, offset float64) []int {
 	width := m.Width() - m.Origin().X // line runes can draw on axes
 	if width <= 0 {
 		return []int{}
 	}
+	if agg != AggregateDefault {
+		cols := aggregateColumns(points, func(f canvas.Float64Point) int {
+			return int(math.Round(f.X))
+		})
+		return aggregateLineSequence(cols, width, agg, offset)
+	}
 	dataLen := len(points)
 	// each index of the bucket corresponds to a graph column.
 	// each index value is the average of data point values
@@ -523,13 +1211,53 @@ func (m *Model) getLineSequence(points []canvas.Float64Point) []int {
 	return r
 }
 
+// LegendEntries returns legend entries of the bullish and bearish candles
+// of each candle set containing candles, followed by a legend entry with
+// a line marker for each data set containing data, both sorted by name.
+func (m *Model) LegendEntries() []legend.Entry {
+	cNames := make([]string, 0, len(m.cSets))
+	for n, cs := range m.cSets {
+		if len(cs.candles) > 0 {
+			cNames = append(cNames, n)
+		}
+	}
+	sort.Strings(cNames)
+	names := make([]string, 0, len(m.dSets))
+	for n, ds := range m.dSets {
+		if ds.tBuf.Length() > 0 {
+			names = append(names, n)
+		}
+	}
+	sort.Strings(names)
+	r := make([]legend.Entry, 0, 2*len(cNames)+len(names))
+	for _, n := range cNames {
+		r = append(r, m.cSets[n].legendEntries(n)...)
+	}
+	for _, n := range names {
+		ds := m.dSets[n]
+		r = append(r, legend.Entry{
+			Name:    n,
+			Marker:  legend.LineMarker(ds.LineStyle),
+			Style:   ds.Style,
+			DataSet: n,
+			Hidden:  !m.DataSetVisible(n),
+		})
+	}
+	return r
+}
+
 // Update processes bubbletea Msg by invoking
 // UpdateHandlerFunc callback if linechart is focused.
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	if !m.Focused() {
 		return m, nil
 	}
-	m.UpdateHandler(&m.Model, msg)
+	m.UpdateHover(msg)
+	if !m.UpdateCursor(msg) { // data cursor keys take precedence
+		m.UpdateHandler(&m.Model, msg)
+	}
 	m.rescaleData()
+	m.fitYRange()
+	m.SyncLinkGroup()
 	return m, nil
 }
@@ -0,0 +1,256 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package timeserieslinechart
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var testTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// testTimePoint returns a TimePoint given number of seconds after testTime.
+func testTimePoint(s int, v float64) TimePoint {
+	return TimePoint{Time: testTime.Add(time.Duration(s) * time.Second), Value: v}
+}
+
+func TestRetention(t *testing.T) {
+	var names []string
+	var evicted []TimePoint
+	m := New(20, 10,
+		WithMaxPoints(3),
+		WithEvictHandler(func(n string, tp TimePoint) {
+			names = append(names, n)
+			evicted = append(evicted, tp)
+		}))
+	for i := 0; i < 5; i++ {
+		m.Push(testTimePoint(i, float64(i)))
+	}
+	tps := m.TimePoints()
+	if (len(tps) != 3) || (tps[0].Value != 2) {
+		t.Errorf("max points kept wrong TimePoints:%v", tps)
+	}
+	if (len(evicted) != 2) || !evicted[0].Time.Equal(testTime) || (evicted[1].Value != 1) {
+		t.Errorf("max points evicted wrong TimePoints:%v", evicted)
+	}
+
+	// lowering max age evicts TimePoints older than the newest TimePoint
+	evicted = nil
+	m.SetDataSetMaxAge(DefaultDataSetName, time.Second)
+	if tps := m.TimePoints(); (len(tps) != 2) || (tps[0].Value != 3) {
+		t.Errorf("max age kept wrong TimePoints:%v", tps)
+	}
+	if (len(evicted) != 1) || (evicted[0].Value != 2) {
+		t.Errorf("max age evicted wrong TimePoints:%v", evicted)
+	}
+	evicted = nil
+	m.Push(testTimePoint(10, 10))
+	if tps := m.TimePoints(); (len(tps) != 1) || (len(evicted) != 2) {
+		t.Errorf("push over max age kept %v evicted %v", tps, evicted)
+	}
+
+	for _, n := range names {
+		if n != DefaultDataSetName {
+			t.Errorf("evicted from wrong data set:%s", n)
+		}
+	}
+
+	// new data sets use default retention limits
+	m.SetMaxAge(0)
+	for i := 0; i < 5; i++ {
+		m.PushDataSet("other", testTimePoint(i, float64(i)))
+	}
+	if n := m.DataSetLen("other"); n != 3 {
+		t.Errorf("default max points kept %d TimePoints", n)
+	}
+}
+
+func TestTimeToX(t *testing.T) {
+	epoch := testTime.Add(250 * time.Millisecond)
+	times := []time.Time{
+		epoch,
+		epoch.Add(1500*time.Millisecond + 123),
+		epoch.Add(-750*time.Millisecond + 7),
+		epoch.Add(-time.Hour - 1),
+		epoch.Add(48*time.Hour + 999999999),
+	}
+	for _, tm := range times {
+		x := timeToX(epoch, tm)
+		if want := tm.Sub(epoch).Seconds(); math.Abs(x-want) > 1e-12 {
+			t.Errorf("wrong X of %v:%f expected %f", tm, x, want)
+		}
+		if rt := xToTime(epoch, x); !rt.Equal(tm) {
+			t.Errorf("time did not round trip:%v expected %v", rt, tm)
+		}
+	}
+}
+
+func TestSetEpoch(t *testing.T) {
+	m := New(20, 10, WithTimeRange(testTime, testTime.Add(100*time.Second)))
+	m.SetViewTimeRange(testTime.Add(10*time.Second), testTime.Add(50*time.Second))
+	m.Push(testTimePoint(20, 1))
+	m.SetEpoch(testTime)
+	if (m.MinX() != 0) || (m.MaxX() != 100) || (m.ViewMinX() != 10) || (m.ViewMaxX() != 50) {
+		t.Errorf("ranges not shifted:%f %f %f %f", m.MinX(), m.MaxX(), m.ViewMinX(), m.ViewMaxX())
+	}
+	if f := m.dSets[DefaultDataSetName].tBuf.ReadAllRaw(); (len(f) != 1) || (f[0].X != 20) {
+		t.Errorf("data not shifted:%v", f)
+	}
+	if tps := m.TimePoints(); !tps[0].Time.Equal(testTime.Add(20 * time.Second)) {
+		t.Errorf("wrong TimePoint time:%v", tps[0].Time)
+	}
+	if l := m.XLabelFormatter(0, 86400); l != "'24 01/02" {
+		t.Errorf("default formatter not using epoch:%q", l)
+	}
+
+	// formatters given by WithXLabelFormatter are kept
+	fmter := TimeLabelFormatter("15:04:05.000", time.UTC, testTime)
+	m = New(20, 10, WithXLabelFormatter(fmter), WithEpoch(testTime))
+	if l := m.XLabelFormatter(0, 1.5); l != "00:00:01.500" {
+		t.Errorf("custom formatter replaced:%q", l)
+	}
+}
+
+func TestDurationUpdateHandler(t *testing.T) {
+	m := New(20, 10,
+		WithEpoch(testTime),
+		WithTimeRange(testTime, testTime.Add(time.Second)),
+		WithUpdateHandler(DurationUpdateHandler(time.Millisecond)))
+	m.SetViewTimeRange(testTime.Add(100*time.Millisecond), testTime.Add(200*time.Millisecond))
+	m.Focus()
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if (math.Abs(m.ViewMinX()-0.101) > 1e-12) || (math.Abs(m.ViewMaxX()-0.201) > 1e-12) {
+		t.Errorf("wrong view after moving right:%f %f", m.ViewMinX(), m.ViewMaxX())
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	if (math.Abs(m.ViewMinX()-0.099) > 1e-12) || (math.Abs(m.ViewMaxX()-0.199) > 1e-12) {
+		t.Errorf("wrong view after moving left:%f %f", m.ViewMinX(), m.ViewMaxX())
+	}
+}
+
+func TestExcludeHiddenRange(t *testing.T) {
+	m := New(20, 10, WithExcludeHiddenRange())
+	for i := 0; i < 5; i++ {
+		m.Push(testTimePoint(i, float64(2+2*i)))
+		m.PushDataSet("b", testTimePoint(i, float64(-50+50*i)))
+	}
+	if (m.MinY() != -50) || (m.MaxY() != 150) {
+		t.Fatalf("wrong Y range:%f %f", m.MinY(), m.MaxY())
+	}
+	m.SetDataSetVisible("b", false)
+	if (m.MinY() != 2) || (m.MaxY() != 10) || (m.ViewMinY() != 2) || (m.ViewMaxY() != 10) {
+		t.Errorf("Y range not reset to visible data sets:%f %f", m.MinY(), m.MaxY())
+	}
+	m.PushDataSet("b", testTimePoint(5, 200))
+	if m.MaxY() != 10 {
+		t.Errorf("hidden data set adjusted Y range:%f", m.MaxY())
+	}
+	m.ToggleDataSetVisible("b")
+	if (m.MinY() != -50) || (m.MaxY() != 200) {
+		t.Errorf("Y range not adjusted to shown data set:%f %f", m.MinY(), m.MaxY())
+	}
+}
+
+func TestAutoFitY(t *testing.T) {
+	m := New(20, 10, WithEpoch(testTime), WithAutoFitY(0, false))
+	for i := 0; i < 6; i++ {
+		m.Push(testTimePoint(i, float64(i*i)))
+	}
+	m.SetViewXRange(2, 8)
+	if (m.ViewMinY() != 4) || (m.ViewMaxY() != 25) {
+		t.Errorf("Y values not fitted to panned view:%f %f", m.ViewMinY(), m.ViewMaxY())
+	}
+
+	// pushed values are fitted when drawing, ignoring values outside the view
+	m.Push(testTimePoint(6, 100))
+	m.Push(testTimePoint(20, 1000))
+	m.DrawAll()
+	if (m.ViewMinX() != 2) || (m.ViewMaxX() != 8) {
+		t.Fatalf("view moved by pushing:%f %f", m.ViewMinX(), m.ViewMaxX())
+	}
+	if (m.ViewMinY() != 4) || (m.ViewMaxY() != 100) {
+		t.Errorf("Y values not fitted to pushed values in view:%f %f", m.ViewMinY(), m.ViewMaxY())
+	}
+}
+
+// testIndicator pushes each TimePoint and its doubled value.
+type testIndicator struct{}
+
+func (testIndicator) Push(t TimePoint) []TimePoint {
+	return []TimePoint{t, {Time: t.Time, Value: 2 * t.Value}}
+}
+
+func (testIndicator) PushCandle(c Candle) []TimePoint {
+	return []TimePoint{{Time: c.Time, Value: c.Close}}
+}
+
+func TestRemoveDataSetLinks(t *testing.T) {
+	m := New(20, 10)
+	m.LinkDataSets("price", testIndicator{}, "copy", "double")
+	m.LinkCandleSet(DefaultDataSetName, testIndicator{}, "close")
+	m.SetBand("double", "copy", '░', lipgloss.NewStyle())
+	m.SetBand("copy", "price", '░', lipgloss.NewStyle())
+	m.PushDataSet("price", testTimePoint(0, 1))
+
+	// removed outputs are not recreated while remaining outputs are pushed
+	m.RemoveDataSet("copy")
+	m.PushDataSet("price", testTimePoint(1, 2))
+	if m.HasDataSet("copy") {
+		t.Error("removed output data set recreated")
+	}
+	if tps := m.DataSetTimePoints("double"); (len(tps) != 2) || (tps[1].Value != 4) {
+		t.Errorf("remaining output not pushed:%v", tps)
+	}
+	if len(m.bands) != 0 {
+		t.Errorf("bands of removed data set kept:%v", m.bands)
+	}
+
+	// indicators without outputs are unlinked
+	m.RemoveDataSet("double")
+	if _, ok := m.links["price"]; ok {
+		t.Error("indicator without outputs still linked")
+	}
+	m.RemoveDataSet("close")
+	if _, ok := m.cLinks[DefaultDataSetName]; ok {
+		t.Error("candle indicator without outputs still linked")
+	}
+	m.PushCandle(testTime, 1, 1, 1, 1, 1)
+	if m.HasDataSet("close") {
+		t.Error("removed candle output data set recreated")
+	}
+}
+
+func TestLinkCycles(t *testing.T) {
+	// output linked back to its source is pushed once without recursion
+	m := New(20, 10)
+	m.PushDataSet("a", testTimePoint(0, 1))
+	m.LinkDataSets("a", testIndicator{}, "a")
+	if tps := m.DataSetTimePoints("a"); (len(tps) != 2) || (tps[1].Value != 1) {
+		t.Errorf("wrong self linked data set after linking:%v", tps)
+	}
+	m.PushDataSet("a", testTimePoint(1, 2))
+	if tps := m.DataSetTimePoints("a"); (len(tps) != 4) || (tps[3].Value != 2) {
+		t.Errorf("wrong self linked data set after push:%v", tps)
+	}
+
+	// two linked data sets push to each other once
+	m = New(20, 10)
+	m.LinkDataSets("a", testIndicator{}, "b")
+	m.LinkDataSets("b", testIndicator{}, "a")
+	m.PushDataSet("a", testTimePoint(0, 1))
+	if tps := m.DataSetTimePoints("a"); len(tps) != 2 {
+		t.Errorf("wrong cycle source data set:%v", tps)
+	}
+	if tps := m.DataSetTimePoints("b"); (len(tps) != 1) || (tps[0].Value != 1) {
+		t.Errorf("wrong cycle output data set:%v", tps)
+	}
+	m.PushDataSet("b", testTimePoint(1, 3))
+	if tps := m.DataSetTimePoints("a"); (len(tps) != 3) || (tps[2].Value != 3) {
+		t.Errorf("wrong cycle data set after pushing output:%v", tps)
+	}
+}
@@ -0,0 +1,342 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package timeserieslinechart
+
+// File contains a tick locator placing X axis ticks at calendar
+// boundaries such as midnight and month starts, and a matching label
+// formatter displaying contextual time labels.
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/NimbleMarkets/ntcharts/linechart"
+)
+
+// timeUnit is a calendar unit of time between ticks.
+type timeUnit int
+
+const (
+	unitNanosecond timeUnit = iota
+	unitSecond
+	unitMinute
+	unitHour
+	unitDay
+	unitWeek
+	unitMonth
+	unitYear
+)
+
+// timeInterval is a number of calendar units between ticks.
+type timeInterval struct {
+	unit   timeUnit
+	n      int
+	approx float64 // approximate number of seconds in interval
+}
+
+// timeIntervals contains the available intervals between ticks in increasing order.
+var timeIntervals = func() []timeInterval {
+	r := []timeInterval{}
+	add := func(u timeUnit, approx float64, ns ...int) {
+		for _, n := range ns {
+			r = append(r, timeInterval{unit: u, n: n, approx: approx * float64(n)})
+		}
+	}
+	for ns := 1; ns < 1e9; ns *= 10 {
+		add(unitNanosecond, 1e-9, ns, 2*ns, 5*ns)
+	}
+	add(unitSecond, 1, 1, 2, 5, 10, 15, 30)
+	add(unitMinute, 60, 1, 2, 5, 10, 15, 30)
+	add(unitHour, 3600, 1, 2, 3, 6, 12)
+	add(unitDay, 86400, 1, 2)
+	add(unitWeek, 7*86400, 1)
+	add(unitMonth, 30*86400, 1, 2, 3, 6)
+	add(unitYear, 365*86400, 1, 2, 5, 10, 25, 50, 100, 250, 500, 1000)
+	return r
+}()
+
+// midnight returns the start of the day of given time in its location.
+func midnight(t time.Time) time.Time {
+	y, mo, d := t.Date()
+	return time.Date(y, mo, d, 0, 0, 0, 0, t.Location())
+}
+
+// floor returns the latest tick boundary of the interval at or before given time.
+// Boundaries are aligned to the wall clock of the location of the given time.
+func (ti timeInterval) floor(t time.Time) time.Time {
+	y, mo, d := t.Date()
+	h, mi, sec := t.Clock()
+	loc := t.Location()
+	switch ti.unit {
+	case unitNanosecond:
+		ns := t.Nanosecond()
+		return time.Date(y, mo, d, h, mi, sec, ns-ns%ti.n, loc)
+	case unitSecond:
+		return time.Date(y, mo, d, h, mi, sec-sec%ti.n, 0, loc)
+	case unitMinute:
+		return time.Date(y, mo, d, h, mi-mi%ti.n, 0, 0, loc)
+	case unitHour:
+		return time.Date(y, mo, d, h-h%ti.n, 0, 0, 0, loc)
+	case unitDay:
+		return time.Date(y, mo, d-(t.YearDay()-1)%ti.n, 0, 0, 0, 0, loc)
+	case unitWeek:
+		return time.Date(y, mo, d-(int(t.Weekday())+6)%7, 0, 0, 0, 0, loc) // Monday
+	case unitMonth:
+		return time.Date(y, mo-time.Month((int(mo)-1)%ti.n), 1, 0, 0, 0, 0, loc)
+	default:
+		return time.Date(y-y%ti.n, time.January, 1, 0, 0, 0, 0, loc)
+	}
+}
+
+// next returns the tick boundary of the interval following given tick boundary.
+func (ti timeInterval) next(t time.Time) time.Time {
+	switch ti.unit {
+	case unitNanosecond:
+		return ti.floor(t.Add(time.Duration(ti.n)))
+	case unitSecond:
+		return ti.floor(t.Add(time.Duration(ti.n) * time.Second))
+	case unitMinute:
+		return ti.floor(t.Add(time.Duration(ti.n) * time.Minute))
+	case unitHour:
+		y, mo, d := t.Date()
+		nt := ti.floor(time.Date(y, mo, d, t.Hour()+ti.n, 0, 0, 0, t.Location()))
+		if !nt.After(t) { // wall clock hour skipped by daylight saving time
+			nt = ti.floor(t.Add(time.Duration(ti.n) * time.Hour))
+		}
+		return nt
+	case unitDay:
+		return ti.floor(t.AddDate(0, 0, ti.n))
+	case unitWeek:
+		return ti.floor(t.AddDate(0, 0, 7))
+	case unitMonth:
+		return ti.floor(t.AddDate(0, ti.n, 0))
+	default:
+		return ti.floor(t.AddDate(ti.n, 0, 0))
+	}
+}
+
+// ticks returns the tick boundaries of the interval between given
+// minimum and maximum times inclusive, or nil if more than n.
+func (ti timeInterval) ticks(min, max time.Time, n int) []time.Time {
+	r := []time.Time{}
+	t := ti.floor(min)
+	if t.Before(min) {
+		t = ti.next(t)
+	}
+	for !t.After(max) {
+		if len(r) == n {
+			return nil
+		}
+		r = append(r, t)
+		nt := ti.next(t)
+		if !nt.After(t) { // guard against boundaries not advancing
+			break
+		}
+		t = nt
+	}
+	return r
+}
+
+// TimeTicks locates X axis ticks at calendar boundaries of fractions of seconds,
+// seconds, minutes, hours, days, weeks, months or years chosen from the visible
+// time range, and formats contextual time labels of the ticks in a time.Location.
+// X values are seconds since an epoch, which defaults to the Unix epoch.
+// If the X axis has an AxisTransform, then ticks at times that are not displayed
+// by the AxisTransform, such as outside of trading sessions, are removed
+// for intervals less than a day and moved to the next displayed time otherwise.
+type TimeTicks struct {
+	loc       *time.Location
+	epoch     time.Time
+	transform linechart.AxisTransform // X axis AxisTransform, nil if linear
+	interval  timeInterval            // interval of last located ticks
+	last      time.Time               // time of last formatted label
+}
+
+// NewTimeTicks returns a new *TimeTicks using given time.Location
+// to determine calendar boundaries and display labels.
+// If nil, then UTC is used.
+func NewTimeTicks(loc *time.Location) *TimeTicks {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return &TimeTicks{
+		loc:      loc,
+		epoch:    unixEpoch,
+		interval: timeInterval{unit: unitDay, n: 1, approx: 86400},
+	}
+}
+
+// Location returns the time.Location used by the TimeTicks.
+func (tt *TimeTicks) Location() *time.Location {
+	return tt.loc
+}
+
+// SetEpoch sets the time.Time of X value 0.
+func (tt *TimeTicks) SetEpoch(t time.Time) {
+	tt.epoch = t
+}
+
+// SetTransform sets the X axis AxisTransform, where nil is linear.
+func (tt *TimeTicks) SetTransform(t linechart.AxisTransform) {
+	tt.transform = t
+}
+
+// Locate is a linechart.TickLocator returning at most n tick values at the
+// calendar boundaries of the smallest interval between given minimum
+// and maximum values.
+func (tt *TimeTicks) Locate(min, max float64, n int) []float64 {
+	if (n < 1) || !(max > min) || math.IsInf(max-min, 0) {
+		return []float64{}
+	}
+	// given values are in the transformed space of the X axis
+	at := tt.transform
+	if at == nil {
+		at = linechart.LinearTransform{}
+	}
+	minX, maxX := at.Inverse(min), at.Inverse(max)
+	minT := xToTime(tt.epoch, minX).In(tt.loc)
+	maxT := xToTime(tt.epoch, maxX).In(tt.loc)
+	for _, ti := range timeIntervals {
+		if (max-min)/ti.approx > float64(n) {
+			continue
+		}
+		// times not displayed are removed after locating ticks
+		limit := n + int(math.Min((maxX-minX)/ti.approx, float64(64*n)))
+		ts := ti.ticks(minT, maxT, limit)
+		if ts == nil {
+			continue
+		}
+		r := make([]float64, 0, len(ts))
+		for _, t := range ts {
+			x := timeToX(tt.epoch, t)
+			v := at.Forward(x)
+			if ti.unit < unitDay {
+				if math.Abs(at.Inverse(v)-x) > 1e-9*math.Max(1, math.Abs(x)) {
+					continue
+				}
+			} else if (len(r) > 0) && (r[len(r)-1] == v) {
+				r = r[:len(r)-1] // keep the last tick moved to the same time
+			}
+			r = append(r, v)
+		}
+		if len(r) > n {
+			continue
+		}
+		tt.interval = ti
+		return r
+	}
+	return []float64{}
+}
+
+// Format is a linechart.LabelFormatter returning labels of tick values
+// for the interval of the last located ticks.  Dates are only displayed
+// on the first label and where they change, and years only on the
+// first label and where they change for intervals of days or more.
+func (tt *TimeTicks) Format(i int, v float64) string {
+	t := xToTime(tt.epoch, v).In(tt.loc)
+	if tt.interval.unit == unitNanosecond { // remove float64 rounding errors
+		t = t.Round(time.Duration(tt.interval.n))
+	}
+	last := tt.last
+	tt.last = t
+	first := (i == 0) || last.IsZero()
+	newYear := first || (t.Year() != last.Year())
+	newDay := newYear || (t.YearDay() != last.YearDay())
+	switch tt.interval.unit {
+	case unitYear:
+		return t.Format("2006")
+	case unitMonth:
+		if newYear {
+			return t.Format("Jan 2006")
+		}
+		return t.Format("Jan")
+	case unitWeek, unitDay:
+		if newYear {
+			return t.Format("'06 01/02")
+		}
+		return t.Format("01/02")
+	}
+	layout := "15:04"
+	if tt.interval.unit == unitSecond {
+		layout = "15:04:05"
+	} else if tt.interval.unit == unitNanosecond {
+		switch {
+		case tt.interval.n >= 1e6:
+			layout = "15:04:05.000"
+		case tt.interval.n >= 1e3:
+			layout = "15:04:05.000000"
+		default:
+			layout = "15:04:05.000000000"
+		}
+	}
+	if !newDay {
+		return t.Format(layout)
+	}
+	if !first && t.Equal(midnight(t)) {
+		return t.Format("01/02")
+	}
+	return fmt.Sprintf("%s %s", t.Format("01/02"), t.Format(layout))
+}
+
+// SetLocation sets the time.Location used to determine calendar
+// boundaries of time ticks and display their labels.
+// If nil, then UTC is used.  The X label formatter is replaced
+// unless set by WithXLabelFormatter.
+func (m *Model) SetLocation(loc *time.Location) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	m.location = loc
+	m.updateTimeTicks()
+}
+
+// SetXLabelFormatter sets the X label formatter for displaying X values
+// as strings, which is kept when time ticks, the time.Location or the
+// epoch of the Model change.  A nil formatter restores the label
+// formatter of the time ticks or the default date time label formatter.
+func (m *Model) SetXLabelFormatter(fmter linechart.LabelFormatter) {
+	m.customXLabels = fmter != nil
+	if fmter != nil {
+		m.XLabelFormatter = fmter
+	}
+	m.updateTimeTicks()
+}
+
+// Location returns the time.Location used by time ticks.
+func (m *Model) Location() *time.Location {
+	if m.location == nil {
+		return time.UTC
+	}
+	return m.location
+}
+
+// SetTimeTicks enables or disables placing X axis values at calendar
+// boundaries using TimeTicks in the Model time.Location.
+// Disabling restores placing X axis values every number of X steps.
+// Labels are formatted by TimeTicks, or by the default date time label
+// formatter if disabled, unless set by SetXLabelFormatter.
+func (m *Model) SetTimeTicks(b bool) {
+	m.timeTicks = b
+	if !b {
+		m.SetXTickLocator(nil)
+	}
+	m.updateTimeTicks()
+}
+
+// updateTimeTicks updates the X tick locator of enabled time ticks and
+// the X label formatter, unless set by SetXLabelFormatter, to the
+// Model time.Location, epoch and X axis AxisTransform.
+func (m *Model) updateTimeTicks() {
+	if m.timeTicks {
+		tt := NewTimeTicks(m.Location())
+		tt.SetEpoch(m.epoch)
+		tt.SetTransform(m.XTransform())
+		m.SetXTickLocator(tt.Locate)
+		if !m.customXLabels {
+			m.XLabelFormatter = tt.Format
+		}
+	} else if !m.customXLabels {
+		m.XLabelFormatter = dateTimeLabelFormatter(m.Location(), m.epoch)
+	}
+}
@@ -0,0 +1,168 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package timeserieslinechart
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeTicks(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone database unavailable:", err)
+	}
+	edt := time.FixedZone("EDT", -4*3600)
+	est := time.FixedZone("EST", -5*3600)
+	tests := []struct {
+		name     string
+		loc      *time.Location
+		min, max time.Time
+		n        int
+		ticks    []time.Time
+		labels   []string
+	}{
+		{
+			name: "DST start hours", loc: ny, n: 6,
+			min: time.Date(2024, 3, 10, 0, 0, 0, 0, ny),
+			max: time.Date(2024, 3, 10, 6, 0, 0, 0, ny),
+			ticks: []time.Time{ // 02:00 skipped
+				time.Date(2024, 3, 10, 0, 0, 0, 0, est),
+				time.Date(2024, 3, 10, 1, 0, 0, 0, est),
+				time.Date(2024, 3, 10, 3, 0, 0, 0, edt),
+				time.Date(2024, 3, 10, 4, 0, 0, 0, edt),
+				time.Date(2024, 3, 10, 5, 0, 0, 0, edt),
+				time.Date(2024, 3, 10, 6, 0, 0, 0, edt),
+			},
+			labels: []string{"03/10 00:00", "01:00", "03:00", "04:00", "05:00", "06:00"},
+		},
+		{
+			name: "DST end hours", loc: ny, n: 5,
+			min: time.Date(2024, 11, 3, 0, 0, 0, 0, edt),
+			max: time.Date(2024, 11, 3, 4, 0, 0, 0, est),
+			ticks: []time.Time{ // repeated 01:00 skipped
+				time.Date(2024, 11, 3, 0, 0, 0, 0, edt),
+				time.Date(2024, 11, 3, 1, 0, 0, 0, edt),
+				time.Date(2024, 11, 3, 2, 0, 0, 0, est),
+				time.Date(2024, 11, 3, 3, 0, 0, 0, est),
+				time.Date(2024, 11, 3, 4, 0, 0, 0, est),
+			},
+			labels: []string{"11/03 00:00", "01:00", "02:00", "03:00", "04:00"},
+		},
+		{
+			name: "DST start days", loc: ny, n: 6,
+			min: time.Date(2024, 3, 8, 0, 0, 0, 0, ny),
+			max: time.Date(2024, 3, 13, 0, 0, 0, 0, ny),
+			ticks: []time.Time{
+				time.Date(2024, 3, 8, 0, 0, 0, 0, est),
+				time.Date(2024, 3, 9, 0, 0, 0, 0, est),
+				time.Date(2024, 3, 10, 0, 0, 0, 0, est),
+				time.Date(2024, 3, 11, 0, 0, 0, 0, edt),
+				time.Date(2024, 3, 12, 0, 0, 0, 0, edt),
+				time.Date(2024, 3, 13, 0, 0, 0, 0, edt),
+			},
+			labels: []string{"'24 03/08", "03/09", "03/10", "03/11", "03/12", "03/13"},
+		},
+		{
+			name: "months across year", loc: time.UTC, n: 5,
+			min: time.Date(2023, 11, 15, 0, 0, 0, 0, time.UTC),
+			max: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+			ticks: []time.Time{
+				time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			},
+			labels: []string{"Dec 2023", "Jan 2024", "Feb", "Mar"},
+		},
+		{
+			name: "days across year", loc: time.UTC, n: 5,
+			min: time.Date(2023, 12, 30, 0, 0, 0, 0, time.UTC),
+			max: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+			ticks: []time.Time{
+				time.Date(2023, 12, 30, 0, 0, 0, 0, time.UTC),
+				time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+			},
+			labels: []string{"'23 12/30", "12/31", "'24 01/01", "01/02", "01/03"},
+		},
+		{
+			name: "hours across year", loc: time.UTC, n: 5,
+			min: time.Date(2023, 12, 31, 22, 0, 0, 0, time.UTC),
+			max: time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC),
+			ticks: []time.Time{
+				time.Date(2023, 12, 31, 22, 0, 0, 0, time.UTC),
+				time.Date(2023, 12, 31, 23, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC),
+			},
+			labels: []string{"12/31 22:00", "23:00", "01/01", "01:00", "02:00"},
+		},
+	}
+	for _, tc := range tests {
+		tt := NewTimeTicks(tc.loc)
+		xs := tt.Locate(timeToX(unixEpoch, tc.min), timeToX(unixEpoch, tc.max), tc.n)
+		if len(xs) != len(tc.ticks) {
+			t.Errorf("%s: wrong number of ticks:%d expected %d", tc.name, len(xs), len(tc.ticks))
+			continue
+		}
+		for i, x := range xs {
+			if got := xToTime(unixEpoch, x); !got.Equal(tc.ticks[i]) {
+				t.Errorf("%s: wrong tick %d:%v expected %v", tc.name, i, got.In(tc.loc), tc.ticks[i])
+			}
+			if l := tt.Format(i, x); l != tc.labels[i] {
+				t.Errorf("%s: wrong label %d:%q expected %q", tc.name, i, l, tc.labels[i])
+			}
+		}
+	}
+}
+
+func TestSetLocation(t *testing.T) {
+	loc := time.FixedZone("EST", -5*3600)
+	x := timeToX(unixEpoch, time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC))
+
+	m := New(20, 10)
+	if l := m.XLabelFormatter(0, x); l != "'24 01/02" {
+		t.Errorf("wrong UTC label:%q", l)
+	}
+	m.SetLocation(loc)
+	if l := m.XLabelFormatter(0, x); l != "'24 01/01" {
+		t.Errorf("default formatter not in location:%q", l)
+	}
+
+	custom := func(int, float64) string { return "custom" }
+	m = New(20, 10, WithXLabelFormatter(custom), WithLocation(loc))
+	if l := m.XLabelFormatter(0, x); l != "custom" {
+		t.Errorf("custom formatter replaced:%q", l)
+	}
+	m.SetTimeTicks(true)
+	if l := m.XLabelFormatter(0, x); l != "custom" {
+		t.Errorf("custom formatter replaced by time ticks:%q", l)
+	}
+
+	// custom formatter is kept in either option order
+	for _, opts := range [][]Option{
+		{WithXLabelFormatter(custom), WithTimeTicks()},
+		{WithTimeTicks(), WithXLabelFormatter(custom)},
+	} {
+		m = New(20, 10, opts...)
+		if l := m.XLabelFormatter(0, x); l != "custom" {
+			t.Errorf("custom formatter replaced by time ticks option:%q", l)
+		}
+		m.SetTimeTicks(false)
+		if l := m.XLabelFormatter(0, x); l != "custom" {
+			t.Errorf("custom formatter replaced by disabling time ticks:%q", l)
+		}
+	}
+
+	// nil formatter restores the time ticks formatter
+	m.SetTimeTicks(true)
+	m.SetXLabelFormatter(nil)
+	m.SetLocation(time.UTC)
+	if l := m.XLabelFormatter(0, x); l != "'24 01/02" {
+		t.Errorf("time ticks formatter not in location:%q", l)
+	}
+}
@@ -9,6 +9,8 @@ package timeserieslinechart
 // the viewport of the linechart
 
 import (
+	"time"
+
 	"github.com/NimbleMarkets/ntcharts/linechart"
 )
 
@@ -75,3 +77,94 @@ func SecondUpdateHandler(i int) linechart.UpdateHandler {
 func SecondNoZoomUpdateHandler(i int) linechart.UpdateHandler {
 	return linechart.XAxisNoZoomUpdateHandler(float64(i))
 }
+
+// DurationUpdateHandler is used by timeserieslinechart to enable
+// zooming in and out with the mouse wheel or page up and page down,
+// moving the viewing window by holding down mouse button and moving,
+// and moving the viewing window with the arrow keys.
+// There is only movement along the X axis by increments of given time.Duration,
+// allowing sub-second increments such as time.Millisecond.
+// Uses linechart Canvas Keymap for keyboard messages.
+func DurationUpdateHandler(d time.Duration) linechart.UpdateHandler {
+	return linechart.XAxisUpdateHandler(d.Seconds())
+}
+
+// DurationNoZoomUpdateHandler is used by timeserieslinechart to enable
+// moving the viewing window by using the mouse scroll wheel,
+// holding down mouse button and moving,
+// and moving the viewing window with the arrow keys.
+// There is only movement along the X axis by increments of given time.Duration,
+// allowing sub-second increments such as time.Millisecond.
+// Uses linechart Canvas Keymap for keyboard messages.
+func DurationNoZoomUpdateHandler(d time.Duration) linechart.UpdateHandler {
+	return linechart.XAxisNoZoomUpdateHandler(d.Seconds())
+}
+
+// SessionUpdateHandler is used by timeserieslinechart with a SessionCalendar
+// to enable zooming in and out with the mouse wheel or page up and page down,
+// moving the viewing window by holding down mouse button and moving,
+// and moving the viewing window with the arrow keys.
+// There is only movement along the X axis by increments of
+// the average daily trading session of the SessionCalendar.
+// Uses linechart Canvas Keymap for keyboard messages.
+func SessionUpdateHandler(c *SessionCalendar, i int) linechart.UpdateHandler {
+	return linechart.XAxisUpdateHandler(c.dailySession().Seconds() * float64(i))
+}
+
+// SessionNoZoomUpdateHandler is used by timeserieslinechart with a SessionCalendar
+// to enable moving the viewing window by using the mouse scroll wheel,
+// holding down mouse button and moving,
+// and moving the viewing window with the arrow keys.
+// There is only movement along the X axis by increments of
+// the average daily trading session of the SessionCalendar.
+// Uses linechart Canvas Keymap for keyboard messages.
+func SessionNoZoomUpdateHandler(c *SessionCalendar, i int) linechart.UpdateHandler {
+	return linechart.XAxisNoZoomUpdateHandler(c.dailySession().Seconds() * float64(i))
+}
+
+// DateBoxZoomUpdateHandler is used by timeserieslinechart to enable
+// zooming into the times of a range selected by holding down
+// the mouse button and moving, zooming in and out with the mouse wheel
+// or page up and page down, moving the viewing window with the arrow keys,
+// and restoring zooms with the linechart ZoomKeyMap.
+// There is only movement along the X axis by day increments.
+// Uses linechart Canvas Keymap for keyboard messages.
+func DateBoxZoomUpdateHandler(i int) linechart.UpdateHandler {
+	daySeconds := 86400 * i // number of seconds in a day
+	return linechart.XAxisBoxZoomUpdateHandler(float64(daySeconds))
+}
+
+// HourBoxZoomUpdateHandler is used by timeserieslinechart to enable
+// zooming into the times of a range selected by holding down
+// the mouse button and moving, zooming in and out with the mouse wheel
+// or page up and page down, moving the viewing window with the arrow keys,
+// and restoring zooms with the linechart ZoomKeyMap.
+// There is only movement along the X axis by hour increments.
+// Uses linechart Canvas Keymap for keyboard messages.
+func HourBoxZoomUpdateHandler(i int) linechart.UpdateHandler {
+	hourSeconds := 3600 * i // number of seconds in a hour
+	return linechart.XAxisBoxZoomUpdateHandler(float64(hourSeconds))
+}
+
+// SecondBoxZoomUpdateHandler is used by timeserieslinechart to enable
+// zooming into the times of a range selected by holding down
+// the mouse button and moving, zooming in and out with the mouse wheel
+// or page up and page down, moving the viewing window with the arrow keys,
+// and restoring zooms with the linechart ZoomKeyMap.
+// There is only movement along the X axis by second increments.
+// Uses linechart Canvas Keymap for keyboard messages.
+func SecondBoxZoomUpdateHandler(i int) linechart.UpdateHandler {
+	return linechart.XAxisBoxZoomUpdateHandler(float64(i))
+}
+
+// SessionBoxZoomUpdateHandler is used by timeserieslinechart with a
+// SessionCalendar to enable zooming into the times of a range selected by
+// holding down the mouse button and moving, zooming in and out with the
+// mouse wheel or page up and page down, moving the viewing window with the
+// arrow keys, and restoring zooms with the linechart ZoomKeyMap.
+// There is only movement along the X axis by increments of
+// the average daily trading session of the SessionCalendar.
+// Uses linechart Canvas Keymap for keyboard messages.
+func SessionBoxZoomUpdateHandler(c *SessionCalendar, i int) linechart.UpdateHandler {
+	return linechart.XAxisBoxZoomUpdateHandler(c.dailySession().Seconds() * float64(i))
+}
@@ -0,0 +1,196 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package linechart
+
+// File contains transforms applied to X and Y axes values
+// such that the axes can be displayed with non-linear scales.
+// Scaling, labeling, zooming and moving the viewport of the linechart
+// is done in the transformed space where the axes are linear.
+
+import (
+	"math"
+
+	"github.com/NimbleMarkets/ntcharts/canvas"
+	"github.com/NimbleMarkets/ntcharts/canvas/buffer"
+)
+
+// AxisTransform maps data values of an axis into
+// a transformed space where the axis is linear.
+type AxisTransform interface {
+	// Forward returns the transformed value of a data value.
+	Forward(float64) float64
+	// Inverse returns the data value of a transformed value.
+	Inverse(float64) float64
+	// Valid returns whether a data value can be transformed.
+	Valid(float64) bool
+}
+
+// LinearTransform is an AxisTransform that does not modify values.
+type LinearTransform struct{}
+
+// Forward returns the given value.
+func (t LinearTransform) Forward(v float64) float64 {
+	return v
+}
+
+// Inverse returns the given value.
+func (t LinearTransform) Inverse(v float64) float64 {
+	return v
+}
+
+// Valid returns true for all values.
+func (t LinearTransform) Valid(v float64) bool {
+	return true
+}
+
+// LogTransform is an AxisTransform using the logarithm of a given base.
+// Only positive values are valid, and non-positive values
+// are transformed as the smallest positive float64 value.
+// A Base that is not positive or is 1, such as in the zero value, is used as 10.
+type LogTransform struct {
+	Base float64
+}
+
+// NewLog10Transform returns a base 10 LogTransform.
+func NewLog10Transform() LogTransform {
+	return LogTransform{Base: 10}
+}
+
+// NewLog2Transform returns a base 2 LogTransform.
+func NewLog2Transform() LogTransform {
+	return LogTransform{Base: 2}
+}
+
+// Forward returns the logarithm of the given value.
+func (t LogTransform) Forward(v float64) float64 {
+	if v <= 0 {
+		v = math.SmallestNonzeroFloat64
+	}
+	return math.Log(v) / math.Log(t.base())
+}
+
+// Inverse returns the base raised to the power of the given value.
+func (t LogTransform) Inverse(v float64) float64 {
+	return math.Pow(t.base(), v)
+}
+
+// base returns the Base, or 10 if the Base is not positive or is 1.
+func (t LogTransform) base() float64 {
+	if (t.Base <= 0) || (t.Base == 1) {
+		return 10
+	}
+	return t.Base
+}
+
+// Valid returns whether given value is positive.
+func (t LogTransform) Valid(v float64) bool {
+	return v > 0
+}
+
+// SymlogTransform is an AxisTransform using a symmetric base 10 logarithm
+// that is linear near zero and logarithmic for large positive and negative values.
+// The Constant sets the size of the linear region around zero.
+type SymlogTransform struct {
+	Constant float64
+}
+
+// NewSymlogTransform returns a SymlogTransform with given constant.
+// The constant will be 1 if not positive.
+func NewSymlogTransform(c float64) SymlogTransform {
+	if c <= 0 {
+		c = 1
+	}
+	return SymlogTransform{Constant: c}
+}
+
+// Forward returns the symmetric logarithm of the given value.
+func (t SymlogTransform) Forward(v float64) float64 {
+	r := math.Log10(1 + math.Abs(v)/t.Constant)
+	if v < 0 {
+		return -r
+	}
+	return r
+}
+
+// Inverse returns the data value of the given symmetric logarithm value.
+func (t SymlogTransform) Inverse(v float64) float64 {
+	r := t.Constant * (math.Pow(10, math.Abs(v)) - 1)
+	if v < 0 {
+		return -r
+	}
+	return r
+}
+
+// Valid returns true for all values.
+func (t SymlogTransform) Valid(v float64) bool {
+	return true
+}
+
+// XTransform returns the X axis AxisTransform.
+func (m *Model) XTransform() AxisTransform {
+	if m.xTransform == nil {
+		return LinearTransform{}
+	}
+	return m.xTransform
+}
+
+// YTransform returns the Y axis AxisTransform.
+func (m *Model) YTransform() AxisTransform {
+	if m.yTransform == nil {
+		return LinearTransform{}
+	}
+	return m.yTransform
+}
+
+// SetXTransform sets the X axis AxisTransform.
+// If nil, then X axis is linear.
+func (m *Model) SetXTransform(t AxisTransform) {
+	m.xTransform = t
+	m.UpdateGraphSizes()
+}
+
+// SetYTransform sets the Y axis AxisTransform.
+// If nil, then Y axis is linear.
+func (m *Model) SetYTransform(t AxisTransform) {
+	m.yTransform = t
+	m.UpdateGraphSizes()
+}
+
+// TransformX returns given X data value in transformed space.
+func (m *Model) TransformX(v float64) float64 {
+	return m.XTransform().Forward(v)
+}
+
+// TransformY returns given Y data value in transformed space.
+func (m *Model) TransformY(v float64) float64 {
+	return m.YTransform().Forward(v)
+}
+
+// TransformFloat64Point returns given Float64Point data point in transformed space.
+func (m *Model) TransformFloat64Point(f canvas.Float64Point) canvas.Float64Point {
+	return canvas.Float64Point{X: m.TransformX(f.X), Y: m.TransformY(f.Y)}
+}
+
+// PointScaleFunc returns a buffer ScaleFunc for Float64Point data points
+// that transforms data points using the current X and Y axes transforms
+// before subtracting the offset and multiplying by the scaling factor.
+// Offset and scaling factor are expected to be in transformed space.
+func (m *Model) PointScaleFunc() buffer.ScaleFunc[canvas.Float64Point] {
+	xt := m.XTransform()
+	yt := m.YTransform()
+	return func(v, offset, scale canvas.Float64Point) canvas.Float64Point {
+		f := canvas.Float64Point{X: xt.Forward(v.X), Y: yt.Forward(v.Y)}
+		return buffer.ScaleFloat64Point(f, offset, scale)
+	}
+}
+
+// YScaleFunc returns a buffer ScaleFunc for float64 Y data values
+// that transforms values using the current Y axis transform
+// before subtracting the offset and multiplying by the scaling factor.
+// Offset and scaling factor are expected to be in transformed space.
+func (m *Model) YScaleFunc() buffer.ScaleFunc[float64] {
+	yt := m.YTransform()
+	return func(v, offset, scale float64) float64 {
+		return buffer.ScaleFloat64(yt.Forward(v), offset, scale)
+	}
+}
@@ -0,0 +1,72 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package linechart
+
+import (
+	"math"
+	"testing"
+
+	"github.com/NimbleMarkets/ntcharts/canvas"
+)
+
+func TestAxisTransformInverse(t *testing.T) {
+	transforms := []AxisTransform{
+		LinearTransform{},
+		NewLog10Transform(),
+		NewLog2Transform(),
+		NewSymlogTransform(1),
+	}
+	for _, tr := range transforms {
+		for _, v := range []float64{0.5, 1, 8, 1000} {
+			if r := tr.Inverse(tr.Forward(v)); math.Abs(r-v) > 1e-9 {
+				t.Errorf("%T inverse of %f returned %f", tr, v, r)
+			}
+		}
+	}
+	sl := NewSymlogTransform(1)
+	if sl.Forward(-99) != -sl.Forward(99) {
+		t.Errorf("SymlogTransform not symmetric:%f", sl.Forward(-99))
+	}
+	if NewLog10Transform().Valid(0) {
+		t.Errorf("LogTransform zero value should not be valid")
+	}
+
+	// invalid bases are used as base 10
+	for _, base := range []float64{0, -2, 1} {
+		lt := LogTransform{Base: base}
+		if (lt.Forward(100) != 2) || (lt.Inverse(3) != 1000) {
+			t.Errorf("LogTransform with base %f not base 10:%f %f", base, lt.Forward(100), lt.Inverse(3))
+		}
+	}
+}
+
+func TestLogScalePoint(t *testing.T) {
+	lc := New(30, 12, 0, 10, 1, 1000, WithYTransform(NewLog10Transform()))
+	h := float64(lc.GraphHeight())
+	// each decade maps to one third of the graph height
+	for i, v := range []float64{1, 10, 100, 1000} {
+		sf := lc.scalePoint(canvas.Float64Point{X: 0, Y: v}, lc.GraphWidth(), lc.GraphHeight())
+		if exp := h * float64(i) / 3; math.Abs(sf.Y-exp) > 1e-9 {
+			t.Errorf("wrong scaled log value for %f:%f, expected %f", v, sf.Y, exp)
+		}
+	}
+	// zooming out by one decade
+	lc.SetYRange(0.1, 10000)
+	lc.ZoomOut(0, 1)
+	if (math.Abs(lc.ViewMinY()-0.1) > 1e-9) || (math.Abs(lc.ViewMaxY()-10000) > 1e-6) {
+		t.Errorf("wrong Y view range after zooming out:%f,%f", lc.ViewMinY(), lc.ViewMaxY())
+	}
+}
+
+func TestLogAutoAdjustRange(t *testing.T) {
+	lc := New(30, 12, 0, 1, 0, 1, WithAutoYRange(), WithYTransform(NewLog10Transform()))
+	// non-positive values are ignored and replace invalid expected minimum
+	lc.AutoAdjustRange(canvas.Float64Point{X: 0, Y: -5})
+	if lc.MinY() != 0 {
+		t.Errorf("invalid log value adjusted range:%f", lc.MinY())
+	}
+	lc.AutoAdjustRange(canvas.Float64Point{X: 0, Y: 0.5})
+	if (lc.MinY() != 0.5) || (lc.ViewMinY() != 0.5) {
+		t.Errorf("invalid log minimum not replaced:%f,%f", lc.MinY(), lc.ViewMinY())
+	}
+}
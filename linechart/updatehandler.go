@@ -9,6 +9,8 @@ package linechart
 // the viewport of the linechart
 
 import (
+	"math"
+
 	"github.com/NimbleMarkets/ntcharts/canvas"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -32,14 +34,7 @@ func XYAxesUpdateHandler(xIncrement, yIncrement float64) UpdateHandler {
 			keyXYHandler(m, msg, xIncrement, yIncrement)
 			keyXYZoomHandler(m, msg, xIncrement, yIncrement)
 		case tea.MouseMsg:
-			switch msg.Button {
-			case tea.MouseButtonWheelUp:
-				// zoom in limited values cannot cross
-				m.ZoomIn(xIncrement, yIncrement)
-			case tea.MouseButtonWheelDown:
-				// zoom out limited by max values
-				m.ZoomOut(xIncrement, yIncrement)
-			}
+			mouseZoomHandler(m, msg, xIncrement, yIncrement)
 			mouseActionXYHandler(m, msg, &lastPos, xIncrement, yIncrement)
 		}
 	}
@@ -59,14 +54,7 @@ func XAxisUpdateHandler(increment float64) UpdateHandler {
 			keyXHandler(m, msg, increment)
 			keyXZoomHandler(m, msg, increment)
 		case tea.MouseMsg:
-			switch msg.Button {
-			case tea.MouseButtonWheelUp:
-				// zoom in limited values cannot cross
-				m.ZoomIn(increment, 0)
-			case tea.MouseButtonWheelDown:
-				// zoom out limited by max values
-				m.ZoomOut(increment, 0)
-			}
+			mouseZoomHandler(m, msg, increment, 0)
 			mouseActionXHandler(m, msg, &lastPos, increment)
 		}
 	}
@@ -109,14 +97,7 @@ func YAxisUpdateHandler(increment float64) UpdateHandler {
 			keyYHandler(m, msg, increment)
 			keyYZoomHandler(m, msg, increment)
 		case tea.MouseMsg:
-			switch msg.Button {
-			case tea.MouseButtonWheelUp:
-				// zoom in limited values cannot cross
-				m.ZoomIn(0, increment)
-			case tea.MouseButtonWheelDown:
-				// zoom out limited by max values
-				m.ZoomOut(0, increment)
-			}
+			mouseZoomHandler(m, msg, 0, increment)
 			mouseActionYHandler(m, msg, &lastPos, increment)
 		}
 	}
@@ -145,71 +126,176 @@ func YAxisNoZoomUpdateHandler(increment float64) UpdateHandler {
 	}
 }
 
+// XYAxesBoxZoomUpdateHandler is used by linechart to enable
+// zooming into the X and Y values of a box selected by holding down
+// the mouse button and moving, zooming in and out with the mouse wheel
+// or page up and page down, moving the viewing window with the arrow keys,
+// and restoring zooms with the linechart ZoomKeyMap.
+// Uses linechart Canvas Keymap for keyboard messages.
+func XYAxesBoxZoomUpdateHandler(xIncrement, yIncrement float64) UpdateHandler {
+	return func(m *Model, tm tea.Msg) {
+		switch msg := tm.(type) {
+		case tea.KeyMsg:
+			keyXYHandler(m, msg, xIncrement, yIncrement)
+			keyXYZoomHandler(m, msg, xIncrement, yIncrement)
+			keyZoomHistoryHandler(m, msg)
+		case tea.MouseMsg:
+			mouseZoomHandler(m, msg, xIncrement, yIncrement)
+			mouseBoxZoomHandler(m, msg, true)
+		}
+	}
+}
+
+// XAxisBoxZoomUpdateHandler is used by linechart to enable
+// zooming into the X values of a range selected by holding down
+// the mouse button and moving, zooming in and out with the mouse wheel
+// or page up and page down, moving the viewing window with the arrow keys,
+// and restoring zooms with the linechart ZoomKeyMap.
+// There is only movement along the X axis with the given increment.
+// Uses linechart Canvas Keymap for keyboard messages.
+func XAxisBoxZoomUpdateHandler(increment float64) UpdateHandler {
+	return func(m *Model, tm tea.Msg) {
+		switch msg := tm.(type) {
+		case tea.KeyMsg:
+			keyXHandler(m, msg, increment)
+			keyXZoomHandler(m, msg, increment)
+			keyZoomHistoryHandler(m, msg)
+		case tea.MouseMsg:
+			mouseZoomHandler(m, msg, increment, 0)
+			mouseBoxZoomHandler(m, msg, false)
+		}
+	}
+}
+
 // ZoomIn will update display X and Y values to simulate
 // zooming into the linechart by given increments.
+// Increments are in the transformed space of the X and Y axes.
 func (m *Model) ZoomIn(x, y float64) {
-	m.SetViewXYRange(
-		m.viewMinX+x,
-		m.viewMaxX-x,
-		m.viewMinY+y,
-		m.viewMaxY-y,
+	m.setTransformedViewXYRange(
+		m.TransformX(m.viewMinX)+x,
+		m.TransformX(m.viewMaxX)-x,
+		m.TransformY(m.viewMinY)+y,
+		m.TransformY(m.viewMaxY)-y,
 	)
 }
 
 // ZoomOut will update display X and Y values to simulate
 // zooming into the linechart by given increments.
+// Increments are in the transformed space of the X and Y axes.
 func (m *Model) ZoomOut(x, y float64) {
-	m.SetViewXYRange(
-		m.viewMinX-x,
-		m.viewMaxX+x,
-		m.viewMinY-y,
-		m.viewMaxY+y,
+	m.setTransformedViewXYRange(
+		m.TransformX(m.viewMinX)-x,
+		m.TransformX(m.viewMaxX)+x,
+		m.TransformY(m.viewMinY)-y,
+		m.TransformY(m.viewMaxY)+y,
 	)
 }
 
+// ZoomInAt will update display X and Y values to simulate zooming into
+// the linechart by given increments anchored at given data point, such that
+// the data point remains at the same position of the graphing area.
+// Increments are in the transformed space of the X and Y axes.
+func (m *Model) ZoomInAt(f canvas.Float64Point, x, y float64) {
+	m.zoomAt(f, -2*x, -2*y)
+}
+
+// ZoomOutAt will update display X and Y values to simulate zooming out of
+// the linechart by given increments anchored at given data point, such that
+// the data point remains at the same position of the graphing area
+// unless limited by the expected values.
+// Increments are in the transformed space of the X and Y axes.
+func (m *Model) ZoomOutAt(f canvas.Float64Point, x, y float64) {
+	m.zoomAt(f, 2*x, 2*y)
+}
+
+// zoomAt changes the sizes of the displayed X and Y ranges by given amounts
+// in transformed space, keeping the relative position of given data point.
+func (m *Model) zoomAt(f canvas.Float64Point, dx, dy float64) {
+	tMinX, tMaxX := m.TransformX(m.viewMinX), m.TransformX(m.viewMaxX)
+	tMinY, tMaxY := m.TransformY(m.viewMinY), m.TransformY(m.viewMaxY)
+	minX, maxX := zoomRange(tMinX, tMaxX, m.TransformX(f.X), dx)
+	minY, maxY := zoomRange(tMinY, tMaxY, m.TransformY(f.Y), dy)
+	m.setTransformedViewXYRange(minX, maxX, minY, maxY)
+}
+
+// zoomRange returns the range from min to max changed in size
+// by given amount keeping the relative position of given value.
+func zoomRange(min, max, v, d float64) (float64, float64) {
+	if max <= min {
+		return min, max
+	}
+	r := (v - min) / (max - min) // relative position of value
+	r = math.Max(math.Min(r, 1), 0)
+	return min - r*d, max + (1-r)*d
+}
+
 // MoveLeft will update display Y values to simulate
-// moving left on the linechart by given increment
+// moving left on the linechart by given increment.
+// Increment is in the transformed space of the X axis.
 func (m *Model) MoveLeft(i float64) {
-	if (m.viewMinX - i) >= m.MinX() {
-		m.SetViewXRange(m.viewMinX-i, m.viewMaxX-i)
-	} else {
-		i = m.viewMinX - m.MinX()
-		m.SetViewXRange(m.viewMinX-i, m.viewMaxX-i)
+	tMin := m.TransformX(m.viewMinX)
+	tMax := m.TransformX(m.viewMaxX)
+	if (tMin - i) < m.TransformX(m.MinX()) {
+		i = tMin - m.TransformX(m.MinX())
 	}
+	m.setTransformedViewXRange(tMin-i, tMax-i)
 }
 
 // MoveRight will update display Y values to simulate
 // moving right on the linechart by given increment.
+// Increment is in the transformed space of the X axis.
 func (m *Model) MoveRight(i float64) {
-	if (m.viewMaxX + i) <= m.MaxX() {
-		m.SetViewXRange(m.viewMinX+i, m.viewMaxX+i)
-	} else {
-		i = m.MaxX() - m.viewMaxX
-		m.SetViewXRange(m.viewMinX+i, m.viewMaxX+i)
+	tMin := m.TransformX(m.viewMinX)
+	tMax := m.TransformX(m.viewMaxX)
+	if (tMax + i) > m.TransformX(m.MaxX()) {
+		i = m.TransformX(m.MaxX()) - tMax
 	}
+	m.setTransformedViewXRange(tMin+i, tMax+i)
 }
 
 // MoveUp will update display X values to simulate
 // moving up on the linechart chart by given increment.
+// Increment is in the transformed space of the Y axis.
 func (m *Model) MoveUp(i float64) {
-	if (m.viewMaxY + i) <= m.MaxY() {
-		m.SetViewYRange(m.viewMinY+i, m.viewMaxY+i)
-	} else {
-		i = m.MaxY() - m.viewMaxY
-		m.SetViewYRange(m.viewMinY+i, m.viewMaxY+i)
+	tMin := m.TransformY(m.viewMinY)
+	tMax := m.TransformY(m.viewMaxY)
+	if (tMax + i) > m.TransformY(m.MaxY()) {
+		i = m.TransformY(m.MaxY()) - tMax
 	}
+	m.setTransformedViewYRange(tMin+i, tMax+i)
 }
 
 // MoveDown will update display Y values to simulate
 // moving down on the linechart chart by given increment.
+// Increment is in the transformed space of the Y axis.
 func (m *Model) MoveDown(i float64) {
-	if (m.viewMinY - i) >= m.MinY() {
-		m.SetViewYRange(m.viewMinY-i, m.viewMaxY-i)
-	} else {
-		i = m.viewMinY - m.MinY()
-		m.SetViewYRange(m.viewMinY-i, m.viewMaxY-i)
+	tMin := m.TransformY(m.viewMinY)
+	tMax := m.TransformY(m.viewMaxY)
+	if (tMin - i) < m.TransformY(m.MinY()) {
+		i = tMin - m.TransformY(m.MinY())
 	}
+	m.setTransformedViewYRange(tMin-i, tMax-i)
+}
 
+// setTransformedViewXRange updates the displayed minimum and maximum X values
+// from given values in the transformed space of the X axis.
+func (m *Model) setTransformedViewXRange(min, max float64) bool {
+	xt := m.XTransform()
+	return m.SetViewXRange(xt.Inverse(min), xt.Inverse(max))
+}
+
+// setTransformedViewYRange updates the displayed minimum and maximum Y values
+// from given values in the transformed space of the Y axis.
+func (m *Model) setTransformedViewYRange(min, max float64) bool {
+	yt := m.YTransform()
+	return m.SetViewYRange(yt.Inverse(min), yt.Inverse(max))
+}
+
+// setTransformedViewXYRange updates the displayed minimum and maximum X and Y values
+// from given values in the transformed space of the X and Y axes.
+func (m *Model) setTransformedViewXYRange(minX, maxX, minY, maxY float64) {
+	m.setTransformedViewXRange(minX, maxX)
+	m.setTransformedViewYRange(minY, maxY)
 }
 
 // keyXYHandler handles keyboard messages for X and Y axis moving
@@ -276,6 +362,111 @@ func keyYZoomHandler(m *Model, msg tea.KeyMsg, yIncrement float64) {
 	}
 }
 
+// keyZoomHistoryHandler handles keyboard messages for the zoom history
+func keyZoomHistoryHandler(m *Model, msg tea.KeyMsg) {
+	switch {
+	case key.Matches(msg, m.ZoomKeyMap.Back):
+		m.ZoomBack()
+	case key.Matches(msg, m.ZoomKeyMap.Forward):
+		m.ZoomForward()
+	case key.Matches(msg, m.ZoomKeyMap.Home):
+		m.ZoomHome()
+	}
+}
+
+// mouseBoxZoomHandler handles mouse messages selecting a box to zoom into,
+// which spans the graphing area height if not zooming the Y axis
+func mouseBoxZoomHandler(m *Model, msg tea.MouseMsg, zoomY bool) {
+	if m.ZoneManager() == nil {
+		return
+	}
+	zInfo := m.ZoneManager().Get(m.ZoneID())
+	a := m.GraphArea()
+	switch msg.Action {
+	case tea.MouseActionPress:
+		if (msg.Button != tea.MouseButtonLeft) || !zInfo.InBounds(msg) {
+			return
+		}
+		x, y := zInfo.Pos(msg)
+		if p := (canvas.Point{X: x, Y: y}); p.In(a) {
+			m.selecting = true
+			m.selStart = p
+			m.selEnd = p
+			if !zoomY {
+				m.selStart.Y = a.Min.Y
+				m.selEnd.Y = a.Max.Y - 1
+			}
+		}
+	case tea.MouseActionMotion:
+		if !m.selecting || (msg.Button == tea.MouseButtonNone) || zInfo.IsZero() {
+			return
+		}
+		// selection continues outside of the linechart up to the graphing area
+		m.selEnd = canvas.Point{
+			X: max(min(msg.X-zInfo.StartX, a.Max.X-1), a.Min.X),
+			Y: max(min(msg.Y-zInfo.StartY, a.Max.Y-1), a.Min.Y),
+		}
+		if !zoomY {
+			m.selEnd.Y = a.Max.Y - 1
+		}
+	case tea.MouseActionRelease:
+		r, ok := m.Selection()
+		if !ok {
+			return
+		}
+		minX, maxX, minY, maxY, _ := m.SelectionRange()
+		m.selecting = false
+		switch {
+		case r.Dx() < 2: // ignore clicks without selecting
+		case !zoomY:
+			m.ZoomTo(minX, maxX, m.viewMinY, m.viewMaxY)
+		case r.Dy() >= 2:
+			m.ZoomTo(minX, maxX, minY, maxY)
+		}
+	}
+}
+
+// mouseZoomHandler handles mouse wheel messages for zooming anchored at
+// the data point under the mouse, or at the center of the graphing area
+// if the mouse position is not known
+func mouseZoomHandler(m *Model, msg tea.MouseMsg, xIncrement, yIncrement float64) {
+	f, ok := mouseDataPoint(m, msg)
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		// zoom in limited values cannot cross
+		if ok {
+			m.ZoomInAt(f, xIncrement, yIncrement)
+		} else {
+			m.ZoomIn(xIncrement, yIncrement)
+		}
+	case tea.MouseButtonWheelDown:
+		// zoom out limited by max values
+		if ok {
+			m.ZoomOutAt(f, xIncrement, yIncrement)
+		} else {
+			m.ZoomOut(xIncrement, yIncrement)
+		}
+	}
+}
+
+// mouseDataPoint returns the data point under the mouse
+// and whether the mouse is over the graphing area
+func mouseDataPoint(m *Model, msg tea.MouseMsg) (canvas.Float64Point, bool) {
+	if m.ZoneManager() == nil {
+		return canvas.Float64Point{}, false
+	}
+	zInfo := m.ZoneManager().Get(m.ZoneID())
+	if !zInfo.InBounds(msg) {
+		return canvas.Float64Point{}, false
+	}
+	x, y := zInfo.Pos(msg)
+	p := canvas.Point{X: x, Y: y}
+	if !p.In(m.GraphArea()) {
+		return canvas.Float64Point{}, false
+	}
+	return m.DataPoint(p), true
+}
+
 // mouseActionXYHandler handles mouse click messages for X and Y axes
 func mouseActionXYHandler(m *Model, msg tea.MouseMsg, lastPos *canvas.Point, xIncrement, yIncrement float64) {
 	if m.ZoneManager() == nil {
@@ -290,7 +481,7 @@ func mouseActionXYHandler(m *Model, msg tea.MouseMsg, lastPos *canvas.Point, xIn
 		}
 	case tea.MouseActionMotion:
 		zInfo := m.ZoneManager().Get(m.ZoneID())
-		if zInfo.InBounds(msg) {
+		if (msg.Button != tea.MouseButtonNone) && zInfo.InBounds(msg) { // ignore hovering
 			x, y := zInfo.Pos(msg)
 			if x > lastPos.X {
 				m.MoveRight(xIncrement)
@@ -321,7 +512,7 @@ func mouseActionXHandler(m *Model, msg tea.MouseMsg, lastPos *canvas.Point, incr
 		}
 	case tea.MouseActionMotion:
 		zInfo := m.ZoneManager().Get(m.ZoneID())
-		if zInfo.InBounds(msg) {
+		if (msg.Button != tea.MouseButtonNone) && zInfo.InBounds(msg) { // ignore hovering
 			x, y := zInfo.Pos(msg)
 			if x > lastPos.X {
 				m.MoveRight(increment)
@@ -348,7 +539,7 @@ func mouseActionYHandler(m *Model, msg tea.MouseMsg, lastPos *canvas.Point, incr
 		}
 	case tea.MouseActionMotion: // event occurs when mouse is pressed
 		zInfo := m.ZoneManager().Get(m.ZoneID())
-		if zInfo.InBounds(msg) {
+		if (msg.Button != tea.MouseButtonNone) && zInfo.InBounds(msg) { // ignore hovering
 			x, y := zInfo.Pos(msg)
 			if y > lastPos.Y {
 				m.MoveDown(increment)
@@ -0,0 +1,203 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package wavelinechart
+
+// File contains the keyboard data cursor of the wavelinechart
+// moving between the data points of the visible data sets.
+
+import (
+	"math"
+	"slices"
+
+	"github.com/NimbleMarkets/ntcharts/canvas"
+	"github.com/NimbleMarkets/ntcharts/linechart"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// UpdateCursor processes bubbletea key Msg matching the CursorKeyMap to
+// show, hide and move the data cursor, and returns whether the Msg was
+// used by the data cursor.  Called by Update before the UpdateHandler, and
+// should be called for charts receiving messages without Update.
+func (m *Model) UpdateCursor(tm tea.Msg) bool {
+	return m.UpdateDataCursor(m, tm)
+}
+
+// CursorDataSet returns the name of the data set of the data cursor.
+func (m *Model) CursorDataSet() string {
+	m.cursorPoint()
+	return m.cursorSet
+}
+
+// SetCursorDataSet moves the data cursor to the data point
+// nearest along the X axis of the data set given by name string.
+func (m *Model) SetCursorDataSet(n string) {
+	m.cursorSet = n
+	m.cursorFollow()
+}
+
+// CursorNextDataSet moves the data cursor to the nearest data point
+// of the visible data set given number of data sets after the
+// data set of the data cursor by name, or before if negative.
+func (m *Model) CursorNextDataSet(d int) {
+	linechart.NextCursorDataSet(m, d)
+}
+
+// CursorNext moves the data cursor to the data point
+// of its data set with the next greater X value.
+func (m *Model) CursorNext() {
+	m.cursorStep(1)
+}
+
+// CursorPrev moves the data cursor to the data point
+// of its data set with the next lesser X value.
+func (m *Model) CursorPrev() {
+	m.cursorStep(-1)
+}
+
+// CursorPoint returns the data point of the data cursor
+// and whether there is a data point to display.
+func (m *Model) CursorPoint() (canvas.Float64Point, bool) {
+	ds, i, ok := m.cursorPoint()
+	if !ok {
+		return canvas.Float64Point{}, false
+	}
+	return ds.pBuf.AtRaw(i), true
+}
+
+// CursorReadout returns lines of text displaying the data set name,
+// X and Y values and difference from the Y value of the previous
+// data point along the X axis of the data cursor.
+func (m *Model) CursorReadout() []string {
+	ds, i, ok := m.cursorPoint()
+	if !ok {
+		return nil
+	}
+	f := ds.pBuf.AtRaw(i)
+	var prev float64
+	j, hasPrev := ds.validStep(f.X, -1)
+	if hasPrev {
+		prev = ds.pBuf.AtRaw(j).Y
+	}
+	lines := []string{
+		"X: " + m.HoverXFormatter(0, f.X),
+		"Y: " + m.HoverYFormatter(0, f.Y),
+	}
+	return m.FormatCursorReadout(m.cursorSet, lines, f.Y, prev, hasPrev)
+}
+
+// DrawCursor draws the data cursor and its readout if the data cursor
+// is shown.  Should be called after drawing data to overlay the graphing area.
+func (m *Model) DrawCursor() {
+	if !m.Cursor() {
+		return
+	}
+	ds, i, ok := m.cursorPoint()
+	if !ok {
+		return
+	}
+	m.DrawCursorAt(canvas.CanvasPointFromFloat64Point(m.Origin(), ds.pBuf.At(i)), m.CursorReadout())
+}
+
+// CursorDataSets returns the names of visible data sets containing
+// data points, which the data cursor can be placed on, sorted by name.
+func (m *Model) CursorDataSets() []string {
+	names := m.DataSetNames()
+	r := names[:0]
+	for _, n := range names {
+		if m.DataSetVisible(n) && (m.dSets[n].pBuf.Length() > 0) {
+			r = append(r, n)
+		}
+	}
+	return r
+}
+
+// cursorPoint returns the data set and index of the data point of the data
+// cursor, and whether there is such a data point.  The data cursor is placed
+// on the data point nearest along the X axis to its X value, or to the maximum
+// displayed X value if not placed, of its data set or of the first visible data set.
+func (m *Model) cursorPoint() (*dataSet, int, bool) {
+	names := m.CursorDataSets()
+	if len(names) == 0 {
+		return nil, 0, false
+	}
+	if !slices.Contains(names, m.cursorSet) {
+		m.cursorSet = names[0]
+	}
+	if !m.cursorPlaced {
+		m.cursorX = m.ViewMaxX()
+	}
+	ds := m.dSets[m.cursorSet]
+	i, ok := ds.nearest(m.cursorX)
+	if ok {
+		m.cursorX = ds.pBuf.AtRaw(i).X
+		m.cursorPlaced = true
+	}
+	return ds, i, ok
+}
+
+// cursorStep moves the data cursor to the next data point of its data set
+// along the X axis in given direction ignoring NaN and infinite Y values.
+func (m *Model) cursorStep(d int) {
+	ds, _, ok := m.cursorPoint()
+	if !ok {
+		return
+	}
+	if j, ok := ds.validStep(m.cursorX, d); ok {
+		m.cursorX = ds.pBuf.AtRaw(j).X
+	}
+	m.cursorFollow()
+}
+
+// cursorFollow moves the displayed X values
+// to display the data point of the data cursor.
+func (m *Model) cursorFollow() {
+	if _, _, ok := m.cursorPoint(); !ok {
+		return
+	}
+	switch {
+	case m.cursorX < m.ViewMinX():
+		m.MoveLeft(m.TransformX(m.ViewMinX()) - m.TransformX(m.cursorX))
+	case m.cursorX > m.ViewMaxX():
+		m.MoveRight(m.TransformX(m.cursorX) - m.TransformX(m.ViewMaxX()))
+	default:
+		return
+	}
+	m.rescaleData()
+	m.fitYRange()
+}
+
+// nearest returns the index of the data point nearest along the X axis
+// to given X value ignoring NaN and infinite Y values,
+// and whether there is such a data point.
+func (ds *dataSet) nearest(x float64) (int, bool) {
+	r := -1
+	d := math.Inf(1)
+	for i, f := range ds.pBuf.ReadAllRaw() {
+		if isFinite(f.Y) && (math.Abs(f.X-x) < d) {
+			r, d = i, math.Abs(f.X-x)
+		}
+	}
+	return r, r >= 0
+}
+
+// validStep returns the index of the data point with the nearest X value
+// greater than given X value if given direction is positive, or lesser if
+// negative, ignoring NaN and infinite Y values, and whether there is one.
+func (ds *dataSet) validStep(x float64, d int) (int, bool) {
+	r := -1
+	for i, f := range ds.pBuf.ReadAllRaw() {
+		if !isFinite(f.Y) || ((f.X-x)*float64(d) <= 0) {
+			continue
+		}
+		if (r < 0) || ((f.X-ds.pBuf.AtRaw(r).X)*float64(d) < 0) {
+			r = i
+		}
+	}
+	return r, r >= 0
+}
+
+// isFinite returns whether given value is neither NaN nor infinite.
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
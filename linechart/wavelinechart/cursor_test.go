@@ -0,0 +1,92 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package wavelinechart
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/NimbleMarkets/ntcharts/canvas"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestCursor(t *testing.T) {
+	m := New(20, 10)
+	for i, v := range []float64{1, 2, math.NaN(), 5, 3} {
+		m.Plot(canvas.Float64Point{X: float64(i), Y: v})
+		m.PlotDataSet("b", canvas.Float64Point{X: float64(i), Y: float64(10 * i)})
+	}
+	m.Focus()
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	if !m.Cursor() {
+		t.Fatal("data cursor not shown")
+	}
+
+	// data cursor starts at the greatest X value of the first data set
+	if f, ok := m.CursorPoint(); !ok || (f.X != 4) || (f.Y != 10*4) {
+		t.Errorf("wrong initial data cursor:%v %s", f, m.CursorDataSet())
+	}
+
+	// tab and shift+tab cycle through the data sets keeping the X value
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if f, _ := m.CursorPoint(); (m.CursorDataSet() != DefaultDataSetName) || (f.Y != 3) {
+		t.Errorf("wrong data cursor after tab:%s %v", m.CursorDataSet(), f)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if m.CursorDataSet() != "b" {
+		t.Errorf("tab did not wrap around:%s", m.CursorDataSet())
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	if m.CursorDataSet() != DefaultDataSetName {
+		t.Errorf("wrong data set after shift+tab:%s", m.CursorDataSet())
+	}
+
+	// stepping skips NaN values
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	if f, _ := m.CursorPoint(); f.Y != 5 {
+		t.Errorf("wrong data cursor after left:%v", f)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	if f, _ := m.CursorPoint(); (f.X != 1) || (f.Y != 2) {
+		t.Errorf("data cursor did not skip NaN value:%v", f)
+	}
+
+	// difference is from the previous finite value along the X axis
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	r := m.CursorReadout()
+	if (len(r) != 4) || (r[0] != DefaultDataSetName) || !strings.HasPrefix(r[3], "Δ: +3") {
+		t.Errorf("wrong readout:%q", r)
+	}
+	m.CursorPrev()
+	m.CursorPrev()
+	m.CursorPrev()
+	if r := m.CursorReadout(); len(r) != 3 {
+		t.Errorf("expected no difference for first data point:%q", r)
+	}
+}
+
+func TestCursorFollow(t *testing.T) {
+	m := New(20, 10)
+	for i := 0; i < 10; i++ {
+		m.Plot(canvas.Float64Point{X: float64(i), Y: float64(i)})
+	}
+	m.SetViewXRange(2, 5)
+	m.SetCursor(true)
+	if f, _ := m.CursorPoint(); f.X != 5 {
+		t.Fatalf("wrong initial data cursor:%v", f)
+	}
+
+	// displayed X values follow the data cursor off the view
+	m.CursorNext()
+	if (m.ViewMinX() != 3) || (m.ViewMaxX() != 6) {
+		t.Errorf("view did not follow data cursor right:%f %f", m.ViewMinX(), m.ViewMaxX())
+	}
+	for i := 0; i < 5; i++ {
+		m.CursorPrev()
+	}
+	if (m.ViewMinX() != 1) || (m.ViewMaxX() != 4) {
+		t.Errorf("view did not follow data cursor left:%f %f", m.ViewMinX(), m.ViewMaxX())
+	}
+}
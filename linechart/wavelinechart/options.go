@@ -120,3 +120,178 @@ func WithDataSetPoints(n string, f []canvas.Float64Point) Option {
 		}
 	}
 }
+
+// WithMaxPoints sets the default maximum number of data points stored by data sets.
+func WithMaxPoints(n int) Option {
+	return func(m *Model) {
+		m.SetMaxPoints(n)
+	}
+}
+
+// WithDataSetMaxPoints sets the maximum number of data points
+// stored by the data set given by name.
+func WithDataSetMaxPoints(n string, max int) Option {
+	return func(m *Model) {
+		m.SetDataSetMaxPoints(n, max)
+	}
+}
+
+// WithMaxXAge sets the default maximum X age of data points stored by data sets
+// relative to the newest data point of each data set.
+func WithMaxXAge(a float64) Option {
+	return func(m *Model) {
+		m.SetMaxXAge(a)
+	}
+}
+
+// WithDataSetMaxXAge sets the maximum X age of data points stored by the data set
+// given by name relative to its newest data point.
+func WithDataSetMaxXAge(n string, a float64) Option {
+	return func(m *Model) {
+		m.SetDataSetMaxXAge(n, a)
+	}
+}
+
+// WithMaxXGap sets the default maximum X distance between data points
+// of data sets plotted consecutively before breaking the line between them.
+func WithMaxXGap(g float64) Option {
+	return func(m *Model) {
+		m.SetMaxXGap(g)
+	}
+}
+
+// WithDataSetMaxXGap sets the maximum X distance between data points of the
+// data set given by name plotted consecutively before breaking the line between them.
+func WithDataSetMaxXGap(n string, g float64) Option {
+	return func(m *Model) {
+		m.SetDataSetMaxXGap(n, g)
+	}
+}
+
+// WithEvictHandler sets the callback invoked for each data point
+// removed from data sets due to data set retention limits.
+func WithEvictHandler(h EvictHandler) Option {
+	return func(m *Model) {
+		m.SetEvictHandler(h)
+	}
+}
+
+// WithXTransform sets the X axis AxisTransform.
+func WithXTransform(t linechart.AxisTransform) Option {
+	return func(m *Model) {
+		m.SetXTransform(t)
+	}
+}
+
+// WithYTransform sets the Y axis AxisTransform.
+func WithYTransform(t linechart.AxisTransform) Option {
+	return func(m *Model) {
+		m.SetYTransform(t)
+	}
+}
+
+// WithXYTickLocators sets the TickLocators used to place
+// X and Y axes values instead of every number of steps.
+// If a TickLocator is nil, then axis values are placed using steps.
+func WithXYTickLocators(x, y linechart.TickLocator) Option {
+	return func(m *Model) {
+		m.SetXTickLocator(x)
+		m.SetYTickLocator(y)
+	}
+}
+
+// WithXYMinorTicks sets the number of minor tick intervals between
+// X and Y axes ticks placed by TickLocators.
+func WithXYMinorTicks(x, y int) Option {
+	return func(m *Model) {
+		m.SetXMinorTicks(x)
+		m.SetYMinorTicks(y)
+	}
+}
+
+// WithDataSetZOrder sets the z-order of the data set given by name string,
+// where data sets with greater z-order are drawn over others.
+func WithDataSetZOrder(n string, z int) Option {
+	return func(m *Model) {
+		m.SetDataSetZOrder(n, z)
+	}
+}
+
+// WithExcludeHiddenRange excludes data values pushed to hidden
+// data sets from automatic adjustment of the value ranges.
+func WithExcludeHiddenRange() Option {
+	return func(m *Model) {
+		m.SetExcludeHiddenRange(true)
+	}
+}
+
+// WithHover enables tracking the mouse over the graphing area for
+// drawing a crosshair and tooltip of the nearest data values with DrawHover.
+func WithHover() Option {
+	return func(m *Model) {
+		m.SetHover(true)
+	}
+}
+
+// WithHoverStyles sets the styles of the hover crosshair and tooltips.
+func WithHoverStyles(crosshair, tooltip lipgloss.Style) Option {
+	return func(m *Model) {
+		m.CrosshairStyle = crosshair
+		m.TooltipStyle = tooltip
+	}
+}
+
+// WithHoverFormatters sets the formatters for displaying
+// X and Y values of hover tooltips as strings.
+func WithHoverFormatters(x, y linechart.LabelFormatter) Option {
+	return func(m *Model) {
+		m.HoverXFormatter = x
+		m.HoverYFormatter = y
+	}
+}
+
+// WithCursor shows the keyboard data cursor drawn with DrawCursor.
+func WithCursor() Option {
+	return func(m *Model) {
+		m.SetCursor(true)
+	}
+}
+
+// WithCursorKeyMap sets the key bindings of the keyboard data cursor.
+func WithCursorKeyMap(k linechart.CursorKeyMap) Option {
+	return func(m *Model) {
+		m.CursorKeyMap = k
+	}
+}
+
+// WithCursorStyle sets the style applied to the data point of the data cursor.
+func WithCursorStyle(s lipgloss.Style) Option {
+	return func(m *Model) {
+		m.CursorStyle = s
+	}
+}
+
+// WithZoomKeyMap sets the key bindings of the zoom history.
+func WithZoomKeyMap(k linechart.ZoomKeyMap) Option {
+	return func(m *Model) {
+		m.ZoomKeyMap = k
+	}
+}
+
+// WithSelectionStyle sets the style of the box zoom selection.
+func WithSelectionStyle(s lipgloss.Style) Option {
+	return func(m *Model) {
+		m.SelectionStyle = s
+	}
+}
+
+// WithAutoFitY enables fitting the displayed Y values to the data points
+// within the displayed X values with given fraction of padding above
+// and below, and whether the fitted Y values include zero.
+func WithAutoFitY(padding float64, zero bool) Option {
+	return func(m *Model) {
+		m.SetAutoFitY(true)
+		m.SetAutoFitYPadding(padding)
+		m.SetAutoFitYZero(zero)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/NimbleMarkets/ntcharts/canvas/buffer"
 	"github.com/NimbleMarkets/ntcharts/canvas/graph"
 	"github.com/NimbleMarkets/ntcharts/canvas/runes"
+	"github.com/NimbleMarkets/ntcharts/legend"
 	"github.com/NimbleMarkets/ntcharts/linechart"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -19,12 +20,20 @@ import (
 
 const DefaultDataSetName = "default"
 
+// EvictHandler is a callback invoked with the data set name
+// and the data point being removed from a data set due to
+// the data set retention limits.
+type EvictHandler func(n string, f canvas.Float64Point)
+
 type dataSet struct {
 	LineStyle runes.LineStyle // type of line runes to draw
 	Style     lipgloss.Style
 
-	// stores data points from Plot() and contains scaled data points
-	pBuf *buffer.Float64PointScaleBuffer
+	maxXGap float64 // maximum X distance between data points without breaking the line, 0 if unlimited
+
+	// stores data points from Plot() and contains scaled data points.
+	// buffer grows as needed and removes data points exceeding retention limits
+	pBuf *buffer.RetentionRingBuffer
 }
 
 // Model contains state of a wavelinechart with an embedded linechart.Model
@@ -40,7 +49,20 @@ type Model struct {
 	linechart.Model
 	dLineStyle runes.LineStyle     // default data set LineStyletype
 	dStyle     lipgloss.Style      // default data set Style
+	dMaxPoints int                 // default data set maximum number of data points
+	dMaxXAge   float64             // default data set maximum X age of data points
+	dMaxXGap   float64             // default data set maximum X gap between data points
 	dSets      map[string]*dataSet // maps names to data sets
+
+	linechart.DataSetVisibility // visibility and z-order of data sets
+
+	evictHandler EvictHandler // callback for data points removed by retention limits
+
+	fitPending bool // whether plotted data points are not yet fitted by auto fit
+
+	cursorSet    string  // name of data set of the data cursor
+	cursorX      float64 // X value of data point of the data cursor
+	cursorPlaced bool    // whether the data cursor is placed on a data point
 }
 
 // New returns a wavelinechart Model initialized
@@ -68,21 +90,45 @@ func New(w, h int, opts ...Option) Model {
 
 // newDataSet returns a new initialize *dataSet.
 func (m *Model) newDataSet() *dataSet {
-	xs := float64(m.GraphWidth()) / (m.ViewMaxX() - m.ViewMinX()) // X scale factor
-	ys := float64(m.Origin().Y) / (m.ViewMaxY() - m.ViewMinY())   // y scale factor
+	offset, scale := m.dataScale()
 	ds := &dataSet{
 		LineStyle: m.dLineStyle,
 		Style:     m.dStyle,
-		pBuf: buffer.NewFloat64PointScaleBuffer(
-			canvas.Float64Point{X: m.ViewMinX(), Y: m.ViewMinY()},
-			canvas.Float64Point{X: xs, Y: ys}),
+		maxXGap:   m.dMaxXGap,
+		pBuf:      buffer.NewRetentionRingBuffer(offset, scale, m.PointScaleFunc()),
 	}
+	ds.pBuf.SetMaxPoints(m.dMaxPoints, nil)
+	ds.pBuf.SetMaxXAge(m.dMaxXAge, nil)
 	return ds
 }
 
-// getLineSequence returns a sequence of Y values
-// to draw line runes from a given set of scaled []FloatPoint64.
-func (m *Model) getLineSequence(points []canvas.Float64Point) (seqY []int) {
+// getDataSet returns the data set given by name string,
+// creating a new data set if it does not exist.
+func (m *Model) getDataSet(n string) *dataSet {
+	if _, ok := m.dSets[n]; !ok {
+		m.dSets[n] = m.newDataSet()
+	}
+	return m.dSets[n]
+}
+
+// evictFunc returns a function reporting data points removed
+// from the data set given by name string to the EvictHandler.
+// Returns nil if there is no EvictHandler.
+func (m *Model) evictFunc(n string) buffer.EvictFunc {
+	if m.evictHandler == nil {
+		return nil
+	}
+	return func(f canvas.Float64Point) {
+		m.evictHandler(n, f)
+	}
+}
+
+// getLineSequence returns a sequence of Y values to draw line runes
+// from the data points of a given data set, and the indices of the
+// sequence breaking the line.  Lines are broken at NaN and infinite Y values,
+// and between data points plotted consecutively with X values
+// further apart than the maximum X gap of the data set.
+func (m *Model) getLineSequence(ds *dataSet) (seqY []int, gaps []bool) {
 	// Create a []int storing canvas coordinates to draw line runes.
 	// Each index of the []int corresponds to a canvas column
 	// and the value of each index is the canvas row
@@ -100,10 +146,26 @@ func (m *Model) getLineSequence(points []canvas.Float64Point) (seqY []int) {
 			seqY[i] = m.Origin().Y
 		}
 	}
+	gaps = make([]bool, width)
+	points := ds.pBuf.ReadAll()
+	if ds.maxXGap > 0 {
+		raw := ds.pBuf.ReadAllRaw()
+		for i := 1; i < len(raw); i++ {
+			if math.Abs(raw[i].X-raw[i-1].X) <= ds.maxXGap {
+				continue
+			}
+			// break line in columns between data points
+			x1 := int(math.Round(math.Min(points[i-1].X, points[i].X)))
+			x2 := int(math.Round(math.Max(points[i-1].X, points[i].X)))
+			for x := max(x1+1, 0); (x < x2) && (x < width); x++ {
+				gaps[x] = true
+			}
+		}
+	}
 	// map data set containing scaled Float64Point data points
 	// onto graph row and column
 	for _, p := range points {
-		m.setLineSequencePoint(seqY, p)
+		m.setLineSequencePoint(seqY, gaps, p)
 	}
 	return
 }
@@ -111,10 +173,15 @@ func (m *Model) getLineSequence(points []canvas.Float64Point) (seqY []int) {
 // setLineSequencePoint will map a scaled Float64Point data point
 // on to a sequence of graph Y values.  Points mapping onto
 // existing indices of the sequence will override the existing value.
-func (m *Model) setLineSequencePoint(seqY []int, f canvas.Float64Point) {
+// Points with NaN or infinite Y values will set a gap in the sequence.
+func (m *Model) setLineSequencePoint(seqY []int, gaps []bool, f canvas.Float64Point) {
 	x := int(math.Round(f.X))
 	// avoid drawing outside graphing area
 	if (x >= 0) && (x < len(seqY)) {
+		gaps[x] = math.IsNaN(f.Y) || math.IsInf(f.Y, 0)
+		if gaps[x] {
+			return
+		}
 		// avoid drawing below X axis
 		seqY[x] = canvas.CanvasYCoordinate(m.Origin().Y, int(math.Round(f.Y)))
 		if (m.XStep() > 0) && (seqY[x] > m.Origin().Y) {
@@ -123,17 +190,65 @@ func (m *Model) setLineSequencePoint(seqY []int, f canvas.Float64Point) {
 	}
 }
 
+// dataScale returns the offset and scale factor used
+// to scale data points in the transformed space of the axes
+// to the graphing area.
+func (m *Model) dataScale() (offset, scale canvas.Float64Point) {
+	offset = canvas.Float64Point{X: m.TransformX(m.ViewMinX()), Y: m.TransformY(m.ViewMinY())}
+	scale.X = float64(m.GraphWidth()) / (m.TransformX(m.ViewMaxX()) - offset.X) // X scale factor
+	scale.Y = float64(m.Origin().Y) / (m.TransformY(m.ViewMaxY()) - offset.Y)   // y scale factor
+	return
+}
+
 // rescaleData will scale all internally stored data with new scale factor.
 func (m *Model) rescaleData() {
 	// rescale all data set graph points
-	xs := float64(m.GraphWidth()) / (m.ViewMaxX() - m.ViewMinX()) // X scale factor
-	ys := float64(m.Origin().Y) / (m.ViewMaxY() - m.ViewMinY())   // y scale factor
+	offset, scale := m.dataScale()
 	for _, ds := range m.dSets {
-		ds.pBuf.SetOffset(canvas.Float64Point{X: m.ViewMinX(), Y: m.ViewMinY()})
-		ds.pBuf.SetScale(canvas.Float64Point{X: xs, Y: ys}) // buffer rescales all raw data points
+		ds.pBuf.SetOffsetAndScale(offset, scale) // buffer rescales all raw data points
+	}
+}
+
+// fitYRange fits the displayed Y values to the Y values of data points
+// of visible data sets within the displayed X values if auto fit is enabled.
+func (m *Model) fitYRange() {
+	m.fitPending = false
+	if !m.AutoFitY() {
+		return
+	}
+	if m.FitViewYRange(m.yRange(m.ViewMinX(), m.ViewMaxX())) {
+		m.rescaleData()
+	}
+}
+
+// fitPlotted fits the displayed Y values if data points have been plotted
+// since the last fit.  Plotting data points only marks the fit as pending,
+// such that plotting many data points fits once when drawing or in Update.
+func (m *Model) fitPlotted() {
+	if m.fitPending {
+		m.fitYRange()
 	}
 }
 
+// yRange returns the minimum and maximum Y values of data points of visible
+// data sets between given X values, which are infinite if there are no such values.
+func (m *Model) yRange(minX, maxX float64) (lo, hi float64) {
+	yt := m.YTransform()
+	lo, hi = math.Inf(1), math.Inf(-1)
+	for n, ds := range m.dSets {
+		if !m.DataSetVisible(n) {
+			continue
+		}
+		for _, f := range ds.pBuf.ReadAllRaw() {
+			if (f.X >= minX) && (f.X <= maxX) && isFinite(f.Y) && yt.Valid(f.Y) {
+				lo = math.Min(lo, f.Y)
+				hi = math.Max(hi, f.Y)
+			}
+		}
+	}
+	return
+}
+
 // ClearAllData will reset stored data values in all data sets.
 func (m *Model) ClearAllData() {
 	for n := range m.dSets {
@@ -149,11 +264,90 @@ func (m *Model) ClearDataSet(n string) {
 	}
 }
 
+// DataSetNames returns the names of all data sets sorted by name.
+func (m *Model) DataSetNames() []string {
+	names := make([]string, 0, len(m.dSets))
+	for n := range m.dSets {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// HasDataSet returns whether the data set given by name string exists.
+func (m *Model) HasDataSet(n string) bool {
+	_, ok := m.dSets[n]
+	return ok
+}
+
+// DataSetLen returns the number of data values stored
+// by the data set given by name string.
+func (m *Model) DataSetLen(n string) int {
+	if ds, ok := m.dSets[n]; ok {
+		return ds.pBuf.Length()
+	}
+	return 0
+}
+
+// DataSetStyles returns the LineStyle and Style of the data set
+// given by name string, or the default styles if it does not exist.
+func (m *Model) DataSetStyles(n string) (runes.LineStyle, lipgloss.Style) {
+	if ds, ok := m.dSets[n]; ok {
+		return ds.LineStyle, ds.Style
+	}
+	return m.dLineStyle, m.dStyle
+}
+
+// Points returns the data points of the default data set.
+func (m *Model) Points() []canvas.Float64Point {
+	return m.DataSetPoints(DefaultDataSetName)
+}
+
+// DataSetPoints returns the data points of the data set
+// given by name string in the order they were plotted.
+func (m *Model) DataSetPoints(n string) []canvas.Float64Point {
+	if ds, ok := m.dSets[n]; ok {
+		return ds.pBuf.ReadAllRaw()
+	}
+	return []canvas.Float64Point{}
+}
+
+// RemoveDataSet will delete the data set given by name string
+// including its styles.  The default data set is replaced
+// by a new empty data set with the default styles.
+func (m *Model) RemoveDataSet(n string) {
+	delete(m.dSets, n)
+	m.DataSetVisibility.RemoveDataSet(n)
+	if n == DefaultDataSetName {
+		m.dSets[DefaultDataSetName] = m.newDataSet()
+	}
+}
+
+// RenameDataSet will rename the data set given by old name string
+// to the new name string, keeping its data and styles.
+// Renaming the default data set leaves a new empty default data set.
+// Returns false without renaming if the old data set does not exist
+// or a data set with the new name exists.
+func (m *Model) RenameDataSet(old, new string) bool {
+	ds, ok := m.dSets[old]
+	if _, exists := m.dSets[new]; !ok || exists {
+		return false
+	}
+	delete(m.dSets, old)
+	m.dSets[new] = ds
+	m.DataSetVisibility.RenameDataSet(old, new)
+	if old == DefaultDataSetName {
+		m.dSets[DefaultDataSetName] = m.newDataSet()
+	}
+	return true
+}
+
 // SetViewXRange updates the displayed minimum and maximum X values.
-// Existing data will be rescaled.
+// Existing data will be rescaled and Y values fitted if auto fit is enabled.
 func (m *Model) SetViewXRange(min, max float64) {
 	m.Model.SetViewXRange(min, max)
 	m.rescaleData()
+	m.fitYRange()
 }
 
 // SetViewYRange updates the displayed minimum and maximum Y values.
@@ -165,10 +359,12 @@ func (m *Model) SetViewYRange(min, max float64) {
 
 // SetViewXYRange updates the displayed minimum and maximum X and Y values.
 // Existing data will be rescaled.
+// Y values are replaced by fitted Y values if auto fit is enabled.
 func (m *Model) SetViewXYRange(minX, maxX, minY, maxY float64) {
 	m.Model.SetViewXRange(minX, maxX)
 	m.Model.SetViewYRange(minY, maxY)
 	m.rescaleData()
+	m.fitYRange()
 }
 
 // Resize will change wavelinechart display width and height.
@@ -178,6 +374,30 @@ func (m *Model) Resize(w, h int) {
 	m.rescaleData()
 }
 
+// SetXTransform sets the X axis AxisTransform.
+// Existing data will be rescaled.
+func (m *Model) SetXTransform(t linechart.AxisTransform) {
+	m.Model.SetXTransform(t)
+	m.resetScaleFunc()
+}
+
+// SetYTransform sets the Y axis AxisTransform.
+// Existing data will be rescaled.
+func (m *Model) SetYTransform(t linechart.AxisTransform) {
+	m.Model.SetYTransform(t)
+	m.resetScaleFunc()
+}
+
+// resetScaleFunc updates the ScaleFunc of all data sets
+// to the current axes transforms and rescales all data.
+func (m *Model) resetScaleFunc() {
+	fn := m.PointScaleFunc()
+	for _, ds := range m.dSets {
+		ds.pBuf.SetScaleFunc(fn)
+	}
+	m.rescaleData()
+}
+
 // SetStyles will set the default styles of data sets.
 func (m *Model) SetStyles(ls runes.LineStyle, s lipgloss.Style) {
 	m.dLineStyle = ls
@@ -195,6 +415,102 @@ func (m *Model) SetDataSetStyles(n string, ls runes.LineStyle, s lipgloss.Style)
 	ds.Style = s
 }
 
+// SetMaxPoints will set the default maximum number of data points
+// stored by data sets. The oldest data points will be removed
+// when plotting data points beyond the limit.  If 0, then there is no limit.
+func (m *Model) SetMaxPoints(n int) {
+	m.dMaxPoints = n
+	m.SetDataSetMaxPoints(DefaultDataSetName, n)
+}
+
+// SetDataSetMaxPoints will set the maximum number of data points
+// stored by the given data set by name string.
+// The oldest data points exceeding the limit will be removed.
+// If 0, then there is no limit.
+func (m *Model) SetDataSetMaxPoints(n string, max int) {
+	m.getDataSet(n).pBuf.SetMaxPoints(max, m.evictFunc(n))
+}
+
+// SetMaxXAge will set the default maximum X age of data points stored
+// by data sets, which is the distance between a data point X value
+// and the X value of the newest data point of the data set.
+// The oldest data points exceeding the age will be removed
+// when plotting newer data points, assuming X values are plotted
+// in increasing order.  If 0, then there is no limit.
+func (m *Model) SetMaxXAge(a float64) {
+	m.dMaxXAge = a
+	m.SetDataSetMaxXAge(DefaultDataSetName, a)
+}
+
+// SetDataSetMaxXAge will set the maximum X age of data points stored
+// by the given data set by name string relative to its newest data point.
+// The oldest data points exceeding the age will be removed.
+// If 0, then there is no limit.
+func (m *Model) SetDataSetMaxXAge(n string, a float64) {
+	m.getDataSet(n).pBuf.SetMaxXAge(a, m.evictFunc(n))
+}
+
+// SetMaxXGap will set the default maximum X distance between data points
+// of data sets plotted consecutively before breaking the line between them.
+// If 0, then lines are only broken at NaN Y values.
+func (m *Model) SetMaxXGap(g float64) {
+	m.dMaxXGap = g
+	m.SetDataSetMaxXGap(DefaultDataSetName, g)
+}
+
+// SetDataSetMaxXGap will set the maximum X distance between data points
+// of the given data set by name string plotted consecutively before
+// breaking the line between them.
+// If 0, then lines are only broken at NaN Y values.
+func (m *Model) SetDataSetMaxXGap(n string, g float64) {
+	if g < 0 {
+		g = 0
+	}
+	m.getDataSet(n).maxXGap = g
+}
+
+// SetEvictHandler will set the callback invoked for each data point
+// removed from data sets due to data set retention limits.
+// If nil, then removed data points are not reported.
+func (m *Model) SetEvictHandler(h EvictHandler) {
+	m.evictHandler = h
+}
+
+// SetDataSetVisible will set whether the data set given by name string
+// is drawn.  While hidden data sets are excluded from automatic range
+// adjustment, showing a data set adjusts the ranges to its data values
+// and hiding a data set resets the automatically adjusted Y values to
+// the data values of the visible data sets.
+// Y values are fitted to the visible data sets if auto fit is enabled.
+func (m *Model) SetDataSetVisible(n string, b bool) {
+	ds := m.getDataSet(n)
+	if !m.DataSetVisibility.SetDataSetVisible(n, b) {
+		return
+	}
+	defer m.fitYRange()
+	if !m.ExcludeHiddenRange() {
+		return
+	}
+	adjusted := false
+	if b {
+		for _, f := range ds.pBuf.ReadAllRaw() {
+			adjusted = m.AutoAdjustRange(f) || adjusted
+		}
+	} else {
+		adjusted = m.ResetAutoYRange(m.yRange(math.Inf(-1), math.Inf(1)))
+	}
+	if adjusted {
+		m.UpdateGraphSizes()
+		m.rescaleData()
+	}
+}
+
+// ToggleDataSetVisible will hide the data set given by
+// name string if it is drawn, and show it otherwise.
+func (m *Model) ToggleDataSetVisible(n string) {
+	m.SetDataSetVisible(n, !m.DataSetVisible(n))
+}
+
 // Plot will map a Float64Point data value to a canvas coordinates
 // to be displayed with Draw. Uses default data set.
 func (m *Model) Plot(f canvas.Float64Point) {
@@ -203,16 +519,16 @@ func (m *Model) Plot(f canvas.Float64Point) {
 
 // PlotDataSet will map a Float64Point data value to a canvas coordinates
 // to be displayed with Draw. Uses given data set by name string.
+// Data points with NaN or infinite Y values are displayed as gaps in the line.
 func (m *Model) PlotDataSet(n string, f canvas.Float64Point) {
-	if m.AutoAdjustRange(f) { // auto adjust x and y ranges if enabled
+	ds := m.getDataSet(n)
+	// auto adjust x and y ranges if enabled
+	if m.DataSetAdjustsRange(n) && m.AutoAdjustRange(f) {
 		m.UpdateGraphSizes()
 		m.rescaleData()
 	}
-	if _, ok := m.dSets[n]; !ok {
-		m.dSets[n] = m.newDataSet()
-	}
-	ds := m.dSets[n]
-	ds.pBuf.Push(f)
+	ds.pBuf.Push(f, m.evictFunc(n))
+	m.fitPending = true
 }
 
 // Draw will draw lines runes for each column
@@ -222,49 +538,115 @@ func (m *Model) Draw() {
 }
 
 // DrawAll will draw lines runes for each column
-// of the graphing area of the canvas for all data sets.
-// Will always draw default data set.
+// of the graphing area of the canvas for all visible data sets in z-order.
+// Will always draw default data set unless hidden.
 func (m *Model) DrawAll() {
 	names := make([]string, 0, len(m.dSets))
 	for n, ds := range m.dSets {
-		if (n == DefaultDataSetName) || (ds.pBuf.Length() > 0) {
+		if ((n == DefaultDataSetName) || (ds.pBuf.Length() > 0)) && m.DataSetVisible(n) {
 			names = append(names, n)
 		}
 	}
-	sort.Strings(names)
+	m.SortDrawOrder(names)
 	m.DrawDataSets(names)
 }
 
 // DrawDataSets will draw lines runes for each column
-// of the graphing area of the canvas for each data set given
-// by name strings.
+// of the graphing area of the canvas for each visible data set
+// given by name strings in order.
 func (m *Model) DrawDataSets(names []string) {
 	if len(names) == 0 {
 		return
 	}
+	m.fitPlotted()
 	m.Clear()
 	m.DrawXYAxisAndLabel()
 	for _, n := range names {
-		if ds, ok := m.dSets[n]; ok {
+		if ds, ok := m.dSets[n]; ok && m.DataSetVisible(n) {
 			startX := m.Origin().X
-			seqY := m.getLineSequence(ds.pBuf.ReadAll())
-			graph.DrawLineSequence(&m.Canvas,
+			seqY, gaps := m.getLineSequence(ds)
+			graph.DrawLineSequenceWithGaps(&m.Canvas,
 				true,
 				startX,
 				seqY,
+				gaps,
 				ds.LineStyle,
 				ds.Style)
 		}
 	}
 }
 
+// LegendEntries returns a legend entry with a line marker for each
+// data set containing data, sorted by name.
+func (m *Model) LegendEntries() []legend.Entry {
+	names := make([]string, 0, len(m.dSets))
+	for n, ds := range m.dSets {
+		if ds.pBuf.Length() > 0 {
+			names = append(names, n)
+		}
+	}
+	sort.Strings(names)
+	r := make([]legend.Entry, 0, len(names))
+	for _, n := range names {
+		ds := m.dSets[n]
+		r = append(r, legend.Entry{
+			Name:    n,
+			Marker:  legend.LineMarker(ds.LineStyle),
+			Style:   ds.Style,
+			DataSet: n,
+			Hidden:  !m.DataSetVisible(n),
+		})
+	}
+	return r
+}
+
+// DrawHover draws the crosshair and a tooltip of the data point nearest
+// to the mouse along the X axis of each visible data set if the mouse is
+// over the graphing area.  Should be called after drawing data to overlay
+// the graphing area.
+func (m *Model) DrawHover() {
+	f, ok := m.HoverPoint()
+	if !ok {
+		return
+	}
+	m.DrawCrosshair()
+	tx := m.TransformX(f.X)
+	var lines []string
+	for _, n := range m.DataSetNames() {
+		if !m.DataSetVisible(n) {
+			continue
+		}
+		ds := m.dSets[n]
+		var near canvas.Float64Point
+		found := false
+		d := math.Inf(1)
+		for _, p := range ds.pBuf.ReadAllRaw() {
+			if math.IsNaN(p.Y) || math.IsInf(p.Y, 0) { // skip gaps
+				continue
+			}
+			if dp := math.Abs(m.TransformX(p.X) - tx); dp < d {
+				near, found, d = p, true, dp
+			}
+		}
+		if found {
+			lines = append(lines, n+": "+m.HoverXFormatter(0, near.X)+", "+m.HoverYFormatter(0, near.Y))
+		}
+	}
+	m.DrawTooltip(lines)
+}
+
 // Update processes bubbletea Msg to by invoking
 // UpdateMsgHandlerFunc callback if wavelinechart is focused.
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	if !m.Focused() {
 		return m, nil
 	}
-	m.UpdateHandler(&m.Model, msg)
+	m.UpdateHover(msg)
+	if !m.UpdateCursor(msg) { // data cursor keys take precedence
+		m.UpdateHandler(&m.Model, msg)
+	}
 	m.rescaleData() // rescale data points to new viewing window
+	m.fitYRange()
+	m.SyncLinkGroup()
 	return m, nil
 }
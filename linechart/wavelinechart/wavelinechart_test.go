@@ -0,0 +1,168 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package wavelinechart
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/NimbleMarkets/ntcharts/canvas"
+	"github.com/NimbleMarkets/ntcharts/canvas/runes"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestRetention(t *testing.T) {
+	var names []string
+	var evicted []canvas.Float64Point
+	m := New(20, 10,
+		WithMaxPoints(3),
+		WithEvictHandler(func(n string, f canvas.Float64Point) {
+			names = append(names, n)
+			evicted = append(evicted, f)
+		}))
+	for i := 0; i < 5; i++ {
+		m.Plot(canvas.Float64Point{X: float64(i), Y: float64(i)})
+	}
+	ps := m.Points()
+	if (len(ps) != 3) || (ps[0].X != 2) {
+		t.Errorf("max points kept wrong data points:%v", ps)
+	}
+	if (len(evicted) != 2) || (evicted[0].X != 0) || (evicted[1].X != 1) {
+		t.Errorf("max points evicted wrong data points:%v", evicted)
+	}
+
+	// lowering max X age evicts data points far from the newest data point
+	evicted = nil
+	m.SetDataSetMaxXAge(DefaultDataSetName, 1)
+	if ps := m.Points(); (len(ps) != 2) || (ps[0].X != 3) {
+		t.Errorf("max X age kept wrong data points:%v", ps)
+	}
+	if (len(evicted) != 1) || (evicted[0].X != 2) {
+		t.Errorf("max X age evicted wrong data points:%v", evicted)
+	}
+	evicted = nil
+	m.Plot(canvas.Float64Point{X: 10, Y: 10})
+	if ps := m.Points(); (len(ps) != 1) || (len(evicted) != 2) {
+		t.Errorf("plot over max X age kept %v evicted %v", ps, evicted)
+	}
+
+	for _, n := range names {
+		if n != DefaultDataSetName {
+			t.Errorf("evicted from wrong data set:%s", n)
+		}
+	}
+
+	// new data sets use default retention limits
+	m.SetMaxXAge(0)
+	for i := 0; i < 5; i++ {
+		m.PlotDataSet("other", canvas.Float64Point{X: float64(i), Y: float64(i)})
+	}
+	if n := m.DataSetLen("other"); n != 3 {
+		t.Errorf("default max points kept %d data points", n)
+	}
+}
+
+func TestExcludeHiddenRange(t *testing.T) {
+	m := New(20, 10, WithExcludeHiddenRange())
+	for i := 0; i < 5; i++ {
+		m.Plot(canvas.Float64Point{X: float64(i), Y: float64(2 + 2*i)})
+		m.PlotDataSet("b", canvas.Float64Point{X: float64(i), Y: float64(-50 + 50*i)})
+	}
+	if (m.MinY() != -50) || (m.MaxY() != 150) {
+		t.Fatalf("wrong Y range:%f %f", m.MinY(), m.MaxY())
+	}
+	m.SetDataSetVisible("b", false)
+	if (m.MinY() != 2) || (m.MaxY() != 10) || (m.ViewMinY() != 2) || (m.ViewMaxY() != 10) {
+		t.Errorf("Y range not reset to visible data sets:%f %f", m.MinY(), m.MaxY())
+	}
+	m.ToggleDataSetVisible("b")
+	if (m.MinY() != -50) || (m.MaxY() != 150) {
+		t.Errorf("Y range not adjusted to shown data set:%f %f", m.MinY(), m.MaxY())
+	}
+}
+
+func TestAutoFitY(t *testing.T) {
+	m := New(20, 10, WithAutoFitY(0, false))
+	for i := 0; i < 10; i++ {
+		m.Plot(canvas.Float64Point{X: float64(i), Y: float64(i * i)})
+	}
+	m.SetViewXRange(2, 5)
+	if (m.ViewMinY() != 4) || (m.ViewMaxY() != 25) {
+		t.Errorf("Y values not fitted to panned view:%f %f", m.ViewMinY(), m.ViewMaxY())
+	}
+
+	// plotted data points are fitted when drawing, ignoring data points outside the view
+	m.Plot(canvas.Float64Point{X: 3.5, Y: 100})
+	m.Plot(canvas.Float64Point{X: 20, Y: 1000})
+	m.Plot(canvas.Float64Point{X: -10, Y: -1000})
+	m.DrawAll()
+	if (m.ViewMinX() != 2) || (m.ViewMaxX() != 5) {
+		t.Fatalf("view moved by plotting:%f %f", m.ViewMinX(), m.ViewMaxX())
+	}
+	if (m.ViewMinY() != 4) || (m.ViewMaxY() != 100) {
+		t.Errorf("Y values not fitted to plotted data points in view:%f %f", m.ViewMinY(), m.ViewMaxY())
+	}
+}
+
+func TestDataSets(t *testing.T) {
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color("4"))
+	m := New(20, 10, WithDataSetStyles("b", runes.ThinLineStyle, style))
+	m.Plot(canvas.Float64Point{X: 1, Y: 1})
+	m.Plot(canvas.Float64Point{X: 2, Y: 2})
+	m.PlotDataSet("b", canvas.Float64Point{X: 3, Y: 3})
+	if s := strings.Join(m.DataSetNames(), ","); s != "b,default" {
+		t.Errorf("wrong data set names:%s", s)
+	}
+	if !m.HasDataSet("b") || m.HasDataSet("c") {
+		t.Error("wrong existing data sets")
+	}
+	if (m.DataSetLen(DefaultDataSetName) != 2) || (m.DataSetLen("b") != 1) || (m.DataSetLen("c") != 0) {
+		t.Errorf("wrong data set lengths:%d %d", m.DataSetLen(DefaultDataSetName), m.DataSetLen("b"))
+	}
+	if ps := m.Points(); (len(ps) != 2) || (ps[1] != canvas.Float64Point{X: 2, Y: 2}) {
+		t.Errorf("wrong points:%v", ps)
+	}
+	if ls, s := m.DataSetStyles("b"); (ls != runes.ThinLineStyle) || (s.GetForeground() != style.GetForeground()) {
+		t.Error("wrong data set styles")
+	}
+	if ls, _ := m.DataSetStyles("c"); ls != runes.ArcLineStyle {
+		t.Error("expected default styles of missing data set")
+	}
+
+	// renaming keeps data, styles and visibility
+	m.SetDataSetVisible("b", false)
+	m.SetDataSetZOrder("b", 2)
+	if m.RenameDataSet("b", DefaultDataSetName) || m.RenameDataSet("c", "d") {
+		t.Error("expected renaming to existing or from missing data sets to fail")
+	}
+	if !m.RenameDataSet("b", "c") {
+		t.Fatal("expected renaming to succeed")
+	}
+	if m.HasDataSet("b") || (m.DataSetPoints("c")[0] != canvas.Float64Point{X: 3, Y: 3}) {
+		t.Error("data set not renamed")
+	}
+	if ls, _ := m.DataSetStyles("c"); (ls != runes.ThinLineStyle) || m.DataSetVisible("c") || (m.DataSetZOrder("c") != 2) {
+		t.Error("renamed data set settings not kept")
+	}
+	if !m.DataSetVisible("b") || (m.DataSetZOrder("b") != 0) {
+		t.Error("old name settings not reset")
+	}
+	if !m.RenameDataSet(DefaultDataSetName, "a") || (m.DataSetLen(DefaultDataSetName) != 0) || (m.DataSetLen("a") != 2) {
+		t.Error("expected renaming default data set to leave empty default data set")
+	}
+
+	// removing deletes data, styles and visibility
+	m.RemoveDataSet("c")
+	if m.HasDataSet("c") || !m.DataSetVisible("c") {
+		t.Error("data set not removed")
+	}
+	m.PlotDataSet("c", canvas.Float64Point{X: 5, Y: 5})
+	if ls, _ := m.DataSetStyles("c"); ls != runes.ArcLineStyle {
+		t.Error("expected recreated data set with default styles")
+	}
+	m.RemoveDataSet(DefaultDataSetName)
+	if !m.HasDataSet(DefaultDataSetName) {
+		t.Error("expected default data set to be replaced")
+	}
+}
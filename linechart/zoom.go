@@ -0,0 +1,158 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package linechart
+
+// File contains the box zoom selection of the linechart
+// and the history of displayed ranges changed by zooming.
+
+import (
+	"image"
+
+	"github.com/NimbleMarkets/ntcharts/canvas"
+	"github.com/NimbleMarkets/ntcharts/canvas/runes"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// ZoomKeyMap contains the key bindings of the zoom history.
+type ZoomKeyMap struct {
+	Back    key.Binding // restore displayed ranges before the last zoom
+	Forward key.Binding // restore displayed ranges undone by Back
+	Home    key.Binding // display the expected ranges
+}
+
+// DefaultZoomKeyMap returns a default ZoomKeyMap for linechart.
+func DefaultZoomKeyMap() ZoomKeyMap {
+	return ZoomKeyMap{
+		Back: key.NewBinding(
+			key.WithKeys("b", "backspace"),
+			key.WithHelp("b", "zoom back"),
+		),
+		Forward: key.NewBinding(
+			key.WithKeys("f"),
+			key.WithHelp("f", "zoom forward"),
+		),
+		Home: key.NewBinding(
+			key.WithKeys("home"),
+			key.WithHelp("home", "reset zoom"),
+		),
+	}
+}
+
+// viewRanges returns the displayed minimum and maximum X and Y values.
+func (m *Model) viewRanges() [4]float64 {
+	return [4]float64{m.viewMinX, m.viewMaxX, m.viewMinY, m.viewMaxY}
+}
+
+// ZoomTo updates the displayed minimum and maximum X and Y values,
+// bounded by the expected values, and adds the previously displayed
+// values to the zoom history if the displayed values have changed.
+func (m *Model) ZoomTo(minX, maxX, minY, maxY float64) {
+	prev := m.viewRanges()
+	m.SetViewXYRange(minX, maxX, minY, maxY)
+	if m.viewRanges() != prev {
+		m.zoomBack = append(m.zoomBack, prev)
+		m.zoomForward = nil
+	}
+}
+
+// ZoomHome displays the expected minimum and maximum X and Y values
+// and adds the previously displayed values to the zoom history.
+func (m *Model) ZoomHome() {
+	m.ZoomTo(m.minX, m.maxX, m.minY, m.maxY)
+}
+
+// ZoomBack restores the displayed values before the last ZoomTo
+// and returns whether there was a previous zoom to restore.
+func (m *Model) ZoomBack() bool {
+	n := len(m.zoomBack)
+	if n == 0 {
+		return false
+	}
+	m.zoomForward = append(m.zoomForward, m.viewRanges())
+	v := m.zoomBack[n-1]
+	m.zoomBack = m.zoomBack[:n-1]
+	m.SetViewXYRange(v[0], v[1], v[2], v[3])
+	return true
+}
+
+// ZoomForward restores the displayed values before the last ZoomBack
+// and returns whether there was a zoom to restore.
+func (m *Model) ZoomForward() bool {
+	n := len(m.zoomForward)
+	if n == 0 {
+		return false
+	}
+	m.zoomBack = append(m.zoomBack, m.viewRanges())
+	v := m.zoomForward[n-1]
+	m.zoomForward = m.zoomForward[:n-1]
+	m.SetViewXYRange(v[0], v[1], v[2], v[3])
+	return true
+}
+
+// ZoomHistory returns the number of zooms that
+// can be restored by ZoomBack and ZoomForward.
+func (m *Model) ZoomHistory() (back, forward int) {
+	return len(m.zoomBack), len(m.zoomForward)
+}
+
+// ClearZoomHistory removes all zooms from the zoom history.
+func (m *Model) ClearZoomHistory() {
+	m.zoomBack = nil
+	m.zoomForward = nil
+}
+
+// Selection returns the canvas area of the box zoom selection
+// being dragged with the mouse, and whether there is a selection.
+func (m *Model) Selection() (image.Rectangle, bool) {
+	if !m.selecting {
+		return image.Rectangle{}, false
+	}
+	r := image.Rectangle{Min: m.selStart, Max: m.selEnd}.Canon()
+	r.Max = r.Max.Add(image.Pt(1, 1)) // include cells at the end of the selection
+	return r, true
+}
+
+// SelectionRange returns the minimum and maximum X and Y data values
+// of the box zoom selection, and whether there is a selection.
+func (m *Model) SelectionRange() (minX, maxX, minY, maxY float64, ok bool) {
+	r, ok := m.Selection()
+	if !ok {
+		return
+	}
+	// canvas Y coordinates increase downwards
+	lo := m.DataPoint(canvas.Point{X: r.Min.X, Y: r.Max.Y - 1})
+	hi := m.DataPoint(canvas.Point{X: r.Max.X - 1, Y: r.Min.Y})
+	return lo.X, hi.X, lo.Y, hi.Y, true
+}
+
+// DrawSelection draws the border of the box zoom selection
+// with the selection style over the graphing area if there is a selection.
+func (m *Model) DrawSelection() {
+	r, ok := m.Selection()
+	if !ok {
+		return
+	}
+	r = r.Intersect(m.GraphArea())
+	if r.Empty() {
+		return
+	}
+	last := r.Max.Sub(image.Pt(1, 1))
+	for x := r.Min.X; x <= last.X; x++ {
+		m.setSelectionCell(canvas.Point{X: x, Y: r.Min.Y}, runes.LineHorizontal)
+		m.setSelectionCell(canvas.Point{X: x, Y: last.Y}, runes.LineHorizontal)
+	}
+	for y := r.Min.Y; y <= last.Y; y++ {
+		m.setSelectionCell(canvas.Point{X: r.Min.X, Y: y}, runes.LineVertical)
+		m.setSelectionCell(canvas.Point{X: last.X, Y: y}, runes.LineVertical)
+	}
+	m.setSelectionCell(r.Min, runes.LineDownRight)
+	m.setSelectionCell(canvas.Point{X: last.X, Y: r.Min.Y}, runes.LineDownLeft)
+	m.setSelectionCell(canvas.Point{X: r.Min.X, Y: last.Y}, runes.LineUpRight)
+	m.setSelectionCell(last, runes.LineUpLeft)
+}
+
+// setSelectionCell sets rune with the selection style at given canvas coordinates.
+func (m *Model) setSelectionCell(p canvas.Point, r rune) {
+	m.Canvas.SetCell(p, canvas.NewCellWithStyle(r, m.SelectionStyle))
+}
@@ -0,0 +1,76 @@
+// ntcharts - Copyright (c) 2024 Neomantra Corp.
+
+package linechart
+
+import (
+	"math"
+	"testing"
+
+	"github.com/NimbleMarkets/ntcharts/canvas"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestZoomHistory(t *testing.T) {
+	lc := New(30, 12, 0, 100, 0, 10, WithUpdateHandler(XYAxesBoxZoomUpdateHandler(1, 1)))
+	lc.Focus()
+	lc.ZoomTo(10, 20, 2, 4)
+	lc.ZoomTo(12, 14, 2, 4)
+	lc.ZoomTo(12, 14, 2, 4) // unchanged ranges are not added
+	if back, forward := lc.ZoomHistory(); (back != 2) || (forward != 0) {
+		t.Errorf("wrong zoom history:%d,%d", back, forward)
+	}
+
+	lc, _ = lc.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}})
+	if (lc.ViewMinX() != 10) || (lc.ViewMaxX() != 20) {
+		t.Errorf("zoom back restored:%f,%f", lc.ViewMinX(), lc.ViewMaxX())
+	}
+	lc, _ = lc.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	if (lc.ViewMinX() != 12) || (lc.ViewMaxX() != 14) {
+		t.Errorf("zoom forward restored:%f,%f", lc.ViewMinX(), lc.ViewMaxX())
+	}
+	lc, _ = lc.Update(tea.KeyMsg{Type: tea.KeyHome})
+	if (lc.ViewMinX() != 0) || (lc.ViewMaxX() != 100) || (lc.ViewMinY() != 0) || (lc.ViewMaxY() != 10) {
+		t.Errorf("zoom home displayed:%f,%f,%f,%f", lc.ViewMinX(), lc.ViewMaxX(), lc.ViewMinY(), lc.ViewMaxY())
+	}
+
+	// zooming after going back removes forward zooms
+	lc.ZoomBack()
+	lc.ZoomTo(50, 60, 0, 10)
+	if back, forward := lc.ZoomHistory(); (back != 3) || (forward != 0) {
+		t.Errorf("wrong zoom history after zooming:%d,%d", back, forward)
+	}
+}
+
+func TestSelection(t *testing.T) {
+	lc := New(11, 6, 0, 10, 0, 10, WithXYSteps(0, 0))
+	lc.selecting = true
+	lc.selStart = canvas.Point{X: 8, Y: 4}
+	lc.selEnd = canvas.Point{X: 2, Y: 1}
+	lc.DrawSelection()
+	want := "           \n" +
+		"  ┌─────┐  \n" +
+		"  │     │  \n" +
+		"  │     │  \n" +
+		"  └─────┘  \n" +
+		"           "
+	if got := lc.Canvas.View(); got != want {
+		t.Errorf("wrong selection:\n%s\nexpected:\n%s", got, want)
+	}
+	minX, maxX, minY, maxY, ok := lc.SelectionRange()
+	if !ok || (minX != 2) || (maxX != 8) || (minY != 2) || (maxY != 8) {
+		t.Errorf("wrong selection range:%f,%f,%f,%f", minX, maxX, minY, maxY)
+	}
+}
+
+func TestZoomAt(t *testing.T) {
+	lc := New(11, 6, 0, 10, 0, 10, WithXYSteps(0, 0))
+	near := func(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+	lc.ZoomInAt(canvas.Float64Point{X: 2, Y: 8}, 1, 1)
+	if !near(lc.ViewMinX(), 0.4) || !near(lc.ViewMaxX(), 8.4) || !near(lc.ViewMinY(), 1.6) || !near(lc.ViewMaxY(), 9.6) {
+		t.Errorf("zoom in displayed:%f,%f,%f,%f", lc.ViewMinX(), lc.ViewMaxX(), lc.ViewMinY(), lc.ViewMaxY())
+	}
+	lc.ZoomOutAt(canvas.Float64Point{X: 2, Y: 8}, 1, 1)
+	if !near(lc.ViewMinX(), 0) || !near(lc.ViewMaxX(), 10) || !near(lc.ViewMinY(), 0) || !near(lc.ViewMaxY(), 10) {
+		t.Errorf("zoom out displayed:%f,%f,%f,%f", lc.ViewMinX(), lc.ViewMaxX(), lc.ViewMinY(), lc.ViewMaxY())
+	}
+}